@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"runtime"
 	"strconv"
@@ -17,6 +19,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
@@ -129,6 +132,48 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	return options, nil
 }
 
+// maxBuildSecretsSize is the maximum size of the JSON encoded secrets of a
+// build.
+const maxBuildSecretsSize = 10 * 1024 * 1024
+
+// readBuildSecrets reads the secrets sent along with the build context, in a
+// multipart/mixed body made of the JSON encoded secrets followed by the build
+// context. It returns the secrets, and the reader of the build context, which
+// is the body itself if there are no secrets.
+func readBuildSecrets(ctx context.Context, r *http.Request) (map[string][]byte, io.ReadCloser, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" || versions.LessThan(httputils.VersionFromContext(ctx), "1.30") {
+		return nil, r.Body, nil
+	}
+	if runtime.GOOS != "linux" {
+		return nil, nil, fmt.Errorf("the daemon on this platform does not support --secret to build")
+	}
+
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	part, err := mr.NextPart()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid build secrets: %v", err)
+	}
+	if ct := part.Header.Get("Content-Type"); ct != "application/json" {
+		return nil, nil, fmt.Errorf("invalid build secrets: unexpected content type %q", ct)
+	}
+	var secrets = map[string][]byte{}
+	if err := json.NewDecoder(io.LimitReader(part, maxBuildSecretsSize)).Decode(&secrets); err != nil {
+		return nil, nil, fmt.Errorf("invalid build secrets: %v", err)
+	}
+	for id := range secrets {
+		if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+			return nil, nil, fmt.Errorf("invalid build secret id %q: must not be a path", id)
+		}
+	}
+
+	part, err = mr.NextPart()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid build context: %v", err)
+	}
+	return secrets, ioutils.NewReadCloserWrapper(part, r.Body.Close), nil
+}
+
 type syncWriter struct {
 	w  io.Writer
 	mu sync.Mutex
@@ -184,6 +229,12 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 	}
 	buildOptions.AuthConfigs = authConfigs
 
+	secrets, buildContext, err := readBuildSecrets(ctx, r)
+	if err != nil {
+		return errf(err)
+	}
+	buildOptions.Secrets = secrets
+
 	remoteURL := r.FormValue("remote")
 
 	// Currently, only used if context is from a remote url.
@@ -211,7 +262,7 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 		ProgressReaderFunc: createProgressReader,
 	}
 
-	imgID, err := br.backend.BuildFromContext(ctx, r.Body, remoteURL, buildOptions, pg)
+	imgID, err := br.backend.BuildFromContext(ctx, buildContext, remoteURL, buildOptions, pg)
 	if err != nil {
 		return errf(err)
 	}
@@ -225,3 +276,20 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 
 	return nil
 }
+
+func (br *buildRouter) postPrune(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	pruneFilters, err := filters.FromParam(r.Form.Get("filters"))
+	if err != nil {
+		return err
+	}
+
+	pruneReport, err := br.backend.PruneCache(pruneFilters)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
+}
@@ -100,6 +100,10 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 		ShowStderr: stderr,
 		Details:    httputils.BoolValue(r, "details"),
 	}
+	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.30") {
+		logsConfig.Until = r.Form.Get("until")
+		logsConfig.Grep = r.Form.Get("grep")
+	}
 
 	// doesn't matter what version the client is on, we're using this internally only
 	// also do we need size? i'm thinkin no we don't
@@ -9,12 +9,14 @@ import (
 	"strconv"
 	"strings"
 
+	apierrors "github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/registry"
@@ -106,7 +108,13 @@ func (s *imageRouter) postImagesCreate(ctx context.Context, w http.ResponseWrite
 			}
 		}
 
-		err = s.backend.PullImage(ctx, image, tag, metaHeaders, authConfig, output)
+		var maxBandwidth int64
+		maxBandwidth, err = maxBandwidthValue(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		err = s.backend.PullImage(ctx, image, tag, metaHeaders, authConfig, maxBandwidth, output)
 	} else { //import
 		src := r.Form.Get("fromSrc")
 		// 'err' MUST NOT be defined within this block, we need any error
@@ -155,12 +163,31 @@ func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter,
 	image := vars["name"]
 	tag := r.Form.Get("tag")
 
+	layerCompression := archive.Gzip
+	if c := r.Form.Get("compression"); c != "" && versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.30") {
+		compression, err := archive.ParseCompression(c)
+		if err != nil || (compression != archive.Gzip && compression != archive.Zstd) {
+			return apierrors.NewBadRequestError(fmt.Errorf("invalid layer compression %q: must be gzip or zstd", c))
+		}
+		if compression == archive.Zstd {
+			if err := archive.CheckZstd(); err != nil {
+				return err
+			}
+		}
+		layerCompression = compression
+	}
+
+	maxBandwidth, err := maxBandwidthValue(ctx, r)
+	if err != nil {
+		return err
+	}
+
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
 
 	w.Header().Set("Content-Type", "application/json")
 
-	if err := s.backend.PushImage(ctx, image, tag, metaHeaders, authConfig, output); err != nil {
+	if err := s.backend.PushImage(ctx, image, tag, metaHeaders, authConfig, layerCompression, maxBandwidth, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -175,6 +202,16 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		return err
 	}
 
+	var format string
+	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.30") {
+		format = r.Form.Get("format")
+		switch format {
+		case "", "docker", "oci":
+		default:
+			return apierrors.NewBadRequestError(fmt.Errorf("invalid format %q: must be docker or oci", format))
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/x-tar")
 
 	output := ioutils.NewWriteFlusher(w)
@@ -186,7 +223,7 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		names = r.Form["names"]
 	}
 
-	if err := s.backend.ExportImage(names, output); err != nil {
+	if err := s.backend.ExportImage(names, format, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -342,3 +379,17 @@ func (s *imageRouter) postImagesPrune(ctx context.Context, w http.ResponseWriter
 	}
 	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
 }
+
+// maxBandwidthValue returns the max number of bytes per second of the layer
+// transfers of a pull or push request, or zero if it is not limited.
+func maxBandwidthValue(ctx context.Context, r *http.Request) (int64, error) {
+	value := r.Form.Get("maxbandwidth")
+	if value == "" || versions.LessThan(httputils.VersionFromContext(ctx), "1.30") {
+		return 0, nil
+	}
+	maxBandwidth, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || maxBandwidth < 0 {
+		return 0, apierrors.NewBadRequestError(fmt.Errorf("invalid max bandwidth %q: must be a number of bytes per second, or 0 for no limit", value))
+	}
+	return maxBandwidth, nil
+}
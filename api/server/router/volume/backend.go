@@ -1,6 +1,8 @@
 package volume
 
 import (
+	"io"
+
 	// TODO return types need to be refactored into pkg
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -9,9 +11,12 @@ import (
 // Backend is the methods that need to be implemented to provide
 // volume specific functionality
 type Backend interface {
-	Volumes(filter string) ([]*types.Volume, []string, error)
-	VolumeInspect(name string) (*types.Volume, error)
+	Volumes(filter string, refreshUsage bool) ([]*types.Volume, []string, error)
+	VolumeInspect(name string, refreshUsage bool) (*types.Volume, error)
 	VolumeCreate(name, driverName string, opts, labels map[string]string) (*types.Volume, error)
 	VolumeRm(name string, force bool) error
 	VolumesPrune(pruneFilters filters.Args) (*types.VolumesPruneReport, error)
+	VolumeClone(source, name string, labels map[string]string) (*types.Volume, error)
+	VolumeExport(name string) (io.ReadCloser, error)
+	VolumeImport(name string, data io.Reader) error
 }
@@ -98,6 +98,9 @@ type ExecProcessConfig struct {
 type ContainerCommitConfig struct {
 	types.ContainerCommitConfig
 	Changes []string
+	// BuildCache marks the image as build cache, for images committed by
+	// the builder.
+	BuildCache bool
 }
 
 // ProgressWriter is a data object to transport progress streams to the client
@@ -74,10 +74,12 @@ type ContainerLogsOptions struct {
 	ShowStdout bool
 	ShowStderr bool
 	Since      string
+	Until      string
 	Timestamps bool
 	Follow     bool
 	Tail       string
 	Details    bool
+	Grep       string
 }
 
 // ContainerRemoveOptions holds parameters to remove containers.
@@ -178,6 +180,10 @@ type ImageBuildOptions struct {
 	SecurityOpt []string
 	ExtraHosts  []string // List of extra hosts
 	Target      string
+	// Secrets are the contents of the secrets made available to the RUN
+	// instructions of the build, by secret id. They are never committed to
+	// the image.
+	Secrets map[string][]byte
 }
 
 // ImageBuildResponse holds information
@@ -224,6 +230,14 @@ type ImagePullOptions struct {
 	All           bool
 	RegistryAuth  string // RegistryAuth is the base64 encoded credentials for the registry
 	PrivilegeFunc RequestPrivilegeFunc
+	// MaxBandwidth is the max number of bytes per second downloaded by
+	// the pull, or uploaded by the push. The transfer is only limited by
+	// the daemon if it is zero.
+	MaxBandwidth int64
+	// Compression is the compression of the layers uploaded by the push,
+	// "gzip" or "zstd". The layers are compressed with gzip if it is empty.
+	// It is ignored by the pull.
+	Compression string
 }
 
 // RequestPrivilegeFunc is a function interface that
@@ -243,6 +257,13 @@ type ImageRemoveOptions struct {
 	PruneChildren bool
 }
 
+// ImageSaveOptions holds parameters to save images.
+type ImageSaveOptions struct {
+	// Format is the format of the archive, "docker" or "oci" for an OCI
+	// image layout. The docker format is used if it is empty.
+	Format string
+}
+
 // ImageSearchOptions holds parameters to search images with.
 type ImageSearchOptions struct {
 	RegistryAuth  string
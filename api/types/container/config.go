@@ -16,6 +16,8 @@ type HealthConfig struct {
 	// {"NONE"} : disable healthcheck
 	// {"CMD", args...} : exec arguments directly
 	// {"CMD-SHELL", command} : run command with system's default shell
+	// {"HTTP", url} : GET url from within the container's network namespace
+	// {"TCP", "[host:]port"} : connect from within the container's network namespace
 	Test []string `json:",omitempty"`
 
 	// Zero means to inherit. Durations are expressed as integer nanoseconds.
@@ -2,6 +2,7 @@ package container
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/mount"
@@ -229,6 +230,14 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              string
 	MaximumRetryCount int
+
+	// Backoff settings applied between restarts. Zero means to use the
+	// daemon defaults. Durations are expressed as integer nanoseconds.
+	InitialDelay time.Duration `json:",omitempty"` // InitialDelay is the delay before the first restart.
+	MaxDelay     time.Duration `json:",omitempty"` // MaxDelay caps the delay between two restarts.
+	Multiplier   float64       `json:",omitempty"` // Multiplier is applied to the delay after each restart.
+	ResetWindow  time.Duration `json:",omitempty"` // ResetWindow is how long a container must run for the delay to be reset.
+	Jitter       float64       `json:",omitempty"` // Jitter is the maximum random fraction (0-1) added to each delay.
 }
 
 // IsNone indicates whether the container has the "no" restart policy.
@@ -258,7 +267,13 @@ func (rp *RestartPolicy) IsUnlessStopped() bool {
 
 // IsSame compares two RestartPolicy to see if they are the same
 func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
-	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
+	return rp.Name == tp.Name &&
+		rp.MaximumRetryCount == tp.MaximumRetryCount &&
+		rp.InitialDelay == tp.InitialDelay &&
+		rp.MaxDelay == tp.MaxDelay &&
+		rp.Multiplier == tp.Multiplier &&
+		rp.ResetWindow == tp.ResetWindow &&
+		rp.Jitter == tp.Jitter
 }
 
 // LogMode is a type to define the available modes for logging
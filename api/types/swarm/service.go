@@ -121,4 +121,10 @@ type UpdateConfig struct {
 	// task. Either the old task is shut down before the new task is
 	// started, or the new task is started before the old task is shut down.
 	Order string
+
+	// HealthGracePeriod is the amount of time a task with a health check
+	// has to become healthy once its container is started. A task which is
+	// not healthy by then is shut down and fails. Zero means no limit.
+	// It can only be set in the UpdateConfig of a service.
+	HealthGracePeriod time.Duration `json:",omitempty"`
 }
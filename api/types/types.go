@@ -275,18 +275,19 @@ type Health struct {
 // ContainerState stores container's running state
 // it's part of ContainerJSONBase and will return by "inspect" command
 type ContainerState struct {
-	Status     string
-	Running    bool
-	Paused     bool
-	Restarting bool
-	OOMKilled  bool
-	Dead       bool
-	Pid        int
-	ExitCode   int
-	Error      string
-	StartedAt  string
-	FinishedAt string
-	Health     *Health `json:",omitempty"`
+	Status       string
+	Running      bool
+	Paused       bool
+	Restarting   bool
+	CrashLooping bool
+	OOMKilled    bool
+	Dead         bool
+	Pid          int
+	ExitCode     int
+	Error        string
+	StartedAt    string
+	FinishedAt   string
+	Health       *Health `json:",omitempty"`
 }
 
 // ContainerNode stores information about the node that a container
@@ -479,6 +480,21 @@ type DiskUsage struct {
 	Images     []*ImageSummary
 	Containers []*Container
 	Volumes    []*Volume
+	BuildCache []*BuildCache
+}
+
+// BuildCache contains information about an image of the build cache, an
+// untagged image committed by the builder.
+type BuildCache struct {
+	ID       string
+	ParentID string
+	// Size is the size of the layer the image adds on top of its parent.
+	Size int64
+	// InUse is set if a container or an image outside of the build cache
+	// is built on top of the image.
+	InUse    bool
+	Created  int64
+	LastUsed int64
 }
 
 // ContainersPruneReport contains the response for Engine API:
@@ -488,6 +504,16 @@ type ContainersPruneReport struct {
 	SpaceReclaimed    uint64
 }
 
+// VolumeCloneRequest contains the request for Engine API:
+// POST "/volumes/{name}/clone"
+type VolumeCloneRequest struct {
+	// Name is the name of the new volume. If not specified, Docker
+	// generates a name.
+	Name string
+	// Labels is the user-defined key/value metadata of the new volume.
+	Labels map[string]string
+}
+
 // VolumesPruneReport contains the response for Engine API:
 // POST "/volumes/prune"
 type VolumesPruneReport struct {
@@ -502,6 +528,13 @@ type ImagesPruneReport struct {
 	SpaceReclaimed uint64
 }
 
+// BuildCachePruneReport contains the response for Engine API:
+// POST "/build/prune"
+type BuildCachePruneReport struct {
+	CachesDeleted  []string
+	SpaceReclaimed uint64
+}
+
 // NetworksPruneReport contains the response for Engine API:
 // POST "/networks/prune"
 type NetworksPruneReport struct {
@@ -520,6 +553,18 @@ type SecretListOptions struct {
 	Filters filters.Args
 }
 
+// ConfigCreateResponse contains the information returned to a client
+// on the creation of a new config.
+type ConfigCreateResponse struct {
+	// ID is the id of the created config.
+	ID string
+}
+
+// ConfigListOptions holds parameters to list configs
+type ConfigListOptions struct {
+	Filters filters.Args
+}
+
 // PushResult contains the tag, manifest digest, and manifest size from the
 // push. It's used to signal this information to the trust code in the client
 // so it can sign the manifest if necessary.
@@ -55,4 +55,8 @@ type VolumeUsageData struct {
 	// The disk space used by the volume (local driver only)
 	// Required: true
 	Size int64 `json:"Size"`
+
+	// The number of inodes used by the volume (local driver only)
+	// Required: true
+	Inodes int64 `json:"Inodes"`
 }
@@ -13,7 +13,10 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	swarmtypes "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/image"
+	"github.com/docker/swarmkit/agent/exec"
 	"golang.org/x/net/context"
 )
 
@@ -111,6 +114,8 @@ type Backend interface {
 	GetImageOnBuild(name string) (Image, error)
 	// TagImageWithReference tags an image with newTag
 	TagImageWithReference(image.ID, reference.Named) error
+	// UnmarkBuildCache marks an image as the result of a build rather than build cache.
+	UnmarkBuildCache(image.ID) error
 	// PullOnBuild tells Docker to pull image referenced by `name`.
 	PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (Image, error)
 	// ContainerAttachRaw attaches to container.
@@ -131,6 +136,10 @@ type Backend interface {
 	ContainerUpdateCmdOnBuild(containerID string, cmd []string) error
 	// ContainerCreateWorkdir creates the workdir
 	ContainerCreateWorkdir(containerID string) error
+	// SetContainerSecretStore sets the secret store backend for the container
+	SetContainerSecretStore(name string, store exec.SecretGetter) error
+	// SetContainerSecretReferences sets the container secret references needed
+	SetContainerSecretReferences(name string, refs []*swarmtypes.SecretReference) error
 
 	// ContainerCopy copies/extracts a source FileInfo to a destination path inside a container
 	// specified by a container object.
@@ -138,8 +147,10 @@ type Backend interface {
 	// TODO: do not pass a FileInfo, instead refactor the archive package to export a Walk function that can be used
 	// with Context.Walk
 	// ContainerCopy(name string, res string) (io.ReadCloser, error)
+	// The files are owned by the user and group of chown, in the user[:group]
+	// format, or by root if chown is empty.
 	// TODO: use copyBackend api
-	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool) error
+	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool, chown string) error
 
 	// HasExperimental checks if the backend supports experimental features
 	HasExperimental() bool
@@ -151,6 +162,12 @@ type Backend interface {
 	MountImage(name string) (string, func() error, error)
 }
 
+// CachePruner represents a backend able to prune the build cache.
+type CachePruner interface {
+	// BuildCachePrune removes the build cache which is not in use.
+	BuildCachePrune(pruneFilters filters.Args) (*types.BuildCachePruneReport, error)
+}
+
 // Image represents a Docker image used by the builder.
 type Image interface {
 	ImageID() string
@@ -159,8 +176,18 @@ type Image interface {
 
 // ImageCacheBuilder represents a generator for stateful image cache.
 type ImageCacheBuilder interface {
-	// MakeImageCache creates a stateful image cache.
-	MakeImageCache(cacheFrom []string) ImageCache
+	// MakeImageCache creates a stateful image cache. The images of cacheFrom
+	// which are not present locally are looked up in their registry, with
+	// the progress of the layers pulled on cache hits written to output.
+	MakeImageCache(ctx context.Context, cacheFrom []string, authConfigs map[string]types.AuthConfig, output io.Writer) ImageCache
+}
+
+// CloneableImageCache is a stateful image cache which can be copied in its
+// initial state, so that it is created once for all the stages of a build.
+type CloneableImageCache interface {
+	ImageCache
+	// Clone returns a copy of the cache, in the state it had when created.
+	Clone() ImageCache
 }
 
 // ImageCache abstracts an image cache.
@@ -35,6 +35,30 @@ func newBuildArgs(argsFromOptions map[string]*string) *buildArgs {
 	}
 }
 
+// Clone returns a copy of the args, for a build stage to use the args
+// independently of the stages built concurrently
+func (b *buildArgs) Clone() *buildArgs {
+	result := newBuildArgs(b.argsFromOptions)
+	for key, value := range b.allowedBuildArgs {
+		result.allowedBuildArgs[key] = value
+	}
+	for key, value := range b.allowedMetaArgs {
+		result.allowedMetaArgs[key] = value
+	}
+	for key := range b.referencedArgs {
+		result.referencedArgs[key] = struct{}{}
+	}
+	return result
+}
+
+// MergeReferencedArgs marks the args referenced by other, a clone of the
+// args, as referenced
+func (b *buildArgs) MergeReferencedArgs(other *buildArgs) {
+	for key := range other.referencedArgs {
+		b.referencedArgs[key] = struct{}{}
+	}
+}
+
 // UnreferencedOptionArgs returns the list of args that were set from options but
 // were never referenced from the Dockerfile
 func (b *buildArgs) UnreferencedOptionArgs() []string {
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/reference"
@@ -14,6 +15,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/builder/dockerfile/command"
 	"github.com/docker/docker/builder/dockerfile/parser"
@@ -56,6 +58,7 @@ type Builder struct {
 	tmpContainers map[string]struct{}
 	image         string         // imageID
 	imageContexts *imageContexts // helper for storing contexts from builds
+	stage         *imageMount    // image of the build stage, nil outside of a multi-stage build
 	noBaseImage   bool           // A flag to track the use of `scratch` as the base image
 	maintainer    string
 	cmdSet        bool
@@ -64,8 +67,34 @@ type Builder struct {
 	buildArgs     *buildArgs
 	escapeToken   rune
 
-	imageCache builder.ImageCache
-	from       builder.Image
+	imageCache      builder.ImageCache
+	buildImageCache *buildImageCache // image cache shared by the stages
+	from            builder.Image
+
+	// contexts of the images the stage copies from, not shared with the
+	// stages built concurrently
+	mountContexts map[*imageMount]builder.Context
+}
+
+// buildImageCache creates the image cache of a build once, so that the
+// images of --cache-from are looked up once for all the stages of the build.
+// Each stage gets its own copy of the cache, with its own state.
+type buildImageCache struct {
+	once  sync.Once
+	cache builder.ImageCache
+}
+
+func (c *buildImageCache) get(makeCache func() builder.ImageCache) builder.ImageCache {
+	if c == nil {
+		return makeCache()
+	}
+	c.once.Do(func() {
+		c.cache = makeCache()
+	})
+	if cc, ok := c.cache.(builder.CloneableImageCache); ok {
+		return cc.Clone()
+	}
+	return c.cache
 }
 
 // BuildManager implements builder.Backend and is shared across all Builder objects.
@@ -105,6 +134,15 @@ func (bm *BuildManager) BuildFromContext(ctx context.Context, src io.ReadCloser,
 	return b.build(pg.StdoutFormatter, pg.StderrFormatter, pg.Output)
 }
 
+// PruneCache removes the build cache which is not in use.
+func (bm *BuildManager) PruneCache(pruneFilters filters.Args) (*types.BuildCachePruneReport, error) {
+	pruner, ok := bm.backend.(builder.CachePruner)
+	if !ok {
+		return nil, errors.New("pruning the build cache is not supported by the backend")
+	}
+	return pruner.BuildCachePrune(pruneFilters)
+}
+
 // NewBuilder creates a new Dockerfile builder from an optional dockerfile and a Config.
 // If dockerfile is nil, the Dockerfile specified by Config.DockerfileName,
 // will be read from the Context passed to Build().
@@ -123,6 +161,8 @@ func NewBuilder(clientCtx context.Context, config *types.ImageBuildOptions, back
 		tmpContainers: map[string]struct{}{},
 		buildArgs:     newBuildArgs(config.BuildArgs),
 		escapeToken:   parser.DefaultEscapeToken,
+
+		buildImageCache: &buildImageCache{},
 	}
 	b.imageContexts = &imageContexts{b: b}
 	return b, nil
@@ -130,7 +170,9 @@ func NewBuilder(clientCtx context.Context, config *types.ImageBuildOptions, back
 
 func (b *Builder) resetImageCache() {
 	if icb, ok := b.docker.(builder.ImageCacheBuilder); ok {
-		b.imageCache = icb.MakeImageCache(b.options.CacheFrom)
+		b.imageCache = b.buildImageCache.get(func() builder.ImageCache {
+			return icb.MakeImageCache(b.clientCtx, b.options.CacheFrom, b.options.AuthConfigs, b.Output)
+		})
 	}
 	b.noBaseImage = false
 	b.cacheBusted = false
@@ -213,6 +255,12 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		}
 	}
 
+	// The image is the result of the build, not build cache, even if it is
+	// a cache hit.
+	if err := b.docker.UnmarkBuildCache(image.ID(b.image)); err != nil {
+		return "", err
+	}
+
 	fmt.Fprintf(b.Stdout, "Successfully built %s\n", shortImageID)
 	if err := b.tagImages(repoAndTags); err != nil {
 		return "", err
@@ -224,9 +272,39 @@ func (b *Builder) dispatchDockerfileWithCancellation(dockerfile *parser.Result)
 	// TODO: pass this to dispatchRequest instead
 	b.escapeToken = dockerfile.EscapeToken
 
-	total := len(dockerfile.AST.Children)
+	nodes := dockerfile.AST.Children
+	total := len(nodes)
+
+	// The instructions before the first FROM are dispatched before the
+	// stages, for the stages to use the args they define.
+	first := 0
+	for first < total && nodes[first].Value != command.From {
+		first++
+	}
+	shortImgID, err := b.dispatchSteps(nodes[:first], 0, total)
+	if err != nil {
+		return "", err
+	}
+
+	stages, err := b.parseStages(nodes, first)
+	if err != nil {
+		return "", err
+	}
+	target, err := targetStage(stages, b.options.Target)
+	if err != nil {
+		return "", err
+	}
+	if target == nil {
+		return shortImgID, nil
+	}
+	return b.dispatchStages(stages, target, total)
+}
+
+// dispatchSteps dispatches nodes, the instructions of the Dockerfile from the
+// one at index first, and returns the short ID of the resulting image.
+func (b *Builder) dispatchSteps(nodes []*parser.Node, first, total int) (string, error) {
 	var shortImgID string
-	for i, n := range dockerfile.AST.Children {
+	for i, n := range nodes {
 		select {
 		case <-b.clientCtx.Done():
 			logrus.Debug("Builder: build cancelled!")
@@ -236,11 +314,7 @@ func (b *Builder) dispatchDockerfileWithCancellation(dockerfile *parser.Result)
 			// Not cancelled yet, keep going...
 		}
 
-		if command.From == n.Value && b.imageContexts.isCurrentTarget(b.options.Target) {
-			break
-		}
-
-		if err := b.dispatch(i, total, n); err != nil {
+		if err := b.dispatch(first+i, total, n); err != nil {
 			if b.options.ForceRemove {
 				b.clearTmp()
 			}
@@ -253,12 +327,16 @@ func (b *Builder) dispatchDockerfileWithCancellation(dockerfile *parser.Result)
 			b.clearTmp()
 		}
 	}
+	return shortImgID, nil
+}
 
-	if b.options.Target != "" && !b.imageContexts.isCurrentTarget(b.options.Target) {
-		return "", errors.Errorf("failed to reach build target %s in Dockerfile", b.options.Target)
+// stageIndex returns the index of the build stage of the builder, or the
+// number of stages if the builder is not building one of them.
+func (b *Builder) stageIndex() int {
+	if b.stage == nil {
+		return len(b.imageContexts.list)
 	}
-
-	return shortImgID, nil
+	return b.stage.stage
 }
 
 func (b *Builder) squashBuild() error {
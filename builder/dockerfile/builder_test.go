@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder"
 	"github.com/docker/docker/builder/dockerfile/parser"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +34,34 @@ func TestAddNodesForLabelOption(t *testing.T) {
 		assert.Equal(t, expected[i], v.Original)
 	}
 }
+
+type cloneableImageCache struct {
+	clones int
+}
+
+func (c *cloneableImageCache) GetCache(parentID string, cfg *container.Config) (string, error) {
+	return "", nil
+}
+
+func (c *cloneableImageCache) Clone() builder.ImageCache {
+	c.clones++
+	return &cloneableImageCache{}
+}
+
+func TestBuildImageCacheMadeOnce(t *testing.T) {
+	made := 0
+	cache := &cloneableImageCache{}
+	makeCache := func() builder.ImageCache {
+		made++
+		return cache
+	}
+
+	c := &buildImageCache{}
+	first := c.get(makeCache)
+	second := c.get(makeCache)
+	assert.Equal(t, 1, made)
+	assert.Equal(t, 2, cache.clones)
+	// each stage gets its own copy of the cache
+	assert.False(t, first == second)
+	assert.False(t, first == builder.ImageCache(cache))
+}
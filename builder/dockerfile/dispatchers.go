@@ -142,11 +142,13 @@ func add(b *Builder, args []string, attributes map[string]bool, original string)
 		return errAtLeastTwoArguments("ADD")
 	}
 
+	flChown := b.flags.AddString("chown", "")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
-	return b.runContextCommand(args, true, true, "ADD", nil)
+	return b.runContextCommand(args, true, true, "ADD", nil, flChown.Value)
 }
 
 // COPY foo /path
@@ -159,6 +161,7 @@ func dispatchCopy(b *Builder, args []string, attributes map[string]bool, origina
 	}
 
 	flFrom := b.flags.AddString("from", "")
+	flChown := b.flags.AddString("chown", "")
 
 	if err := b.flags.Parse(); err != nil {
 		return err
@@ -167,13 +170,13 @@ func dispatchCopy(b *Builder, args []string, attributes map[string]bool, origina
 	var im *imageMount
 	if flFrom.IsUsed() {
 		var err error
-		im, err = b.imageContexts.get(flFrom.Value)
+		im, err = b.imageContexts.get(b, flFrom.Value)
 		if err != nil {
 			return err
 		}
 	}
 
-	return b.runContextCommand(args, false, false, "COPY", im)
+	return b.runContextCommand(args, false, false, "COPY", im, flChown.Value)
 }
 
 // FROM imagename
@@ -208,11 +211,12 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 	var image builder.Image
 
 	b.resetImageCache()
-	if _, err := b.imageContexts.new(ctxName, true); err != nil {
+
+	im, err := b.imageContexts.stage(name, b.stageIndex())
+	if err != nil {
 		return err
 	}
-
-	if im, ok := b.imageContexts.byName[name]; ok {
+	if im != nil {
 		if len(im.ImageID()) > 0 {
 			image = im
 		}
@@ -233,7 +237,7 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		}
 	}
 	if image != nil {
-		b.imageContexts.update(image.ImageID(), image.RunConfig())
+		b.imageContexts.update(b.stage, image.ImageID(), image.RunConfig())
 	}
 	b.from = image
 
@@ -413,6 +417,10 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 		return err
 	}
 
+	if err := b.setupSecrets(cID); err != nil {
+		return err
+	}
+
 	if err := b.run(cID); err != nil {
 		return err
 	}
@@ -494,7 +502,8 @@ func parseOptInterval(f *Flag) (time.Duration, error) {
 // HEALTHCHECK foo
 //
 // Set the default healthcheck command to run in the container (which may be empty).
-// Argument handling is the same as RUN.
+// Argument handling is the same as RUN. The HTTP and TCP types take a single
+// URL or address which the daemon probes from within the container.
 //
 func healthcheck(b *Builder, args []string, attributes map[string]bool, original string) error {
 	if len(args) == 0 {
@@ -541,8 +550,14 @@ func healthcheck(b *Builder, args []string, attributes map[string]bool, original
 			}
 
 			healthcheck.Test = strslice.StrSlice(append([]string{typ}, cmdSlice...))
+		case "HTTP", "TCP":
+			target := handleJSONArgs(args, attributes)
+			if len(target) != 1 || len(strings.Fields(target[0])) != 1 {
+				return fmt.Errorf("HEALTHCHECK %s requires exactly one argument", typ)
+			}
+			healthcheck.Test = strslice.StrSlice{typ, target[0]}
 		default:
-			return fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD)", typ)
+			return fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD, HTTP or TCP)", typ)
 		}
 
 		interval, err := parseOptInterval(flInterval)
@@ -835,10 +850,7 @@ func mountByRef(b *Builder, name string) (*imageMount, error) {
 	if err != nil {
 		return nil, err
 	}
-	im, err := b.imageContexts.new("", false)
-	if err != nil {
-		return nil, err
-	}
+	im := b.imageContexts.newRef()
 	im.id = image.ImageID()
 	return im, nil
 }
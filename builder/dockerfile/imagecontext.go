@@ -15,15 +15,18 @@ import (
 // imageContexts is a helper for stacking up built image rootfs and reusing
 // them as contexts
 type imageContexts struct {
-	b           *Builder
-	list        []*imageMount
-	byName      map[string]*imageMount
-	cache       *pathCache
-	currentName string
-}
-
-func (ic *imageContexts) new(name string, increment bool) (*imageMount, error) {
-	im := &imageMount{ic: ic}
+	b      *Builder
+	mu     sync.Mutex // protects the images of the stages built concurrently
+	list   []*imageMount
+	byName map[string]*imageMount
+	refs   []*imageMount
+	cache  *pathCache
+}
+
+// add registers the image of a build stage, in the order of the stages in
+// the Dockerfile.
+func (ic *imageContexts) add(name string) (*imageMount, error) {
+	im := &imageMount{ic: ic, stage: len(ic.list)}
 	if len(name) > 0 {
 		if ic.byName == nil {
 			ic.byName = make(map[string]*imageMount)
@@ -33,22 +36,42 @@ func (ic *imageContexts) new(name string, increment bool) (*imageMount, error) {
 		}
 		ic.byName[name] = im
 	}
-	if increment {
-		ic.list = append(ic.list, im)
-	}
-	ic.currentName = name
+	ic.list = append(ic.list, im)
 	return im, nil
 }
 
-func (ic *imageContexts) update(imageID string, runConfig *container.Config) {
-	ic.list[len(ic.list)-1].id = imageID
-	ic.list[len(ic.list)-1].runConfig = runConfig
+// newRef returns an image mount for an image reference, to be unmounted with
+// the images of the stages.
+func (ic *imageContexts) newRef() *imageMount {
+	im := &imageMount{ic: ic, stage: -1}
+	ic.mu.Lock()
+	ic.refs = append(ic.refs, im)
+	ic.mu.Unlock()
+	return im
+}
+
+func (ic *imageContexts) update(im *imageMount, imageID string, runConfig *container.Config) {
+	if im == nil {
+		return
+	}
+	ic.mu.Lock()
+	im.id = imageID
+	im.runConfig = runConfig
+	ic.mu.Unlock()
+}
+
+// setBuilt marks the build stage of im as built, for the following stages to
+// use its image.
+func (ic *imageContexts) setBuilt(im *imageMount) {
+	ic.mu.Lock()
+	im.built = true
+	ic.mu.Unlock()
 }
 
-func (ic *imageContexts) validate(i int) error {
-	if i < 0 || i >= len(ic.list)-1 {
+func (ic *imageContexts) validate(i, current int) error {
+	if i < 0 || i >= current {
 		var extraMsg string
-		if i == len(ic.list)-1 {
+		if i == current {
 			extraMsg = " refers current build block"
 		}
 		return errors.Errorf("invalid from flag value %d%s", i, extraMsg)
@@ -56,18 +79,43 @@ func (ic *imageContexts) validate(i int) error {
 	return nil
 }
 
-func (ic *imageContexts) get(indexOrName string) (*imageMount, error) {
+// stage returns the image of the stage named name among the stages before
+// the stage at index current, or nil if there is no such stage.
+func (ic *imageContexts) stage(name string, current int) (*imageMount, error) {
+	im, ok := ic.byName[name]
+	if !ok || im.stage >= current {
+		return nil, nil
+	}
+	return im, ic.checkBuilt(im, name)
+}
+
+// checkBuilt returns an error if the stage of im is not built. A stage only
+// waits for the stages it uses in the Dockerfile, not for those used by the
+// ONBUILD triggers of its base image.
+func (ic *imageContexts) checkBuilt(im *imageMount, indexOrName string) error {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	if !im.built {
+		return errors.Errorf("build stage %s is not a dependency of the current build stage", indexOrName)
+	}
+	return nil
+}
+
+func (ic *imageContexts) get(b *Builder, indexOrName string) (*imageMount, error) {
+	current := b.stageIndex()
 	index, err := strconv.Atoi(indexOrName)
 	if err == nil {
-		if err := ic.validate(index); err != nil {
+		if err := ic.validate(index, current); err != nil {
 			return nil, err
 		}
-		return ic.list[index], nil
+		im := ic.list[index]
+		return im, ic.checkBuilt(im, indexOrName)
 	}
-	if im, ok := ic.byName[strings.ToLower(indexOrName)]; ok {
-		return im, nil
+	im, err := ic.stage(strings.ToLower(indexOrName), current)
+	if err != nil || im != nil {
+		return im, err
 	}
-	im, err := mountByRef(ic.b, indexOrName)
+	im, err = mountByRef(b, indexOrName)
 	if err != nil {
 		return nil, errors.Wrapf(err, "invalid from flag value %s", indexOrName)
 	}
@@ -81,7 +129,7 @@ func (ic *imageContexts) unmount() (retErr error) {
 			retErr = err
 		}
 	}
-	for _, im := range ic.byName {
+	for _, im := range ic.refs {
 		if err := im.unmount(); err != nil {
 			logrus.Error(err)
 			retErr = err
@@ -90,13 +138,6 @@ func (ic *imageContexts) unmount() (retErr error) {
 	return
 }
 
-func (ic *imageContexts) isCurrentTarget(target string) bool {
-	if target == "" {
-		return false
-	}
-	return strings.EqualFold(ic.currentName, target)
-}
-
 func (ic *imageContexts) getCache(id, path string) (interface{}, bool) {
 	if ic.cache != nil {
 		if id == "" {
@@ -117,29 +158,51 @@ func (ic *imageContexts) setCache(id, path string, v interface{}) {
 // by an existing image
 type imageMount struct {
 	id        string
-	ctx       builder.Context
+	root      string // path the image is mounted at
 	release   func() error
 	ic        *imageContexts
 	runConfig *container.Config
+	stage     int  // index of the build stage, -1 for an image reference
+	built     bool // whether the build stage is built
+}
+
+// context returns a build context backed by the image for the builder b.
+// The image is mounted once for all the stages, but each stage gets its own
+// context as a context can't be used concurrently.
+func (im *imageMount) context(b *Builder) (builder.Context, error) {
+	if ctx, ok := b.mountContexts[im]; ok {
+		return ctx, nil
+	}
+	root, err := im.mount()
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := remotecontext.NewLazyContext(root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create lazycontext for %s", root)
+	}
+	if b.mountContexts == nil {
+		b.mountContexts = make(map[*imageMount]builder.Context)
+	}
+	b.mountContexts[im] = ctx
+	return ctx, nil
 }
 
-func (im *imageMount) context() (builder.Context, error) {
-	if im.ctx == nil {
+func (im *imageMount) mount() (string, error) {
+	im.ic.mu.Lock()
+	defer im.ic.mu.Unlock()
+	if im.release == nil {
 		if im.id == "" {
-			return nil, errors.Errorf("could not copy from empty context")
+			return "", errors.Errorf("could not copy from empty context")
 		}
 		p, release, err := im.ic.b.docker.MountImage(im.id)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to mount %s", im.id)
-		}
-		ctx, err := remotecontext.NewLazyContext(p)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to create lazycontext for %s", p)
+			return "", errors.Wrapf(err, "failed to mount %s", im.id)
 		}
+		im.root = p
 		im.release = release
-		im.ctx = ctx
 	}
-	return im.ctx, nil
+	return im.root, nil
 }
 
 func (im *imageMount) unmount() error {
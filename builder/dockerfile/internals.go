@@ -74,6 +74,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 			Pause:  true,
 			Config: &autoConfig,
 		},
+		BuildCache: true,
 	}
 
 	// Commit the container
@@ -83,7 +84,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 	}
 
 	b.image = imageID
-	b.imageContexts.update(imageID, &autoConfig)
+	b.imageContexts.update(b.stage, imageID, &autoConfig)
 	return nil
 }
 
@@ -92,10 +93,13 @@ type copyInfo struct {
 	decompress bool
 }
 
-func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string, imageSource *imageMount) error {
+func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string, imageSource *imageMount, chown string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("Invalid %s format - at least two arguments required", cmdName)
 	}
+	if chown != "" && runtime.GOOS == "windows" {
+		return fmt.Errorf("The --chown flag of %s is not supported on Windows", cmdName)
+	}
 
 	// Work in daemon-specific filepath semantics
 	dest := filepath.FromSlash(args[len(args)-1]) // last one is always the dest
@@ -168,8 +172,15 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 		origPaths = strings.Join(origs, " ")
 	}
 
+	// The owner is only part of the cache key when it is set, for the
+	// images built without --chown to hit the cache.
+	instruction := cmdName
+	if chown != "" {
+		instruction += " --chown=" + chown
+	}
+
 	cmd := b.runConfig.Cmd
-	b.runConfig.Cmd = strslice.StrSlice(append(getShell(b.runConfig), fmt.Sprintf("#(nop) %s %s in %s ", cmdName, srcHash, dest)))
+	b.runConfig.Cmd = strslice.StrSlice(append(getShell(b.runConfig), fmt.Sprintf("#(nop) %s %s in %s ", instruction, srcHash, dest)))
 	defer func(cmd strslice.StrSlice) { b.runConfig.Cmd = cmd }(cmd)
 
 	if hit, err := b.probeCache(); err != nil {
@@ -188,7 +199,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	}
 	b.tmpContainers[container.ID] = struct{}{}
 
-	comment := fmt.Sprintf("%s %s in %s", cmdName, origPaths, dest)
+	comment := fmt.Sprintf("%s %s in %s", instruction, origPaths, dest)
 
 	// Twiddle the destination when it's a relative path - meaning, make it
 	// relative to the WORKINGDIR
@@ -197,7 +208,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	}
 
 	for _, info := range infos {
-		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress); err != nil {
+		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress, chown); err != nil {
 			return err
 		}
 	}
@@ -331,7 +342,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	context := b.context
 	var err error
 	if imageSource != nil {
-		context, err = imageSource.context()
+		context, err = imageSource.context(b)
 		if err != nil {
 			return nil, err
 		}
@@ -510,7 +521,7 @@ func (b *Builder) probeCache() (bool, error) {
 	fmt.Fprint(b.Stdout, " ---> Using cache\n")
 	logrus.Debugf("[BUILDER] Use cached version: %s", b.runConfig.Cmd)
 	b.image = string(cache)
-	b.imageContexts.update(b.image, b.runConfig)
+	b.imageContexts.update(b.stage, b.image, b.runConfig)
 
 	return true, nil
 }
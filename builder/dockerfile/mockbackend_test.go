@@ -8,14 +8,18 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	swarmtypes "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/image"
+	"github.com/docker/swarmkit/agent/exec"
 	"golang.org/x/net/context"
 )
 
 // MockBackend implements the builder.Backend interface for unit testing
 type MockBackend struct {
 	getImageOnBuildFunc func(string) (builder.Image, error)
+	mountImageFunc      func(string) (string, func() error, error)
+	commitFunc          func(string, *backend.ContainerCommitConfig) (string, error)
 }
 
 func (m *MockBackend) GetImageOnBuild(name string) (builder.Image, error) {
@@ -29,6 +33,10 @@ func (m *MockBackend) TagImageWithReference(image.ID, reference.Named) error {
 	return nil
 }
 
+func (m *MockBackend) UnmarkBuildCache(image.ID) error {
+	return nil
+}
+
 func (m *MockBackend) PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (builder.Image, error) {
 	return nil, nil
 }
@@ -45,7 +53,10 @@ func (m *MockBackend) ContainerRm(name string, config *types.ContainerRmConfig)
 	return nil
 }
 
-func (m *MockBackend) Commit(string, *backend.ContainerCommitConfig) (string, error) {
+func (m *MockBackend) Commit(cID string, cfg *backend.ContainerCommitConfig) (string, error) {
+	if m.commitFunc != nil {
+		return m.commitFunc(cID, cfg)
+	}
 	return "", nil
 }
 
@@ -69,7 +80,15 @@ func (m *MockBackend) ContainerCreateWorkdir(containerID string) error {
 	return nil
 }
 
-func (m *MockBackend) CopyOnBuild(containerID string, destPath string, src builder.FileInfo, decompress bool) error {
+func (m *MockBackend) SetContainerSecretStore(name string, store exec.SecretGetter) error {
+	return nil
+}
+
+func (m *MockBackend) SetContainerSecretReferences(name string, refs []*swarmtypes.SecretReference) error {
+	return nil
+}
+
+func (m *MockBackend) CopyOnBuild(containerID string, destPath string, src builder.FileInfo, decompress bool, chown string) error {
 	return nil
 }
 
@@ -82,6 +101,9 @@ func (m *MockBackend) SquashImage(from string, to string) (string, error) {
 }
 
 func (m *MockBackend) MountImage(name string) (string, func() error, error) {
+	if m.mountImageFunc != nil {
+		return m.mountImageFunc(name)
+	}
 	return "", func() error { return nil }, nil
 }
 
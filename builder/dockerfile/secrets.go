@@ -0,0 +1,63 @@
+package dockerfile
+
+import (
+	"sort"
+
+	swarmtypes "github.com/docker/docker/api/types/swarm"
+	swarmapi "github.com/docker/swarmkit/api"
+)
+
+// buildSecrets is the secret store of the containers of the RUN
+// instructions, serving the secrets passed to the build by their id.
+type buildSecrets map[string][]byte
+
+// Get returns the secret with the given id, or nil if there is no such
+// secret.
+func (s buildSecrets) Get(id string) *swarmapi.Secret {
+	data, ok := s[id]
+	if !ok {
+		return nil
+	}
+	return &swarmapi.Secret{
+		ID: id,
+		Spec: swarmapi.SecretSpec{
+			Annotations: swarmapi.Annotations{Name: id},
+			Data:        data,
+		},
+	}
+}
+
+// setupSecrets makes the secrets of the build available to the container at
+// /run/secrets/<id>. The daemon writes them to a tmpfs which is mounted only
+// while the container runs, so that they are never part of the changes
+// committed from the container.
+func (b *Builder) setupSecrets(cID string) error {
+	if len(b.options.Secrets) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(b.options.Secrets))
+	for id := range b.options.Secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	refs := make([]*swarmtypes.SecretReference, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, &swarmtypes.SecretReference{
+			File: &swarmtypes.SecretReferenceFileTarget{
+				Name: id,
+				UID:  "0",
+				GID:  "0",
+				Mode: 0444,
+			},
+			SecretID:   id,
+			SecretName: id,
+		})
+	}
+
+	if err := b.docker.SetContainerSecretStore(cID, buildSecrets(b.options.Secrets)); err != nil {
+		return err
+	}
+	return b.docker.SetContainerSecretReferences(cID, refs)
+}
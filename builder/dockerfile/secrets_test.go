@@ -0,0 +1,53 @@
+package dockerfile
+
+import (
+	"testing"
+
+	swarmtypes "github.com/docker/docker/api/types/swarm"
+	"github.com/docker/swarmkit/agent/exec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type secretsBackend struct {
+	MockBackend
+	store exec.SecretGetter
+	refs  []*swarmtypes.SecretReference
+}
+
+func (m *secretsBackend) SetContainerSecretStore(name string, store exec.SecretGetter) error {
+	m.store = store
+	return nil
+}
+
+func (m *secretsBackend) SetContainerSecretReferences(name string, refs []*swarmtypes.SecretReference) error {
+	m.refs = refs
+	return nil
+}
+
+func TestSetupSecrets(t *testing.T) {
+	backend := &secretsBackend{}
+	b := newBuilderWithMockBackend()
+	b.docker = backend
+
+	require.NoError(t, b.setupSecrets("container"))
+	assert.Nil(t, backend.store)
+	assert.Nil(t, backend.refs)
+
+	b.options.Secrets = map[string][]byte{
+		"foo": []byte("foo secret"),
+		"bar": []byte("bar secret"),
+	}
+	require.NoError(t, b.setupSecrets("container"))
+	require.Len(t, backend.refs, 2)
+	for i, id := range []string{"bar", "foo"} {
+		ref := backend.refs[i]
+		assert.Equal(t, id, ref.SecretID)
+		assert.Equal(t, id, ref.File.Name)
+
+		secret := backend.store.Get(ref.SecretID)
+		require.NotNil(t, secret)
+		assert.Equal(t, b.options.Secrets[id], secret.Spec.Data)
+	}
+	assert.Nil(t, backend.store.Get("baz"))
+}
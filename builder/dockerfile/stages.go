@@ -0,0 +1,324 @@
+package dockerfile
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder/dockerfile/command"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// buildStage is a block of instructions of a Dockerfile starting with FROM.
+type buildStage struct {
+	name  string         // name of the stage given with FROM ... AS, lowercased
+	index int            // index of the stage in the Dockerfile
+	first int            // index of the FROM instruction in the Dockerfile
+	nodes []*parser.Node // instructions of the stage, FROM included
+	deps  []int          // earlier stages the stage builds on or copies from
+	mount *imageMount
+}
+
+func (s *buildStage) addDep(index int) {
+	for _, d := range s.deps {
+		if d == index {
+			return
+		}
+	}
+	s.deps = append(s.deps, index)
+}
+
+// parseStages splits the instructions of the Dockerfile from the first FROM
+// instruction, at index first, into build stages, and registers the images
+// of the stages. A stage depends on the earlier stages it uses as base image
+// or copies files from.
+func (b *Builder) parseStages(nodes []*parser.Node, first int) ([]*buildStage, error) {
+	metaArgs := []string{}
+	for key, value := range b.buildArgs.GetAllMeta() {
+		metaArgs = append(metaArgs, key+"="+value)
+	}
+
+	var stages []*buildStage
+	for i := first; i < len(nodes); i++ {
+		n := nodes[i]
+		if n.Value == command.From {
+			args := nodeArgs(n)
+			name := ""
+			if len(args) == 3 && strings.EqualFold(args[1], "as") {
+				name = strings.ToLower(args[2])
+			}
+			mount, err := b.imageContexts.add(name)
+			if err != nil {
+				return nil, err
+			}
+			stage := &buildStage{name: name, index: len(stages), first: i, nodes: []*parser.Node{n}, mount: mount}
+			if len(args) > 0 {
+				// Errors are returned when the FROM instruction is dispatched.
+				if base, err := ProcessWord(args[0], metaArgs, b.escapeToken); err == nil {
+					if dep := findStage(stages, base); dep != nil {
+						stage.addDep(dep.index)
+					}
+				}
+			}
+			stages = append(stages, stage)
+			continue
+		}
+
+		stage := stages[len(stages)-1]
+		stage.nodes = append(stage.nodes, n)
+		if n.Value != command.Copy {
+			continue
+		}
+		for _, flag := range n.Flags {
+			if !strings.HasPrefix(flag, "--from=") {
+				continue
+			}
+			indexOrName := strings.TrimPrefix(flag, "--from=")
+			if index, err := strconv.Atoi(indexOrName); err == nil {
+				if index >= 0 && index < stage.index {
+					stage.addDep(index)
+				}
+			} else if dep := findStage(stages[:stage.index], strings.ToLower(indexOrName)); dep != nil {
+				stage.addDep(dep.index)
+			}
+		}
+	}
+	return stages, nil
+}
+
+func nodeArgs(n *parser.Node) []string {
+	var args []string
+	for next := n.Next; next != nil; next = next.Next {
+		args = append(args, next.Value)
+	}
+	return args
+}
+
+func findStage(stages []*buildStage, name string) *buildStage {
+	for _, s := range stages {
+		if s.name != "" && s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// targetStage returns the stage named target, or the last stage if target is
+// empty.
+func targetStage(stages []*buildStage, target string) (*buildStage, error) {
+	if target == "" {
+		if len(stages) == 0 {
+			return nil, nil
+		}
+		return stages[len(stages)-1], nil
+	}
+	for _, s := range stages {
+		if strings.EqualFold(s.name, target) {
+			return s, nil
+		}
+	}
+	return nil, errors.Errorf("failed to reach build target %s in Dockerfile", target)
+}
+
+// neededStages returns the stages needed to build target, target included,
+// in the order of the Dockerfile.
+func neededStages(stages []*buildStage, target *buildStage) []*buildStage {
+	needed := make([]bool, target.index+1)
+	needed[target.index] = true
+	for i := target.index; i >= 0; i-- {
+		if !needed[i] {
+			continue
+		}
+		for _, d := range stages[i].deps {
+			needed[d] = true
+		}
+	}
+
+	var result []*buildStage
+	for i, s := range stages[:target.index+1] {
+		if needed[i] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// dispatchStages builds the stages needed to build target, each stage as soon
+// as the stages it depends on are built. The stages which don't depend on
+// each other are built concurrently. The builder takes the image of target.
+func (b *Builder) dispatchStages(stages []*buildStage, target *buildStage, total int) (string, error) {
+	needed := neededStages(stages, target)
+	for _, s := range stages {
+		if s.index <= target.index && !containsStage(needed, s) {
+			logrus.Debugf("Builder: skipping build stage %d, the target stage does not depend on it", s.index)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(b.clientCtx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		builders = make(map[int]*Builder)
+		done     = make(map[int]chan struct{})
+		outputs  = &stageOutputs{}
+	)
+	for _, s := range needed {
+		builders[s.index] = b.newStageBuilder(ctx, s, outputs.add())
+		done[s.index] = make(chan struct{})
+	}
+
+	for i, s := range needed {
+		wg.Add(1)
+		go func(s *buildStage, output *stageOutput) {
+			defer wg.Done()
+			defer output.finish()
+
+			for _, d := range s.deps {
+				select {
+				case <-done[d]:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if _, err := builders[s.index].dispatchSteps(s.nodes, s.first, total); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				// The stages built concurrently are cancelled.
+				cancel()
+				return
+			}
+			b.imageContexts.setBuilt(s.mount)
+			close(done[s.index])
+		}(s, outputs.stages[i])
+	}
+	wg.Wait()
+
+	for _, s := range needed {
+		b.buildArgs.MergeReferencedArgs(builders[s.index].buildArgs)
+	}
+	if firstErr != nil {
+		return "", firstErr
+	}
+	select {
+	case <-done[target.index]:
+	default:
+		return "", errors.New("Build cancelled")
+	}
+
+	tb := builders[target.index]
+	b.image = tb.image
+	b.from = tb.from
+	return stringid.TruncateID(b.image), nil
+}
+
+func containsStage(stages []*buildStage, stage *buildStage) bool {
+	for _, s := range stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// newStageBuilder returns a builder for a stage, sharing the options, the
+// context and the images of the stages with b.
+func (b *Builder) newStageBuilder(ctx context.Context, stage *buildStage, output *stageOutput) *Builder {
+	return &Builder{
+		options:       b.options,
+		Stdout:        output.writer(b.Stdout),
+		Stderr:        output.writer(b.Stderr),
+		Output:        output.writer(b.Output),
+		docker:        b.docker,
+		context:       b.context,
+		clientCtx:     ctx,
+		runConfig:     new(container.Config),
+		tmpContainers: map[string]struct{}{},
+		imageContexts: b.imageContexts,
+		buildArgs:     b.buildArgs.Clone(),
+		escapeToken:   b.escapeToken,
+		stage:         stage.mount,
+
+		buildImageCache: b.buildImageCache,
+	}
+}
+
+// stageOutputs orders the output of the stages built concurrently, as if the
+// stages were built one after the other: the output of a stage is written as
+// soon as the stages before it are built, and is buffered until then.
+type stageOutputs struct {
+	mu      sync.Mutex
+	stages  []*stageOutput
+	current int
+}
+
+func (o *stageOutputs) add() *stageOutput {
+	so := &stageOutput{outputs: o}
+	o.stages = append(o.stages, so)
+	return so
+}
+
+type stageOutput struct {
+	outputs  *stageOutputs
+	buffered []bufferedWrite
+	finished bool
+}
+
+type bufferedWrite struct {
+	w io.Writer
+	p []byte
+}
+
+func (so *stageOutput) writer(w io.Writer) io.Writer {
+	return &stageWriter{output: so, w: w}
+}
+
+// finish writes the output of the next stages which are not buffering
+// anymore once the stage is built.
+func (so *stageOutput) finish() {
+	o := so.outputs
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	so.finished = true
+	for o.current < len(o.stages) && o.stages[o.current].finished {
+		o.current++
+		if o.current == len(o.stages) {
+			break
+		}
+		next := o.stages[o.current]
+		for _, bw := range next.buffered {
+			bw.w.Write(bw.p)
+		}
+		next.buffered = nil
+	}
+}
+
+type stageWriter struct {
+	output *stageOutput
+	w      io.Writer
+}
+
+func (sw *stageWriter) Write(p []byte) (int, error) {
+	o := sw.output.outputs
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.current < len(o.stages) && o.stages[o.current] == sw.output {
+		return sw.w.Write(p)
+	}
+	sw.output.buffered = append(sw.output.buffered, bufferedWrite{w: sw.w, p: append([]byte(nil), p...)})
+	return len(p), nil
+}
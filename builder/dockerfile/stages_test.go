@@ -0,0 +1,200 @@
+package dockerfile
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestParseStages(t *testing.T) {
+	dockerfile := `
+ARG BASE=busybox
+FROM ${BASE} AS Deps
+RUN echo deps
+FROM busybox AS assets
+FROM deps AS build
+COPY --from=assets /assets /assets
+COPY --from=0 /deps /deps
+FROM busybox
+COPY --from=build /bin /bin
+COPY --from=busybox /etc /etc
+`
+	result, err := parser.Parse(strings.NewReader(dockerfile))
+	require.NoError(t, err)
+
+	b := newBuilderWithMockBackend()
+	nodes := result.AST.Children
+	require.NoError(t, arg(b, []string{"BASE=busybox"}, nil, ""))
+
+	stages, err := b.parseStages(nodes, 1)
+	require.NoError(t, err)
+	require.Len(t, stages, 4)
+
+	assert.Equal(t, "deps", stages[0].name)
+	assert.Equal(t, 1, stages[0].first)
+	assert.Len(t, stages[0].nodes, 2)
+	assert.Empty(t, stages[0].deps)
+
+	assert.Equal(t, "assets", stages[1].name)
+	assert.Empty(t, stages[1].deps)
+
+	assert.Equal(t, "build", stages[2].name)
+	assert.Equal(t, []int{0, 1}, stages[2].deps)
+
+	assert.Equal(t, "", stages[3].name)
+	assert.Equal(t, 7, stages[3].first)
+	assert.Equal(t, []int{2}, stages[3].deps)
+
+	assert.Len(t, b.imageContexts.list, 4)
+	for i, s := range stages {
+		assert.Equal(t, i, s.mount.stage)
+	}
+}
+
+func TestParseStagesDuplicateName(t *testing.T) {
+	result, err := parser.Parse(strings.NewReader("FROM busybox AS a\nFROM busybox AS A\n"))
+	require.NoError(t, err)
+
+	b := newBuilderWithMockBackend()
+	_, err = b.parseStages(result.AST.Children, 0)
+	assert.EqualError(t, err, "duplicate name a")
+}
+
+func TestTargetStage(t *testing.T) {
+	stages := []*buildStage{{name: "build", index: 0}, {name: "", index: 1}}
+
+	target, err := targetStage(stages, "")
+	require.NoError(t, err)
+	assert.Equal(t, stages[1], target)
+
+	target, err = targetStage(stages, "Build")
+	require.NoError(t, err)
+	assert.Equal(t, stages[0], target)
+
+	_, err = targetStage(stages, "nosuchtarget")
+	assert.EqualError(t, err, "failed to reach build target nosuchtarget in Dockerfile")
+
+	target, err = targetStage(nil, "")
+	require.NoError(t, err)
+	assert.Nil(t, target)
+}
+
+func TestNeededStages(t *testing.T) {
+	stages := []*buildStage{
+		{index: 0},
+		{index: 1},
+		{index: 2, deps: []int{0}},
+		{index: 3, deps: []int{2}},
+		{index: 4},
+	}
+
+	assert.Equal(t, []*buildStage{stages[0], stages[2], stages[3]}, neededStages(stages, stages[3]))
+	assert.Equal(t, []*buildStage{stages[1]}, neededStages(stages, stages[1]))
+	assert.Equal(t, []*buildStage{stages[4]}, neededStages(stages, stages[4]))
+}
+
+func TestDispatchStages(t *testing.T) {
+	result, err := parser.Parse(strings.NewReader("FROM alpine AS base\nFROM busybox AS other\nFROM base\n"))
+	require.NoError(t, err)
+
+	var pulled []string
+	b := newBuilderWithMockBackend()
+	b.docker.(*MockBackend).getImageOnBuildFunc = func(name string) (builder.Image, error) {
+		pulled = append(pulled, name)
+		return &mockImage{id: name + "-id"}, nil
+	}
+	var stdout bytes.Buffer
+	b.clientCtx = context.Background()
+	b.Stdout, b.Stderr, b.Output = &stdout, &stdout, &stdout
+	b.tmpContainers = map[string]struct{}{}
+
+	// The "other" stage is not needed for the last stage.
+	shortImgID, err := b.dispatchDockerfileWithCancellation(result)
+	require.NoError(t, err)
+	assert.Equal(t, "alpine-id", shortImgID)
+	assert.Equal(t, "alpine-id", b.image)
+	assert.Equal(t, []string{"alpine"}, pulled)
+	assert.Contains(t, stdout.String(), "Step 1/3 : FROM alpine AS base")
+	assert.Contains(t, stdout.String(), "Step 3/3 : FROM base")
+	assert.NotContains(t, stdout.String(), "Step 2/3")
+}
+
+func TestDispatchStagesCopyFromSameStage(t *testing.T) {
+	root, err := ioutil.TempDir("", "builder-stages-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	for i := 0; i < 50; i++ {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(root, fmt.Sprintf("file%d", i)), []byte("content"), 0644))
+	}
+
+	// The two sibling stages copy from the first stage concurrently.
+	dockerfile := `
+FROM busybox AS base
+FROM busybox AS first
+COPY --from=base /file* /first/
+FROM busybox AS second
+COPY --from=base /file* /second/
+FROM busybox
+COPY --from=first /file0 /first/
+COPY --from=second /file1 /second/
+`
+	result, err := parser.Parse(strings.NewReader(dockerfile))
+	require.NoError(t, err)
+
+	var mounts, commits int32
+	b := newBuilderWithMockBackend()
+	b.docker.(*MockBackend).mountImageFunc = func(name string) (string, func() error, error) {
+		atomic.AddInt32(&mounts, 1)
+		return root, func() error { return nil }, nil
+	}
+	b.docker.(*MockBackend).commitFunc = func(string, *backend.ContainerCommitConfig) (string, error) {
+		return fmt.Sprintf("image%d", atomic.AddInt32(&commits, 1)), nil
+	}
+	var stdout bytes.Buffer
+	b.clientCtx = context.Background()
+	b.Stdout, b.Stderr, b.Output = &stdout, &stdout, &stdout
+	b.tmpContainers = map[string]struct{}{}
+
+	_, err = b.dispatchDockerfileWithCancellation(result)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Step 8/8 : COPY --from=second /file1 /second/")
+	// Each image is mounted once, even if several stages copy from it.
+	assert.Equal(t, int32(3), atomic.LoadInt32(&mounts))
+}
+
+func TestStageOutputs(t *testing.T) {
+	var buf bytes.Buffer
+	outputs := &stageOutputs{}
+	first, second, third := outputs.add(), outputs.add(), outputs.add()
+
+	// The output of the first stage is written right away, the output of
+	// the others once the stages before them are built.
+	third.writer(&buf).Write([]byte("3"))
+	second.writer(&buf).Write([]byte("2"))
+	first.writer(&buf).Write([]byte("1"))
+	assert.Equal(t, "1", buf.String())
+
+	third.finish()
+	assert.Equal(t, "1", buf.String())
+
+	first.finish()
+	assert.Equal(t, "12", buf.String())
+
+	second.writer(&buf).Write([]byte("2"))
+	assert.Equal(t, "122", buf.String())
+
+	second.finish()
+	assert.Equal(t, "1223", buf.String())
+}
@@ -0,0 +1,84 @@
+package builder
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+type pruneOptions struct {
+	force  bool
+	filter opts.FilterOpt
+}
+
+// NewPruneCommand returns a new cobra prune command for the build cache
+func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
+	opts := pruneOptions{filter: opts.NewFilterOpt()}
+
+	cmd := &cobra.Command{
+		Use:   "prune [OPTIONS]",
+		Short: "Remove build cache",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceReclaimed, output, err := runPrune(dockerCli, opts)
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				fmt.Fprintln(dockerCli.Out(), output)
+			}
+			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
+			return nil
+		},
+		Tags: map[string]string{"version": "1.30"},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
+	flags.Var(&opts.filter, "filter", "Provide filter values (e.g. 'until=24h', 'keep-storage=10GB')")
+
+	return cmd
+}
+
+const warning = `WARNING! This will remove all build cache not used by an image or a container.
+Are you sure you want to continue?`
+
+func runPrune(dockerCli *command.DockerCli, opts pruneOptions) (spaceReclaimed uint64, output string, err error) {
+	if !opts.force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+		return
+	}
+
+	report, err := dockerCli.Client().BuildCachePrune(context.Background(), opts.filter.Value())
+	if err != nil {
+		return
+	}
+
+	if len(report.CachesDeleted) > 0 {
+		output = "Deleted build cache:\n"
+		for _, id := range report.CachesDeleted {
+			output += id + "\n"
+		}
+		spaceReclaimed = report.SpaceReclaimed
+	}
+
+	return
+}
+
+// RunPrune calls the Build Cache Prune API, with the until filter only as
+// the build cache has no labels.
+// This returns the amount of space reclaimed and a detailed output string
+func RunPrune(dockerCli *command.DockerCli, filter opts.FilterOpt) (uint64, string, error) {
+	untilFilter := opts.NewFilterOpt()
+	for _, until := range filter.Value().Get("until") {
+		if err := untilFilter.Set("until=" + until); err != nil {
+			return 0, "", err
+		}
+	}
+	return runPrune(dockerCli, pruneOptions{force: true, filter: untilFilter})
+}
@@ -4,7 +4,9 @@ import (
 	"os"
 
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/builder"
 	"github.com/docker/docker/cli/command/checkpoint"
+	"github.com/docker/docker/cli/command/config"
 	"github.com/docker/docker/cli/command/container"
 	"github.com/docker/docker/cli/command/image"
 	"github.com/docker/docker/cli/command/network"
@@ -23,9 +25,15 @@ import (
 // AddCommands adds all the commands from cli/command to the root command
 func AddCommands(cmd *cobra.Command, dockerCli *command.DockerCli) {
 	cmd.AddCommand(
+		// builder
+		builder.NewBuilderCommand(dockerCli),
+
 		// checkpoint
 		checkpoint.NewCheckpointCommand(dockerCli),
 
+		// config
+		config.NewConfigCommand(dockerCli),
+
 		// container
 		container.NewContainerCommand(dockerCli),
 		container.NewRunCommand(dockerCli),
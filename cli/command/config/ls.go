@@ -0,0 +1,61 @@
+package config
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/formatter"
+	"github.com/docker/docker/opts"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type listOptions struct {
+	quiet  bool
+	format string
+	filter opts.FilterOpt
+}
+
+func newConfigListCommand(dockerCli command.Cli) *cobra.Command {
+	opts := listOptions{filter: opts.NewFilterOpt()}
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List configs",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigList(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display IDs")
+	flags.StringVarP(&opts.format, "format", "", "", "Pretty-print configs using a Go template")
+	flags.VarP(&opts.filter, "filter", "f", "Filter output based on conditions provided")
+
+	return cmd
+}
+
+func runConfigList(dockerCli command.Cli, opts listOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	configs, err := client.ConfigList(ctx, types.ConfigListOptions{Filters: opts.filter.Value()})
+	if err != nil {
+		return err
+	}
+	format := opts.format
+	if len(format) == 0 {
+		if len(dockerCli.ConfigFile().ConfigFormat) > 0 && !opts.quiet {
+			format = dockerCli.ConfigFile().ConfigFormat
+		} else {
+			format = formatter.TableFormatKey
+		}
+	}
+	configCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewConfigFormat(format, opts.quiet),
+	}
+	return formatter.ConfigWrite(configCtx, configs)
+}
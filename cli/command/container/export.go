@@ -11,8 +11,9 @@ import (
 )
 
 type exportOptions struct {
-	container string
-	output    string
+	container   string
+	output      string
+	compression string
 }
 
 // NewExportCommand creates a new `docker export` command
@@ -32,6 +33,7 @@ func NewExportCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.compression, "compression", "none", "Compress the archive (none, gzip, zstd)")
 
 	return cmd
 }
@@ -49,10 +51,16 @@ func runExport(dockerCli *command.DockerCli, opts exportOptions) error {
 	}
 	defer responseBody.Close()
 
+	archive, err := command.CompressReader(responseBody, opts.compression)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
 	if opts.output == "" {
-		_, err := io.Copy(dockerCli.Out(), responseBody)
+		_, err := io.Copy(dockerCli.Out(), archive)
 		return err
 	}
 
-	return command.CopyToFile(opts.output, responseBody)
+	return command.CopyToFile(opts.output, archive)
 }
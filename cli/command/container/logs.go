@@ -14,9 +14,13 @@ import (
 type logsOptions struct {
 	follow     bool
 	since      string
+	until      string
 	timestamps bool
 	details    bool
 	tail       string
+	grep       string
+	stdout     bool
+	stderr     bool
 
 	container string
 }
@@ -41,20 +45,30 @@ func NewLogsCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
 	flags.BoolVar(&opts.details, "details", false, "Show extra details provided to logs")
 	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs")
+	flags.StringVar(&opts.until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	flags.SetAnnotation("until", "version", []string{"1.30"})
+	flags.StringVar(&opts.grep, "grep", "", "Only show the lines matching a regular expression")
+	flags.SetAnnotation("grep", "version", []string{"1.30"})
+	flags.BoolVar(&opts.stdout, "stdout", false, "Only show the logs of the stdout stream")
+	flags.BoolVar(&opts.stderr, "stderr", false, "Only show the logs of the stderr stream")
 	return cmd
 }
 
 func runLogs(dockerCli *command.DockerCli, opts *logsOptions) error {
 	ctx := context.Background()
 
+	// both streams are shown unless one of them is selected
+	showAll := !opts.stdout && !opts.stderr
 	options := types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
+		ShowStdout: showAll || opts.stdout,
+		ShowStderr: showAll || opts.stderr,
 		Since:      opts.since,
+		Until:      opts.until,
 		Timestamps: opts.timestamps,
 		Follow:     opts.follow,
 		Tail:       opts.tail,
 		Details:    opts.details,
+		Grep:       opts.grep,
 	}
 	responseBody, err := dockerCli.Client().ContainerLogs(ctx, opts.container, options)
 	if err != nil {
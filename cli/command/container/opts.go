@@ -101,6 +101,11 @@ type containerOptions struct {
 	ipcMode            string
 	pidsLimit          int64
 	restartPolicy      string
+	restartDelay       time.Duration
+	restartMaxDelay    time.Duration
+	restartMultiplier  float64
+	restartResetWindow time.Duration
+	restartJitter      float64
 	readonlyRootfs     bool
 	loggingDriver      string
 	cgroupParent       string
@@ -111,6 +116,8 @@ type containerOptions struct {
 	shmSize            opts.MemBytes
 	noHealthcheck      bool
 	healthCmd          string
+	healthHTTP         string
+	healthTCP          string
 	healthInterval     time.Duration
 	healthTimeout      time.Duration
 	healthStartPeriod  time.Duration
@@ -175,6 +182,16 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.labelsFile, "label-file", "Read in a line delimited file of labels")
 	flags.BoolVar(&copts.readonlyRootfs, "read-only", false, "Mount the container's root filesystem as read only")
 	flags.StringVar(&copts.restartPolicy, "restart", "no", "Restart policy to apply when a container exits")
+	flags.DurationVar(&copts.restartDelay, "restart-delay", 0, "Delay before the first restart (ns|us|ms|s|m|h) (default 100ms)")
+	flags.SetAnnotation("restart-delay", "version", []string{"1.30"})
+	flags.DurationVar(&copts.restartMaxDelay, "restart-max-delay", 0, "Maximum delay between restarts (ns|us|ms|s|m|h) (default 1m)")
+	flags.SetAnnotation("restart-max-delay", "version", []string{"1.30"})
+	flags.Float64Var(&copts.restartMultiplier, "restart-multiplier", 0, "Factor applied to the delay after each restart (default 2)")
+	flags.SetAnnotation("restart-multiplier", "version", []string{"1.30"})
+	flags.DurationVar(&copts.restartResetWindow, "restart-reset-window", 0, "Run time after which the restart delay is reset (ns|us|ms|s|m|h) (default 10s)")
+	flags.SetAnnotation("restart-reset-window", "version", []string{"1.30"})
+	flags.Float64Var(&copts.restartJitter, "restart-jitter", 0, "Maximum random fraction (0-1) added to each restart delay")
+	flags.SetAnnotation("restart-jitter", "version", []string{"1.30"})
 	flags.StringVar(&copts.stopSignal, "stop-signal", signal.DefaultStopSignal, "Signal to stop a container")
 	flags.IntVar(&copts.stopTimeout, "stop-timeout", 0, "Timeout (in seconds) to stop a container")
 	flags.SetAnnotation("stop-timeout", "version", []string{"1.25"})
@@ -230,6 +247,10 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 
 	// Health-checking
 	flags.StringVar(&copts.healthCmd, "health-cmd", "", "Command to run to check health")
+	flags.StringVar(&copts.healthHTTP, "health-http", "", "HTTP(S) URL to GET from within the container to check health")
+	flags.SetAnnotation("health-http", "version", []string{"1.30"})
+	flags.StringVar(&copts.healthTCP, "health-tcp", "", "[host:]port to connect to from within the container to check health")
+	flags.SetAnnotation("health-tcp", "version", []string{"1.30"})
 	flags.DurationVar(&copts.healthInterval, "health-interval", 0, "Time between running the check (ns|us|ms|s|m|h) (default 0s)")
 	flags.IntVar(&copts.healthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy")
 	flags.DurationVar(&copts.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ns|us|ms|s|m|h) (default 0s)")
@@ -446,6 +467,26 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 	if err != nil {
 		return nil, err
 	}
+	if copts.restartDelay < 0 {
+		return nil, errors.Errorf("--restart-delay cannot be negative")
+	}
+	if copts.restartMaxDelay < 0 {
+		return nil, errors.Errorf("--restart-max-delay cannot be negative")
+	}
+	if copts.restartMultiplier != 0 && copts.restartMultiplier < 1 {
+		return nil, errors.Errorf("--restart-multiplier must be at least 1")
+	}
+	if copts.restartResetWindow < 0 {
+		return nil, errors.Errorf("--restart-reset-window cannot be negative")
+	}
+	if copts.restartJitter < 0 || copts.restartJitter > 1 {
+		return nil, errors.Errorf("--restart-jitter must be between 0 and 1")
+	}
+	restartPolicy.InitialDelay = copts.restartDelay
+	restartPolicy.MaxDelay = copts.restartMaxDelay
+	restartPolicy.Multiplier = copts.restartMultiplier
+	restartPolicy.ResetWindow = copts.restartResetWindow
+	restartPolicy.Jitter = copts.restartJitter
 
 	loggingOpts, err := parseLoggingOpts(copts.loggingDriver, copts.loggingOpts.GetAll())
 	if err != nil {
@@ -465,6 +506,8 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 	// Healthcheck
 	var healthConfig *container.HealthConfig
 	haveHealthSettings := copts.healthCmd != "" ||
+		copts.healthHTTP != "" ||
+		copts.healthTCP != "" ||
 		copts.healthInterval != 0 ||
 		copts.healthTimeout != 0 ||
 		copts.healthStartPeriod != 0 ||
@@ -477,9 +520,22 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		healthConfig = &container.HealthConfig{Test: test}
 	} else if haveHealthSettings {
 		var probe strslice.StrSlice
+		probes := 0
 		if copts.healthCmd != "" {
 			args := []string{"CMD-SHELL", copts.healthCmd}
 			probe = strslice.StrSlice(args)
+			probes++
+		}
+		if copts.healthHTTP != "" {
+			probe = strslice.StrSlice{"HTTP", copts.healthHTTP}
+			probes++
+		}
+		if copts.healthTCP != "" {
+			probe = strslice.StrSlice{"TCP", copts.healthTCP}
+			probes++
+		}
+		if probes > 1 {
+			return nil, errors.Errorf("--health-cmd, --health-http and --health-tcp conflict with each other")
 		}
 		if copts.healthInterval < 0 {
 			return nil, errors.Errorf("--health-interval cannot be negative")
@@ -461,6 +461,35 @@ func TestParseRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestParseRestartBackoff(t *testing.T) {
+	_, hostconfig, _, err := parseRun([]string{"--restart=always", "--restart-delay=1s", "--restart-max-delay=30s", "--restart-multiplier=1.5", "--restart-reset-window=1m", "--restart-jitter=0.2", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   1.5,
+		ResetWindow:  1 * time.Minute,
+		Jitter:       0.2,
+	}
+	if hostconfig.RestartPolicy != expected {
+		t.Fatalf("Expected %v, got %v", expected, hostconfig.RestartPolicy)
+	}
+
+	invalids := map[string]string{
+		"--restart-delay=-1s":      "--restart-delay cannot be negative",
+		"--restart-multiplier=0.5": "--restart-multiplier must be at least 1",
+		"--restart-jitter=2":       "--restart-jitter must be between 0 and 1",
+	}
+	for flag, expectedError := range invalids {
+		if _, _, _, err := parseRun([]string{"--restart=always", flag, "img", "cmd"}); err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected an error with message '%v' for %v, got %v", expectedError, flag, err)
+		}
+	}
+}
+
 func TestParseRestartPolicyAutoRemove(t *testing.T) {
 	expected := "Conflicting options: --restart and --rm"
 	_, _, _, err := parseRun([]string{"--rm", "--restart=always", "img", "cmd"})
@@ -495,6 +524,18 @@ func TestParseHealth(t *testing.T) {
 	if len(health.Test) != 2 || health.Test[0] != "CMD-SHELL" || health.Test[1] != "/check.sh -q" {
 		t.Fatalf("--health-cmd: got %#v", health.Test)
 	}
+
+	health = checkOk("--health-http=http://localhost:8080/health", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "HTTP" || health.Test[1] != "http://localhost:8080/health" {
+		t.Fatalf("--health-http: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-tcp=5432", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "TCP" || health.Test[1] != "5432" {
+		t.Fatalf("--health-tcp: got %#v", health.Test)
+	}
+	checkError("--health-cmd, --health-http and --health-tcp conflict with each other",
+		"--health-cmd=/check.sh", "--health-tcp=5432", "img", "cmd")
 	if health.Timeout != 0 {
 		t.Fatalf("--health-cmd: timeout = %s", health.Timeout)
 	}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stringid"
 	units "github.com/docker/go-units"
 )
 
@@ -15,6 +17,7 @@ const (
 	defaultDiskUsageImageTableFormat     = "table {{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.CreatedSince}} ago\t{{.VirtualSize}}\t{{.SharedSize}}\t{{.UniqueSize}}\t{{.Containers}}"
 	defaultDiskUsageContainerTableFormat = "table {{.ID}}\t{{.Image}}\t{{.Command}}\t{{.LocalVolumes}}\t{{.Size}}\t{{.RunningFor}} ago\t{{.Status}}\t{{.Names}}"
 	defaultDiskUsageVolumeTableFormat    = "table {{.Name}}\t{{.Links}}\t{{.Size}}"
+	defaultDiskUsageBuildCacheFormat     = "table {{.ID}}\t{{.Size}}\t{{.CreatedSince}}\t{{.LastUsedSince}}\t{{.InUse}}"
 	defaultDiskUsageTableFormat          = "table {{.Type}}\t{{.TotalCount}}\t{{.Active}}\t{{.Size}}\t{{.Reclaimable}}"
 
 	typeHeader        = "TYPE"
@@ -24,6 +27,9 @@ const (
 	containersHeader  = "CONTAINERS"
 	sharedSizeHeader  = "SHARED SIZE"
 	uniqueSizeHeader  = "UNIQUE SiZE"
+	cacheIDHeader     = "CACHE ID"
+	lastUsedHeader    = "LAST USED"
+	inUseHeader       = "IN USE"
 )
 
 // DiskUsageContext contains disk usage specific information required by the formatter, encapsulate a Context struct.
@@ -34,6 +40,7 @@ type DiskUsageContext struct {
 	Images     []*types.ImageSummary
 	Containers []*types.Container
 	Volumes    []*types.Volume
+	BuildCache []*types.BuildCache
 }
 
 func (ctx *DiskUsageContext) startSubsection(format string) (*template.Template, error) {
@@ -95,6 +102,13 @@ func (ctx *DiskUsageContext) Write() (err error) {
 			return err
 		}
 
+		err = ctx.contextFormat(tmpl, &diskUsageBuildCacheContext{
+			buildCache: ctx.BuildCache,
+		})
+		if err != nil {
+			return err
+		}
+
 		diskUsageContainersCtx := diskUsageContainersContext{containers: []*types.Container{}}
 		diskUsageContainersCtx.header = map[string]string{
 			"Type":        typeHeader,
@@ -176,6 +190,23 @@ func (ctx *DiskUsageContext) Write() (err error) {
 		}
 	}
 	ctx.postFormat(tmpl, newVolumeContext())
+
+	// And the build cache
+	ctx.Output.Write([]byte("\nBuild cache usage:\n\n"))
+	tmpl, err = ctx.startSubsection(defaultDiskUsageBuildCacheFormat)
+	if err != nil {
+		return
+	}
+	for _, c := range ctx.BuildCache {
+		err = ctx.contextFormat(tmpl, &buildCacheContext{
+			trunc: true,
+			c:     *c,
+		})
+		if err != nil {
+			return
+		}
+	}
+	ctx.postFormat(tmpl, newBuildCacheContext())
 	return
 }
 
@@ -356,3 +387,106 @@ func (c *diskUsageVolumesContext) Reclaimable() string {
 
 	return fmt.Sprintf("%s", units.HumanSize(float64(reclaimable)))
 }
+
+type diskUsageBuildCacheContext struct {
+	HeaderContext
+	buildCache []*types.BuildCache
+}
+
+func (c *diskUsageBuildCacheContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *diskUsageBuildCacheContext) Type() string {
+	return "Build Cache"
+}
+
+func (c *diskUsageBuildCacheContext) TotalCount() string {
+	return fmt.Sprintf("%d", len(c.buildCache))
+}
+
+func (c *diskUsageBuildCacheContext) Active() string {
+	used := 0
+	for _, bc := range c.buildCache {
+		if bc.InUse {
+			used++
+		}
+	}
+
+	return fmt.Sprintf("%d", used)
+}
+
+func (c *diskUsageBuildCacheContext) Size() string {
+	var size int64
+
+	for _, bc := range c.buildCache {
+		size += bc.Size
+	}
+
+	return units.HumanSize(float64(size))
+}
+
+func (c *diskUsageBuildCacheContext) Reclaimable() string {
+	var reclaimable int64
+	var totalSize int64
+
+	for _, bc := range c.buildCache {
+		if !bc.InUse {
+			reclaimable += bc.Size
+		}
+		totalSize += bc.Size
+	}
+
+	if totalSize > 0 {
+		return fmt.Sprintf("%s (%v%%)", units.HumanSize(float64(reclaimable)), (reclaimable*100)/totalSize)
+	}
+
+	return fmt.Sprintf("%s", units.HumanSize(float64(reclaimable)))
+}
+
+type buildCacheContext struct {
+	HeaderContext
+	trunc bool
+	c     types.BuildCache
+}
+
+func newBuildCacheContext() *buildCacheContext {
+	buildCacheCtx := buildCacheContext{}
+	buildCacheCtx.header = map[string]string{
+		"ID":            cacheIDHeader,
+		"Size":          sizeHeader,
+		"CreatedSince":  createdSinceHeader,
+		"LastUsedSince": lastUsedHeader,
+		"InUse":         inUseHeader,
+	}
+	return &buildCacheCtx
+}
+
+func (c *buildCacheContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *buildCacheContext) ID() string {
+	if c.trunc {
+		return stringid.TruncateID(c.c.ID)
+	}
+	return c.c.ID
+}
+
+func (c *buildCacheContext) Size() string {
+	return units.HumanSizeWithPrecision(float64(c.c.Size), 3)
+}
+
+func (c *buildCacheContext) CreatedSince() string {
+	createdAt := time.Unix(c.c.Created, 0)
+	return units.HumanDuration(time.Now().UTC().Sub(createdAt)) + " ago"
+}
+
+func (c *buildCacheContext) LastUsedSince() string {
+	lastUsed := time.Unix(c.c.LastUsed, 0)
+	return units.HumanDuration(time.Now().UTC().Sub(lastUsed)) + " ago"
+}
+
+func (c *buildCacheContext) InUse() string {
+	return fmt.Sprintf("%t", c.c.InUse)
+}
@@ -23,6 +23,7 @@ func TestDiskUsageContextFormatWrite(t *testing.T) {
 Images              0                   0                   0B                  0B
 Containers          0                   0                   0B                  0B
 Local Volumes       0                   0                   0B                  0B
+Build Cache         0                   0                   0B                  0B
 `,
 		},
 		{
@@ -38,6 +39,10 @@ CONTAINER ID        IMAGE               COMMAND             LOCAL VOLUMES
 Local Volumes space usage:
 
 VOLUME NAME         LINKS               SIZE
+
+Build cache usage:
+
+CACHE ID            SIZE                CREATED             LAST USED           IN USE
 `,
 		},
 		// Errors
@@ -70,6 +75,7 @@ VOLUME NAME         LINKS               SIZE
 Images              0                   0                   0B                  0B
 Containers          0                   0                   0B                  0B
 Local Volumes       0                   0                   0B                  0B
+Build Cache         0                   0                   0B                  0B
 `,
 		},
 		{
@@ -82,6 +88,7 @@ Local Volumes       0                   0                   0B
 Images              0
 Containers          0
 Local Volumes       0
+Build Cache         0
 `,
 		},
 		// Raw Format
@@ -109,6 +116,12 @@ active: 0
 size: 0B
 reclaimable: 0B
 
+type: Build Cache
+total: 0
+active: 0
+size: 0B
+reclaimable: 0B
+
 `,
 		},
 	}
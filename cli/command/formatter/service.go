@@ -59,6 +59,9 @@ UpdateConfig:
 {{- end }}
  Max failure ratio: {{ .UpdateMaxFailureRatio }}
  Update order:      {{ .UpdateOrder }}
+{{- if .HasUpdateHealthGracePeriod }}
+ Health grace period: {{ .UpdateHealthGracePeriod }}
+{{- end }}
 {{- end }}
 {{- if .HasRollbackConfig }}
 RollbackConfig:
@@ -295,6 +298,14 @@ func (ctx *serviceInspectContext) UpdateMaxFailureRatio() float32 {
 	return ctx.Service.Spec.UpdateConfig.MaxFailureRatio
 }
 
+func (ctx *serviceInspectContext) HasUpdateHealthGracePeriod() bool {
+	return ctx.Service.Spec.UpdateConfig.HealthGracePeriod.Nanoseconds() > 0
+}
+
+func (ctx *serviceInspectContext) UpdateHealthGracePeriod() time.Duration {
+	return ctx.Service.Spec.UpdateConfig.HealthGracePeriod
+}
+
 func (ctx *serviceInspectContext) HasRollbackConfig() bool {
 	return ctx.Service.Spec.RollbackConfig != nil
 }
@@ -15,6 +15,7 @@ const (
 	volumeNameHeader = "VOLUME NAME"
 	mountpointHeader = "MOUNTPOINT"
 	linksHeader      = "LINKS"
+	inodesHeader     = "INODES"
 	// Status header ?
 )
 
@@ -73,6 +74,7 @@ func newVolumeContext() *volumeContext {
 		"Labels":     labelsHeader,
 		"Links":      linksHeader,
 		"Size":       sizeHeader,
+		"Inodes":     inodesHeader,
 	}
 	return &volumeCtx
 }
@@ -124,8 +126,15 @@ func (c *volumeContext) Links() string {
 }
 
 func (c *volumeContext) Size() string {
-	if c.v.UsageData == nil {
+	if c.v.UsageData == nil || c.v.UsageData.Size == -1 {
 		return "N/A"
 	}
 	return units.HumanSize(float64(c.v.UsageData.Size))
 }
+
+func (c *volumeContext) Inodes() string {
+	if c.v.UsageData == nil || c.v.UsageData.Inodes == -1 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d", c.v.UsageData.Inodes)
+}
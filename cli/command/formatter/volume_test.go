@@ -38,6 +38,18 @@ func TestVolumeContext(t *testing.T) {
 		{volumeContext{
 			v: types.Volume{Labels: map[string]string{"label1": "value1", "label2": "value2"}},
 		}, "label1=value1,label2=value2", ctx.Labels},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: 2048, Inodes: 3, RefCount: 1}},
+		}, "2.048kB", ctx.Size},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: 2048, Inodes: 3, RefCount: 1}},
+		}, "3", ctx.Inodes},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: -1, Inodes: -1, RefCount: 1}},
+		}, "N/A", ctx.Size},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: -1, Inodes: -1, RefCount: 1}},
+		}, "N/A", ctx.Inodes},
 	}
 
 	for _, c := range cases {
@@ -144,8 +156,8 @@ func TestVolumeContextWriteJSON(t *testing.T) {
 		{Driver: "bar", Name: "foobar_bar"},
 	}
 	expectedJSONs := []map[string]interface{}{
-		{"Driver": "foo", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_baz", "Scope": "", "Size": "N/A"},
-		{"Driver": "bar", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_bar", "Scope": "", "Size": "N/A"},
+		{"Driver": "foo", "Inodes": "N/A", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_baz", "Scope": "", "Size": "N/A"},
+		{"Driver": "bar", "Inodes": "N/A", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_bar", "Scope": "", "Size": "N/A"},
 	}
 	out := bytes.NewBufferString("")
 	err := VolumeWrite(Context{Format: "{{json .}}", Output: out}, volumes)
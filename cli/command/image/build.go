@@ -17,6 +17,7 @@ import (
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/builder/dockerignore"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
@@ -61,10 +62,12 @@ type buildOptions struct {
 	pull           bool
 	cacheFrom      []string
 	compress       bool
+	compression    string
 	securityOpt    []string
 	networkMode    string
 	squash         bool
 	target         string
+	secrets        opts.BuildSecretOpt
 }
 
 // NewBuildCommand creates a new `docker build` command
@@ -112,11 +115,15 @@ func NewBuildCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVar(&options.pull, "pull", false, "Always attempt to pull a newer version of the image")
 	flags.StringSliceVar(&options.cacheFrom, "cache-from", []string{}, "Images to consider as cache sources")
 	flags.BoolVar(&options.compress, "compress", false, "Compress the build context using gzip")
+	flags.StringVar(&options.compression, "compression", "", "Compress the build context using the given compression (gzip, zstd)")
+	flags.SetAnnotation("compression", "version", []string{"1.30"})
 	flags.StringSliceVar(&options.securityOpt, "security-opt", []string{}, "Security options")
 	flags.StringVar(&options.networkMode, "network", "default", "Set the networking mode for the RUN instructions during build")
 	flags.SetAnnotation("network", "version", []string{"1.25"})
 	flags.Var(&options.extraHosts, "add-host", "Add a custom host-to-IP mapping (host:ip)")
 	flags.StringVar(&options.target, "target", "", "Set the target build stage to build.")
+	flags.Var(&options.secrets, "secret", "Secret file to expose to the RUN instructions (format: id=mysecret,src=/path/to/file)")
+	flags.SetAnnotation("secret", "version", []string{"1.30"})
 
 	command.AddTrustVerificationFlags(flags)
 
@@ -127,6 +134,47 @@ func NewBuildCommand(dockerCli *command.DockerCli) *cobra.Command {
 	return cmd
 }
 
+// contextCompression returns the compression of the build context sent to
+// the daemon.
+func contextCompression(dockerCli *command.DockerCli, options buildOptions) (archive.Compression, error) {
+	if options.compression == "" {
+		if options.compress {
+			return archive.Gzip, nil
+		}
+		return archive.Uncompressed, nil
+	}
+	compression, err := archive.ParseCompression(options.compression)
+	if err != nil {
+		return archive.Uncompressed, err
+	}
+	switch compression {
+	case archive.Uncompressed, archive.Gzip:
+	case archive.Zstd:
+		if versions.LessThan(dockerCli.Client().ClientVersion(), "1.30") {
+			return archive.Uncompressed, errors.Errorf("zstd compression of the build context requires API version 1.30 or greater")
+		}
+	default:
+		return archive.Uncompressed, errors.Errorf("unsupported build context compression %q", options.compression)
+	}
+	return compression, nil
+}
+
+// readBuildSecrets reads the content of the secret files, by secret id.
+func readBuildSecrets(files map[string]string) (map[string][]byte, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	secrets := make(map[string][]byte, len(files))
+	for id, src := range files {
+		data, err := ioutil.ReadFile(src)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read secret %s", id)
+		}
+		secrets[id] = data
+	}
+	return secrets, nil
+}
+
 // lastProgressOutput is the same as progress.Output except
 // that it only output with the last update. It is used in
 // non terminal scenarios to suppress verbose messages
@@ -235,9 +283,9 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 			excludes = append(excludes, "!"+relDockerfile)
 		}
 
-		compression := archive.Uncompressed
-		if options.compress {
-			compression = archive.Gzip
+		compression, err := contextCompression(dockerCli, options)
+		if err != nil {
+			return err
 		}
 		buildCtx, err = archive.TarWithOptions(contextDir, &archive.TarOptions{
 			Compression:     compression,
@@ -276,6 +324,11 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 
 	var body io.Reader = progress.NewProgressReader(buildCtx, progressOutput, 0, "", "Sending build context to Docker daemon")
 
+	secrets, err := readBuildSecrets(options.secrets.Value())
+	if err != nil {
+		return err
+	}
+
 	authConfigs, _ := dockerCli.GetAllCredentials()
 	buildOptions := types.ImageBuildOptions{
 		Memory:         options.memory.Value(),
@@ -305,6 +358,7 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		Squash:         options.squash,
 		ExtraHosts:     options.extraHosts.GetAll(),
 		Target:         options.target,
+		Secrets:        secrets,
 	}
 
 	response, err := dockerCli.Client().ImageBuild(ctx, body, buildOptions)
@@ -7,6 +7,7 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
 	"github.com/docker/docker/registry"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -14,8 +15,9 @@ import (
 )
 
 type pullOptions struct {
-	remote string
-	all    bool
+	remote       string
+	all          bool
+	maxBandwidth opts.MemBytes
 }
 
 // NewPullCommand creates a new `docker pull` command
@@ -35,6 +37,8 @@ func NewPullCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.BoolVarP(&opts.all, "all-tags", "a", false, "Download all tagged images in the repository")
+	flags.Var(&opts.maxBandwidth, "max-bandwidth", "Limit the download bandwidth, in bytes per second")
+	flags.SetAnnotation("max-bandwidth", "version", []string{"1.30"})
 	command.AddTrustVerificationFlags(flags)
 
 	return cmd
@@ -70,9 +74,9 @@ func runPull(dockerCli *command.DockerCli, opts pullOptions) error {
 	// Check if reference has a digest
 	_, isCanonical := distributionRef.(reference.Canonical)
 	if command.IsTrusted() && !isCanonical {
-		err = trustedPull(ctx, dockerCli, repoInfo, distributionRef, authConfig, requestPrivilege)
+		err = trustedPull(ctx, dockerCli, repoInfo, distributionRef, authConfig, requestPrivilege, opts.maxBandwidth.Value())
 	} else {
-		err = imagePullPrivileged(ctx, dockerCli, authConfig, reference.FamiliarString(distributionRef), requestPrivilege, opts.all)
+		err = imagePullPrivileged(ctx, dockerCli, authConfig, reference.FamiliarString(distributionRef), requestPrivilege, opts.all, opts.maxBandwidth.Value())
 	}
 	if err != nil {
 		if strings.Contains(err.Error(), "when fetching 'plugin'") {
@@ -6,31 +6,45 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/registry"
 	"github.com/spf13/cobra"
 )
 
+type pushOptions struct {
+	remote       string
+	compression  string
+	maxBandwidth opts.MemBytes
+}
+
 // NewPushCommand creates a new `docker push` command
 func NewPushCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts pushOptions
+
 	cmd := &cobra.Command{
 		Use:   "push [OPTIONS] NAME[:TAG]",
 		Short: "Push an image or a repository to a registry",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPush(dockerCli, args[0])
+			opts.remote = args[0]
+			return runPush(dockerCli, opts)
 		},
 	}
 
 	flags := cmd.Flags()
+	flags.StringVar(&opts.compression, "compression", "", "Compression of the pushed layers (gzip, zstd)")
+	flags.SetAnnotation("compression", "version", []string{"1.30"})
+	flags.Var(&opts.maxBandwidth, "max-bandwidth", "Limit the upload bandwidth, in bytes per second")
+	flags.SetAnnotation("max-bandwidth", "version", []string{"1.30"})
 
 	command.AddTrustSigningFlags(flags)
 
 	return cmd
 }
 
-func runPush(dockerCli *command.DockerCli, remote string) error {
-	ref, err := reference.ParseNormalizedNamed(remote)
+func runPush(dockerCli *command.DockerCli, opts pushOptions) error {
+	ref, err := reference.ParseNormalizedNamed(opts.remote)
 	if err != nil {
 		return err
 	}
@@ -48,10 +62,10 @@ func runPush(dockerCli *command.DockerCli, remote string) error {
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "push")
 
 	if command.IsTrusted() {
-		return trustedPush(ctx, dockerCli, repoInfo, ref, authConfig, requestPrivilege)
+		return trustedPush(ctx, dockerCli, repoInfo, ref, authConfig, requestPrivilege, opts.compression, opts.maxBandwidth.Value())
 	}
 
-	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref, requestPrivilege)
+	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref, requestPrivilege, opts.compression, opts.maxBandwidth.Value())
 	if err != nil {
 		return err
 	}
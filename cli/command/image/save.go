@@ -3,6 +3,7 @@ package image
 import (
 	"io"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/pkg/errors"
@@ -11,8 +12,10 @@ import (
 )
 
 type saveOptions struct {
-	images []string
-	output string
+	images      []string
+	output      string
+	compression string
+	format      string
 }
 
 // NewSaveCommand creates a new `docker save` command
@@ -32,6 +35,9 @@ func NewSaveCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.compression, "compression", "none", "Compress the archive (none, gzip, zstd)")
+	flags.StringVar(&opts.format, "format", "", "Format of the archive (docker, oci)")
+	flags.SetAnnotation("format", "version", []string{"1.30"})
 
 	return cmd
 }
@@ -41,16 +47,22 @@ func runSave(dockerCli *command.DockerCli, opts saveOptions) error {
 		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
 	}
 
-	responseBody, err := dockerCli.Client().ImageSave(context.Background(), opts.images)
+	responseBody, err := dockerCli.Client().ImageSaveWithOptions(context.Background(), opts.images, types.ImageSaveOptions{Format: opts.format})
 	if err != nil {
 		return err
 	}
 	defer responseBody.Close()
 
+	archive, err := command.CompressReader(responseBody, opts.compression)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
 	if opts.output == "" {
-		_, err := io.Copy(dockerCli.Out(), responseBody)
+		_, err := io.Copy(dockerCli.Out(), archive)
 		return err
 	}
 
-	return command.CopyToFile(opts.output, responseBody)
+	return command.CopyToFile(opts.output, archive)
 }
@@ -2,6 +2,7 @@ package prune
 
 import (
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/builder"
 	"github.com/docker/docker/cli/command/container"
 	"github.com/docker/docker/cli/command/image"
 	"github.com/docker/docker/cli/command/network"
@@ -30,6 +31,11 @@ func NewNetworkPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 	return network.NewPruneCommand(dockerCli)
 }
 
+// NewBuildCachePruneCommand returns a cobra prune command for the build cache
+func NewBuildCachePruneCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return builder.NewPruneCommand(dockerCli)
+}
+
 // RunContainerPrune executes a prune command for containers
 func RunContainerPrune(dockerCli *command.DockerCli, filter opts.FilterOpt) (uint64, string, error) {
 	return container.RunPrune(dockerCli, filter)
@@ -49,3 +55,8 @@ func RunImagePrune(dockerCli *command.DockerCli, all bool, filter opts.FilterOpt
 func RunNetworkPrune(dockerCli *command.DockerCli, filter opts.FilterOpt) (uint64, string, error) {
 	return network.RunPrune(dockerCli, filter)
 }
+
+// RunBuildCachePrune executes a prune command for the build cache
+func RunBuildCachePrune(dockerCli *command.DockerCli, filter opts.FilterOpt) (uint64, string, error) {
+	return builder.RunPrune(dockerCli, filter)
+}
@@ -192,12 +192,13 @@ func (s *ShlexOpt) Value() []string {
 }
 
 type updateOptions struct {
-	parallelism     uint64
-	delay           time.Duration
-	monitor         time.Duration
-	onFailure       string
-	maxFailureRatio floatValue
-	order           string
+	parallelism       uint64
+	delay             time.Duration
+	monitor           time.Duration
+	onFailure         string
+	maxFailureRatio   floatValue
+	order             string
+	healthGracePeriod time.Duration
 }
 
 func updateConfigFromDefaults(defaultUpdateConfig *api.UpdateConfig) *swarm.UpdateConfig {
@@ -214,7 +215,7 @@ func updateConfigFromDefaults(defaultUpdateConfig *api.UpdateConfig) *swarm.Upda
 }
 
 func (opts updateOptions) updateConfig(flags *pflag.FlagSet) *swarm.UpdateConfig {
-	if !anyChanged(flags, flagUpdateParallelism, flagUpdateDelay, flagUpdateMonitor, flagUpdateFailureAction, flagUpdateMaxFailureRatio) {
+	if !anyChanged(flags, flagUpdateParallelism, flagUpdateDelay, flagUpdateMonitor, flagUpdateFailureAction, flagUpdateMaxFailureRatio, flagUpdateHealthGracePeriod) {
 		return nil
 	}
 
@@ -238,6 +239,9 @@ func (opts updateOptions) updateConfig(flags *pflag.FlagSet) *swarm.UpdateConfig
 	if flags.Changed(flagUpdateOrder) {
 		updateConfig.Order = opts.order
 	}
+	if flags.Changed(flagUpdateHealthGracePeriod) {
+		updateConfig.HealthGracePeriod = opts.healthGracePeriod
+	}
 
 	return updateConfig
 }
@@ -548,6 +552,7 @@ type serviceOptions struct {
 
 	healthcheck healthCheckOptions
 	secrets     opts.SecretOpt
+	configs     opts.ConfigOpt
 }
 
 func newServiceOptions() *serviceOptions {
@@ -779,6 +784,8 @@ func addServiceFlags(flags *pflag.FlagSet, opts *serviceOptions, defaultFlagValu
 	flags.SetAnnotation(flagUpdateMaxFailureRatio, "version", []string{"1.25"})
 	flags.StringVar(&opts.update.order, flagUpdateOrder, "", flagDesc(flagUpdateOrder, `Update order ("start-first"|"stop-first")`))
 	flags.SetAnnotation(flagUpdateOrder, "version", []string{"1.29"})
+	flags.DurationVar(&opts.update.healthGracePeriod, flagUpdateHealthGracePeriod, 0, "Time for updated tasks with a health check to become healthy (ns|us|ms|s|m|h)")
+	flags.SetAnnotation(flagUpdateHealthGracePeriod, "version", []string{"1.30"})
 
 	flags.Uint64Var(&opts.rollback.parallelism, flagRollbackParallelism, defaultFlagValues.getUint64(flagRollbackParallelism), "Maximum number of tasks rolled back simultaneously (0 to roll back all at once)")
 	flags.SetAnnotation(flagRollbackParallelism, "version", []string{"1.28"})
@@ -891,6 +898,7 @@ const (
 	flagTTY                     = "tty"
 	flagUpdateDelay             = "update-delay"
 	flagUpdateFailureAction     = "update-failure-action"
+	flagUpdateHealthGracePeriod = "update-health-grace-period"
 	flagUpdateMaxFailureRatio   = "update-max-failure-ratio"
 	flagUpdateMonitor           = "update-monitor"
 	flagUpdateOrder             = "update-order"
@@ -909,4 +917,7 @@ const (
 	flagSecret                  = "secret"
 	flagSecretAdd               = "secret-add"
 	flagSecretRemove            = "secret-rm"
+	flagConfig                  = "config"
+	flagConfigAdd               = "config-add"
+	flagConfigRemove            = "config-rm"
 )
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -82,6 +83,7 @@ func ServiceProgress(ctx context.Context, client client.APIClient, serviceID str
 		convergedAt time.Time
 		monitor     = 5 * time.Second
 		rollback    bool
+		health      = &healthTracker{}
 	)
 
 	for {
@@ -141,6 +143,13 @@ func ServiceProgress(ctx context.Context, client client.APIClient, serviceID str
 			return err
 		}
 
+		if health.update(tasks) {
+			progressOut.WriteProgress(progress.Progress{
+				ID:     "health",
+				Action: "Detected task which did not become healthy",
+			})
+		}
+
 		converged, err = updater.update(service, tasks, activeNodes, rollback)
 		if err != nil {
 			return err
@@ -215,17 +224,81 @@ func initializeUpdater(service swarm.Service, progressOut progress.Output) (prog
 	return nil, errors.New("unrecognized service mode")
 }
 
-func writeOverallProgress(progressOut progress.Output, numerator, denominator int, rollback bool) {
+// The errors of the tasks whose container did not become healthy, as reported
+// by the container executor of the daemon.
+var unhealthyTaskErrors = []string{
+	"dockerexec: unhealthy container",
+	"dockerexec: container not healthy within the health grace period",
+}
+
+// healthcheckDisabled returns whether the service disables the health check
+// of the image of its tasks.
+func healthcheckDisabled(service swarm.Service) bool {
+	healthcheck := service.Spec.TaskTemplate.ContainerSpec.Healthcheck
+	return healthcheck != nil && len(healthcheck.Test) > 0 && healthcheck.Test[0] == "NONE"
+}
+
+// waitingToBeHealthy returns whether the task is waiting for its container to
+// become healthy. A task stays in the starting state until its container is
+// healthy if it has a health check, set in the service or in the image.
+// Without a health check, it only does while the container starts.
+func waitingToBeHealthy(service swarm.Service, task swarm.Task) bool {
+	if task.Status.State != swarm.TaskStateStarting || healthcheckDisabled(service) {
+		return false
+	}
+	return task.Status.ContainerStatus.ContainerID != ""
+}
+
+// unhealthy returns whether the task failed because its container became
+// unhealthy, or did not become healthy within the health grace period.
+func unhealthy(task swarm.Task) bool {
+	if task.Status.State != swarm.TaskStateFailed {
+		return false
+	}
+	for _, msg := range unhealthyTaskErrors {
+		if strings.Contains(task.Status.Err, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// healthTracker detects the tasks which failed because their container did
+// not become healthy.
+type healthTracker struct {
+	reported map[string]bool // unhealthy tasks already reported
+}
+
+// update returns whether one of the tasks failed because its container did
+// not become healthy since the last update.
+func (h *healthTracker) update(tasks []swarm.Task) bool {
+	if h.reported == nil {
+		h.reported = make(map[string]bool)
+	}
+	failed := false
+	for _, task := range tasks {
+		if unhealthy(task) && !h.reported[task.ID] {
+			h.reported[task.ID] = true
+			failed = true
+		}
+	}
+	return failed
+}
+
+// writeOverallProgress writes the number of running tasks out of the number
+// of tasks to converge. waiting is the number of tasks waiting for their
+// container to become healthy, which is only shown when non-zero.
+func writeOverallProgress(progressOut progress.Output, numerator, denominator, waiting int, rollback bool) {
+	action := fmt.Sprintf("%d out of %d tasks", numerator, denominator)
+	if waiting > 0 {
+		action += fmt.Sprintf(" (%d waiting to be healthy)", waiting)
+	}
 	if rollback {
-		progressOut.WriteProgress(progress.Progress{
-			ID:     "overall progress",
-			Action: fmt.Sprintf("rolling back update: %d out of %d tasks", numerator, denominator),
-		})
-		return
+		action = "rolling back update: " + action
 	}
 	progressOut.WriteProgress(progress.Progress{
 		ID:     "overall progress",
-		Action: fmt.Sprintf("%d out of %d tasks", numerator, denominator),
+		Action: action,
 	})
 }
 
@@ -250,7 +323,7 @@ func (u *replicatedProgressUpdater) update(service swarm.Service, tasks []swarm.
 		u.slotMap = make(map[int]int)
 
 		// Draw progress bars in order
-		writeOverallProgress(u.progressOut, 0, int(replicas), rollback)
+		writeOverallProgress(u.progressOut, 0, int(replicas), 0, rollback)
 
 		if replicas <= maxProgressBars {
 			for i := uint64(1); i <= replicas; i++ {
@@ -289,6 +362,7 @@ func (u *replicatedProgressUpdater) update(service swarm.Service, tasks []swarm.
 	}
 
 	running := uint64(0)
+	waiting := 0
 
 	for _, task := range tasksBySlot {
 		mappedSlot := u.slotMap[task.Slot]
@@ -308,11 +382,13 @@ func (u *replicatedProgressUpdater) update(service swarm.Service, tasks []swarm.
 		}
 		if task.Status.State == swarm.TaskStateRunning {
 			running++
+		} else if waitingToBeHealthy(service, task) {
+			waiting++
 		}
 	}
 
 	if !u.done {
-		writeOverallProgress(u.progressOut, int(running), int(replicas), rollback)
+		writeOverallProgress(u.progressOut, int(running), int(replicas), waiting, rollback)
 
 		if running == replicas {
 			u.done = true
@@ -364,7 +440,7 @@ func (u *globalProgressUpdater) update(service swarm.Service, tasks []swarm.Task
 			return false, nil
 		}
 
-		writeOverallProgress(u.progressOut, 0, nodeCount, rollback)
+		writeOverallProgress(u.progressOut, 0, nodeCount, 0, rollback)
 		u.initialized = true
 	}
 
@@ -379,6 +455,7 @@ func (u *globalProgressUpdater) update(service swarm.Service, tasks []swarm.Task
 	}
 
 	running := 0
+	waiting := 0
 
 	for _, task := range tasksByNode {
 		if node, nodeActive := activeNodes[task.NodeID]; nodeActive {
@@ -393,12 +470,14 @@ func (u *globalProgressUpdater) update(service swarm.Service, tasks []swarm.Task
 			}
 			if task.Status.State == swarm.TaskStateRunning {
 				running++
+			} else if waitingToBeHealthy(service, task) {
+				waiting++
 			}
 		}
 	}
 
 	if !u.done {
-		writeOverallProgress(u.progressOut, running, nodeCount, rollback)
+		writeOverallProgress(u.progressOut, running, nodeCount, waiting, rollback)
 
 		if running == nodeCount {
 			u.done = true
@@ -0,0 +1,138 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProgress struct {
+	p []progress.Progress
+}
+
+func (m *mockProgress) WriteProgress(p progress.Progress) error {
+	m.p = append(m.p, p)
+	return nil
+}
+
+func (m *mockProgress) action(id string) string {
+	action := ""
+	for _, p := range m.p {
+		if p.ID == id {
+			action = p.Action
+		}
+	}
+	return action
+}
+
+func replicatedService(replicas uint64) swarm.Service {
+	service := swarm.Service{}
+	service.Spec.Mode.Replicated = &swarm.ReplicatedService{Replicas: &replicas}
+	return service
+}
+
+func task(id string, slot int, state swarm.TaskState) swarm.Task {
+	return swarm.Task{
+		ID:           id,
+		Slot:         slot,
+		DesiredState: swarm.TaskStateRunning,
+		Status: swarm.TaskStatus{
+			State:           state,
+			ContainerStatus: swarm.ContainerStatus{ContainerID: "container-" + id},
+		},
+	}
+}
+
+func failedTask(id string, slot int, err string) swarm.Task {
+	t := task(id, slot, swarm.TaskStateFailed)
+	t.Status.Err = err
+	return t
+}
+
+func TestWaitingToBeHealthy(t *testing.T) {
+	service := replicatedService(1)
+	assert.True(t, waitingToBeHealthy(service, task("a", 1, swarm.TaskStateStarting)))
+	assert.False(t, waitingToBeHealthy(service, task("a", 1, swarm.TaskStateRunning)))
+
+	// The container of the task is not created yet.
+	assert.False(t, waitingToBeHealthy(service, swarm.Task{Status: swarm.TaskStatus{State: swarm.TaskStateStarting}}))
+
+	// The health check of the image is disabled.
+	service.Spec.TaskTemplate.ContainerSpec.Healthcheck = &container.HealthConfig{Test: []string{"NONE"}}
+	assert.False(t, waitingToBeHealthy(service, task("a", 1, swarm.TaskStateStarting)))
+}
+
+func TestWriteOverallProgress(t *testing.T) {
+	out := &mockProgress{}
+
+	writeOverallProgress(out, 1, 3, 0, false)
+	assert.Equal(t, "1 out of 3 tasks", out.action("overall progress"))
+
+	writeOverallProgress(out, 1, 3, 2, false)
+	assert.Equal(t, "1 out of 3 tasks (2 waiting to be healthy)", out.action("overall progress"))
+
+	writeOverallProgress(out, 1, 3, 2, true)
+	assert.Equal(t, "rolling back update: 1 out of 3 tasks (2 waiting to be healthy)", out.action("overall progress"))
+}
+
+func TestHealthTracker(t *testing.T) {
+	h := &healthTracker{}
+
+	assert.False(t, h.update([]swarm.Task{
+		task("a", 1, swarm.TaskStateStarting),
+		task("b", 2, swarm.TaskStateRunning),
+	}))
+
+	// A task failing for another reason is not reported as unhealthy.
+	assert.False(t, h.update([]swarm.Task{
+		task("a", 1, swarm.TaskStateStarting),
+		failedTask("b", 2, "task: non-zero exit (1)"),
+	}))
+
+	// A task which became unhealthy is, even if it was running.
+	assert.True(t, h.update([]swarm.Task{
+		task("a", 1, swarm.TaskStateStarting),
+		failedTask("b", 2, "task: non-zero exit (137): dockerexec: unhealthy container"),
+	}))
+
+	// It is only reported once.
+	assert.False(t, h.update([]swarm.Task{
+		task("a", 1, swarm.TaskStateStarting),
+		failedTask("b", 2, "task: non-zero exit (137): dockerexec: unhealthy container"),
+	}))
+
+	// A task which did not become healthy in time is.
+	assert.True(t, h.update([]swarm.Task{
+		failedTask("a", 1, "task: non-zero exit (137): dockerexec: container not healthy within the health grace period"),
+	}))
+}
+
+func TestReplicatedProgressWaiting(t *testing.T) {
+	out := &mockProgress{}
+	u := &replicatedProgressUpdater{progressOut: out}
+	activeNodes := map[string]swarm.Node{"": {}}
+
+	service := replicatedService(2)
+	converged, err := u.update(service, []swarm.Task{
+		task("a", 1, swarm.TaskStateStarting),
+		task("b", 2, swarm.TaskStateRunning),
+	}, activeNodes, false)
+	require.NoError(t, err)
+	assert.False(t, converged)
+	assert.Equal(t, "1 out of 2 tasks (1 waiting to be healthy)", out.action("overall progress"))
+
+	// Without a health check, starting tasks are not waiting to be healthy.
+	out = &mockProgress{}
+	u = &replicatedProgressUpdater{progressOut: out}
+	service.Spec.TaskTemplate.ContainerSpec.Healthcheck = &container.HealthConfig{Test: []string{"NONE"}}
+	_, err = u.update(service, []swarm.Task{
+		task("a", 1, swarm.TaskStateStarting),
+		task("b", 2, swarm.TaskStateRunning),
+	}, activeNodes, false)
+	require.NoError(t, err)
+	assert.Equal(t, "1 out of 2 tasks", out.action("overall progress"))
+}
@@ -451,6 +451,64 @@ func TestUpdateSecretUpdateInPlace(t *testing.T) {
 	assert.Equal(t, "foo2", updatedSecrets[0].File.Name)
 }
 
+type configAPIClientMock struct {
+	listResult []swarm.Config
+}
+
+func (s configAPIClientMock) ConfigList(ctx context.Context, options types.ConfigListOptions) ([]swarm.Config, error) {
+	return s.listResult, nil
+}
+func (s configAPIClientMock) ConfigCreate(ctx context.Context, config swarm.ConfigSpec) (types.ConfigCreateResponse, error) {
+	return types.ConfigCreateResponse{}, nil
+}
+func (s configAPIClientMock) ConfigRemove(ctx context.Context, id string) error {
+	return nil
+}
+func (s configAPIClientMock) ConfigInspectWithRaw(ctx context.Context, name string) (swarm.Config, []byte, error) {
+	return swarm.Config{}, []byte{}, nil
+}
+func (s configAPIClientMock) ConfigUpdate(ctx context.Context, id string, version swarm.Version, config swarm.ConfigSpec) error {
+	return nil
+}
+
+// TestUpdateConfigUpdateInPlace tests the ability to update the "target" of a config with "docker service update"
+// by combining "--config-rm" and "--config-add" for the same config.
+func TestUpdateConfigUpdateInPlace(t *testing.T) {
+	apiClient := configAPIClientMock{
+		listResult: []swarm.Config{
+			{
+				ID:   "tn9qiblgnuuut11eufquw5dev",
+				Spec: swarm.ConfigSpec{Annotations: swarm.Annotations{Name: "foo"}},
+			},
+		},
+	}
+
+	flags := newUpdateCommand(nil).Flags()
+	flags.Set("config-add", "source=foo,target=foo2")
+	flags.Set("config-rm", "foo")
+
+	configs := []*swarm.ConfigReference{
+		{
+			File: &swarm.ConfigReferenceFileTarget{
+				Name: "foo",
+				UID:  "0",
+				GID:  "0",
+				Mode: 292,
+			},
+			ConfigID:   "tn9qiblgnuuut11eufquw5dev",
+			ConfigName: "foo",
+		},
+	}
+
+	updatedConfigs, err := getUpdatedConfigs(apiClient, flags, configs)
+
+	assert.NoError(t, err)
+	require.Len(t, updatedConfigs, 1)
+	assert.Equal(t, "tn9qiblgnuuut11eufquw5dev", updatedConfigs[0].ConfigID)
+	assert.Equal(t, "foo", updatedConfigs[0].ConfigName)
+	assert.Equal(t, "foo2", updatedConfigs[0].File.Name)
+}
+
 func TestUpdateReadOnly(t *testing.T) {
 	spec := &swarm.ServiceSpec{}
 	cspec := &spec.TaskTemplate.ContainerSpec
@@ -494,3 +552,19 @@ func TestUpdateStopSignal(t *testing.T) {
 	updateService(nil, nil, flags, spec)
 	assert.Equal(t, "SIGWINCH", cspec.StopSignal)
 }
+
+func TestUpdateHealthGracePeriod(t *testing.T) {
+	flags := newUpdateCommand(nil).Flags()
+	flags.Set("update-health-grace-period", "30s")
+
+	spec := &swarm.ServiceSpec{}
+	updateService(nil, nil, flags, spec)
+	require.NotNil(t, spec.UpdateConfig)
+	assert.Equal(t, 30*time.Second, spec.UpdateConfig.HealthGracePeriod)
+
+	flags = newUpdateCommand(nil).Flags()
+	flags.Set("update-max-failure-ratio", "0.2")
+	updateService(nil, nil, flags, spec)
+	assert.Equal(t, float32(0.2), spec.UpdateConfig.MaxFailureRatio)
+	assert.Equal(t, 30*time.Second, spec.UpdateConfig.HealthGracePeriod)
+}
@@ -14,20 +14,30 @@ import (
 type fakeClient struct {
 	client.Client
 
+	version string
+
 	services []string
 	networks []string
 	secrets  []string
+	configs  []string
 
 	removedServices []string
 	removedNetworks []string
 	removedSecrets  []string
+	removedConfigs  []string
 
 	serviceListFunc   func(options types.ServiceListOptions) ([]swarm.Service, error)
 	networkListFunc   func(options types.NetworkListOptions) ([]types.NetworkResource, error)
 	secretListFunc    func(options types.SecretListOptions) ([]swarm.Secret, error)
+	configListFunc    func(options types.ConfigListOptions) ([]swarm.Config, error)
 	serviceRemoveFunc func(serviceID string) error
 	networkRemoveFunc func(networkID string) error
 	secretRemoveFunc  func(secretID string) error
+	configRemoveFunc  func(configID string) error
+}
+
+func (cli *fakeClient) ClientVersion() string {
+	return cli.version
 }
 
 func (cli *fakeClient) ServiceList(ctx context.Context, options types.ServiceListOptions) ([]swarm.Service, error) {
@@ -75,6 +85,21 @@ func (cli *fakeClient) SecretList(ctx context.Context, options types.SecretListO
 	return secretsList, nil
 }
 
+func (cli *fakeClient) ConfigList(ctx context.Context, options types.ConfigListOptions) ([]swarm.Config, error) {
+	if cli.configListFunc != nil {
+		return cli.configListFunc(options)
+	}
+
+	namespace := namespaceFromFilters(options.Filters)
+	configsList := []swarm.Config{}
+	for _, name := range cli.configs {
+		if belongToNamespace(name, namespace) {
+			configsList = append(configsList, configFromName(name))
+		}
+	}
+	return configsList, nil
+}
+
 func (cli *fakeClient) ServiceRemove(ctx context.Context, serviceID string) error {
 	if cli.serviceRemoveFunc != nil {
 		return cli.serviceRemoveFunc(serviceID)
@@ -102,6 +127,15 @@ func (cli *fakeClient) SecretRemove(ctx context.Context, secretID string) error
 	return nil
 }
 
+func (cli *fakeClient) ConfigRemove(ctx context.Context, configID string) error {
+	if cli.configRemoveFunc != nil {
+		return cli.configRemoveFunc(configID)
+	}
+
+	cli.removedConfigs = append(cli.removedConfigs, configID)
+	return nil
+}
+
 func serviceFromName(name string) swarm.Service {
 	return swarm.Service{
 		ID: "ID-" + name,
@@ -127,6 +161,15 @@ func secretFromName(name string) swarm.Secret {
 	}
 }
 
+func configFromName(name string) swarm.Config {
+	return swarm.Config{
+		ID: "ID-" + name,
+		Spec: swarm.ConfigSpec{
+			Annotations: swarm.Annotations{Name: name},
+		},
+	}
+}
+
 func namespaceFromFilters(filters filters.Args) string {
 	label := filters.Get("label")[0]
 	return strings.TrimPrefix(label, convert.LabelNamespace+"=")
@@ -6,6 +6,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/pkg/errors"
@@ -55,13 +56,24 @@ func runRemove(dockerCli command.Cli, opts removeOptions) error {
 			return err
 		}
 
-		if len(services)+len(networks)+len(secrets) == 0 {
+		var configs []swarm.Config
+
+		version := client.ClientVersion()
+		if versions.GreaterThanOrEqualTo(version, "1.30") {
+			configs, err = getStackConfigs(ctx, client, namespace)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(services)+len(networks)+len(secrets)+len(configs) == 0 {
 			fmt.Fprintf(dockerCli.Out(), "Nothing found in stack: %s\n", namespace)
 			continue
 		}
 
 		hasError := removeServices(ctx, dockerCli, services)
 		hasError = removeSecrets(ctx, dockerCli, secrets) || hasError
+		hasError = removeConfigs(ctx, dockerCli, configs) || hasError
 		hasError = removeNetworks(ctx, dockerCli, networks) || hasError
 
 		if hasError {
@@ -119,3 +131,18 @@ func removeSecrets(
 	}
 	return err != nil
 }
+
+func removeConfigs(
+	ctx context.Context,
+	dockerCli command.Cli,
+	configs []swarm.Config,
+) bool {
+	var err error
+	for _, config := range configs {
+		fmt.Fprintf(dockerCli.Err(), "Removing config %s\n", config.Spec.Name)
+		if err = dockerCli.Client().ConfigRemove(ctx, config.ID); err != nil {
+			fmt.Fprintf(dockerCli.Err(), "Failed to remove config %s: %s", config.ID, err)
+		}
+	}
+	return err != nil
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/cli/internal/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,10 +34,19 @@ func TestRemoveStack(t *testing.T) {
 	}
 	allSecretIDs := buildObjectIDs(allSecrets)
 
+	allConfigs := []string{
+		objectName("foo", "config1"),
+		objectName("foo", "config2"),
+		objectName("bar", "config1"),
+	}
+	allConfigIDs := buildObjectIDs(allConfigs)
+
 	cli := &fakeClient{
+		version:  "1.30",
 		services: allServices,
 		networks: allNetworks,
 		secrets:  allSecrets,
+		configs:  allConfigs,
 	}
 	cmd := newRemoveCommand(test.NewFakeCli(cli, &bytes.Buffer{}))
 	cmd.SetArgs([]string{"foo", "bar"})
@@ -44,6 +55,28 @@ func TestRemoveStack(t *testing.T) {
 	assert.Equal(t, allServiceIDs, cli.removedServices)
 	assert.Equal(t, allNetworkIDs, cli.removedNetworks)
 	assert.Equal(t, allSecretIDs, cli.removedSecrets)
+	assert.Equal(t, allConfigIDs, cli.removedConfigs)
+}
+
+func TestRemoveStackSkipConfigs(t *testing.T) {
+	allServices := []string{objectName("foo", "service1")}
+	allServiceIDs := buildObjectIDs(allServices)
+
+	// Configs are not listed with API versions before 1.30.
+	cli := &fakeClient{
+		version:  "1.29",
+		services: allServices,
+		configs:  []string{objectName("foo", "config1")},
+		configListFunc: func(options types.ConfigListOptions) ([]swarm.Config, error) {
+			return nil, errors.New("configs are not supported")
+		},
+	}
+	cmd := newRemoveCommand(test.NewFakeCli(cli, &bytes.Buffer{}))
+	cmd.SetArgs([]string{"foo"})
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, allServiceIDs, cli.removedServices)
+	assert.Nil(t, cli.removedConfigs)
 }
 
 func TestSkipEmptyStack(t *testing.T) {
@@ -61,6 +61,7 @@ func runDiskUsage(dockerCli *command.DockerCli, opts diskUsageOptions) error {
 		Images:     du.Images,
 		Containers: du.Containers,
 		Volumes:    du.Volumes,
+		BuildCache: du.BuildCache,
 		Verbose:    opts.verbose,
 	}
 
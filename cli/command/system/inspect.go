@@ -46,7 +46,7 @@ func NewInspectCommand(dockerCli *command.DockerCli) *cobra.Command {
 func runInspect(dockerCli *command.DockerCli, opts inspectOptions) error {
 	var elementSearcher inspect.GetRefFunc
 	switch opts.inspectType {
-	case "", "container", "image", "node", "network", "service", "volume", "task", "plugin", "secret":
+	case "", "container", "image", "node", "network", "service", "volume", "task", "plugin", "secret", "config":
 		elementSearcher = inspectAll(context.Background(), dockerCli, opts.size, opts.inspectType)
 	default:
 		return errors.Errorf("%q is not a valid value for --type", opts.inspectType)
@@ -109,6 +109,12 @@ func inspectSecret(ctx context.Context, dockerCli *command.DockerCli) inspect.Ge
 	}
 }
 
+func inspectConfig(ctx context.Context, dockerCli *command.DockerCli) inspect.GetRefFunc {
+	return func(ref string) (interface{}, []byte, error) {
+		return dockerCli.Client().ConfigInspectWithRaw(ctx, ref)
+	}
+}
+
 func inspectAll(ctx context.Context, dockerCli *command.DockerCli, getSize bool, typeConstraint string) inspect.GetRefFunc {
 	var inspectAutodetect = []struct {
 		objectType      string
@@ -157,6 +163,11 @@ func inspectAll(ctx context.Context, dockerCli *command.DockerCli, getSize bool,
 			isSwarmObject:   true,
 			objectInspector: inspectSecret(ctx, dockerCli),
 		},
+		{
+			objectType:      "config",
+			isSwarmObject:   true,
+			objectInspector: inspectConfig(ctx, dockerCli),
+		},
 	}
 
 	// isSwarmManager does an Info API call to verify that the daemon is
@@ -170,8 +181,10 @@ func inspectAll(ctx context.Context, dockerCli *command.DockerCli, getSize bool,
 		return info.Swarm.ControlAvailable
 	}
 
+	// Objects the daemon doesn't support, or which require a newer API
+	// version, are skipped when the type is not specified.
 	isErrNotSupported := func(err error) bool {
-		return strings.Contains(err.Error(), "not supported")
+		return strings.Contains(err.Error(), "not supported") || strings.Contains(err.Error(), "requires API version")
 	}
 
 	return func(ref string) (interface{}, []byte, error) {
@@ -3,6 +3,7 @@ package system
 import (
 	"fmt"
 
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/prune"
@@ -45,6 +46,7 @@ const (
 	- all volumes not used by at least one container
 	- all networks not used by at least one container
 	%s
+	- all build cache not used by at least one image or container
 Are you sure you want to continue?`
 
 	danglingImageDesc = "- all dangling images"
@@ -90,6 +92,19 @@ func runPrune(dockerCli *command.DockerCli, options pruneOptions) error {
 		fmt.Fprintln(dockerCli.Out(), output)
 	}
 
+	// The build cache is pruned after the images, as deleting an image can
+	// leave the build cache it was built from unused.
+	if versions.GreaterThanOrEqualTo(dockerCli.Client().ClientVersion(), "1.30") {
+		spc, output, err := prune.RunBuildCachePrune(dockerCli, options.filter)
+		if err != nil {
+			return err
+		}
+		if spc > 0 {
+			spaceReclaimed += spc
+			fmt.Fprintln(dockerCli.Out(), output)
+		}
+	}
+
 	fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
 
 	return nil
@@ -4,15 +4,52 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/system"
 )
 
+// CompressReader returns a reader which supplies the content of the reader
+// compressed with the named compression (none, gzip or zstd). The content is
+// returned as is if the compression is empty or none.
+func CompressReader(r io.Reader, compression string) (io.ReadCloser, error) {
+	if compression == "" {
+		return ioutil.NopCloser(r), nil
+	}
+	c, err := archive.ParseCompression(compression)
+	if err != nil {
+		return nil, err
+	}
+	switch c {
+	case archive.Uncompressed:
+		return ioutil.NopCloser(r), nil
+	case archive.Gzip, archive.Zstd:
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+
+	pipeR, pipeW := io.Pipe()
+	go func() {
+		w, err := archive.CompressStream(pipeW, c)
+		if err != nil {
+			pipeW.CloseWithError(err)
+			return
+		}
+		_, err = io.Copy(w, r)
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+		pipeW.CloseWithError(err)
+	}()
+	return pipeR, nil
+}
+
 // CopyToFile writes the content of the reader to the specified file
 func CopyToFile(outfile string, r io.Reader) error {
 	// We use sequential file access here to avoid depleting the standby list
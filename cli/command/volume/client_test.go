@@ -1,6 +1,8 @@
 package volume
 
 import (
+	"io"
+
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -15,6 +17,9 @@ type fakeClient struct {
 	volumeListFunc    func(filter filters.Args) (volumetypes.VolumesListOKBody, error)
 	volumeRemoveFunc  func(volumeID string, force bool) error
 	volumePruneFunc   func(filter filters.Args) (types.VolumesPruneReport, error)
+	volumeCloneFunc   func(volumeID string, options types.VolumeCloneRequest) (types.Volume, error)
+	volumeExportFunc  func(volumeID string) (io.ReadCloser, error)
+	volumeImportFunc  func(volumeID string, content io.Reader) error
 }
 
 func (c *fakeClient) VolumeCreate(ctx context.Context, options volumetypes.VolumesCreateBody) (types.Volume, error) {
@@ -51,3 +56,24 @@ func (c *fakeClient) VolumeRemove(ctx context.Context, volumeID string, force bo
 	}
 	return nil
 }
+
+func (c *fakeClient) VolumeClone(ctx context.Context, volumeID string, options types.VolumeCloneRequest) (types.Volume, error) {
+	if c.volumeCloneFunc != nil {
+		return c.volumeCloneFunc(volumeID, options)
+	}
+	return types.Volume{}, nil
+}
+
+func (c *fakeClient) VolumeExport(ctx context.Context, volumeID string) (io.ReadCloser, error) {
+	if c.volumeExportFunc != nil {
+		return c.volumeExportFunc(volumeID)
+	}
+	return nil, nil
+}
+
+func (c *fakeClient) VolumeImport(ctx context.Context, volumeID string, content io.Reader) error {
+	if c.volumeImportFunc != nil {
+		return c.volumeImportFunc(volumeID, content)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package volume
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type cloneOptions struct {
+	source string
+	name   string
+	labels opts.ListOpts
+}
+
+func newCloneCommand(dockerCli command.Cli) *cobra.Command {
+	opts := cloneOptions{
+		labels: opts.NewListOpts(opts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [OPTIONS] VOLUME [NEW_VOLUME]",
+		Short: "Create a volume with a copy of the data of a volume",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.source = args[0]
+			if len(args) == 2 {
+				opts.name = args[1]
+			}
+			return runClone(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.30"},
+	}
+	flags := cmd.Flags()
+	flags.Var(&opts.labels, "label", "Set metadata for the new volume")
+
+	return cmd
+}
+
+func runClone(dockerCli command.Cli, opts cloneOptions) error {
+	client := dockerCli.Client()
+
+	cloneReq := types.VolumeCloneRequest{
+		Name:   opts.name,
+		Labels: runconfigopts.ConvertKVStringsToMap(opts.labels.GetAll()),
+	}
+
+	vol, err := client.VolumeClone(context.Background(), opts.source, cloneReq)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", vol.Name)
+	return nil
+}
@@ -0,0 +1,80 @@
+package volume
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/testutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeCloneErrors(t *testing.T) {
+	testCases := []struct {
+		args            []string
+		volumeCloneFunc func(string, types.VolumeCloneRequest) (types.Volume, error)
+		expectedError   string
+	}{
+		{
+			expectedError: "requires at least 1 and at most 2 argument(s)",
+		},
+		{
+			args:          []string{"too", "many", "args"},
+			expectedError: "requires at least 1 and at most 2 argument(s)",
+		},
+		{
+			args: []string{"volumeName"},
+			volumeCloneFunc: func(volumeID string, options types.VolumeCloneRequest) (types.Volume, error) {
+				return types.Volume{}, errors.Errorf("error cloning volume")
+			},
+			expectedError: "error cloning volume",
+		},
+	}
+	for _, tc := range testCases {
+		buf := new(bytes.Buffer)
+		cmd := newCloneCommand(
+			test.NewFakeCli(&fakeClient{
+				volumeCloneFunc: tc.volumeCloneFunc,
+			}, buf),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeCloneWithLabels(t *testing.T) {
+	expectedLabels := map[string]string{
+		"lbl1": "v1",
+		"lbl2": "v2",
+	}
+
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		volumeCloneFunc: func(volumeID string, options types.VolumeCloneRequest) (types.Volume, error) {
+			if volumeID != "source" {
+				return types.Volume{}, errors.Errorf("expected source volume %q, got %q", "source", volumeID)
+			}
+			if options.Name != "backup" {
+				return types.Volume{}, errors.Errorf("expected name %q, got %q", "backup", options.Name)
+			}
+			if !compareMap(options.Labels, expectedLabels) {
+				return types.Volume{}, errors.Errorf("expected labels %v, got %v", expectedLabels, options.Labels)
+			}
+			return types.Volume{
+				Name: options.Name,
+			}, nil
+		},
+	}, buf)
+
+	cmd := newCloneCommand(cli)
+	cmd.SetArgs([]string{"source", "backup"})
+	cmd.Flags().Set("label", "lbl1=v1")
+	cmd.Flags().Set("label", "lbl2=v2")
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "backup", strings.TrimSpace(buf.String()))
+}
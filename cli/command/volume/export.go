@@ -0,0 +1,63 @@
+package volume
+
+import (
+	"io"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type exportOptions struct {
+	volume      string
+	output      string
+	compression string
+}
+
+func newExportCommand(dockerCli command.Cli) *cobra.Command {
+	var opts exportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] VOLUME",
+		Short: "Export the data of a volume as a tar archive",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.volume = args[0]
+			return runExport(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.30"},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.compression, "compression", "none", "Compress the archive (none, gzip, zstd)")
+
+	return cmd
+}
+
+func runExport(dockerCli command.Cli, opts exportOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
+	}
+
+	responseBody, err := dockerCli.Client().VolumeExport(context.Background(), opts.volume)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	archive, err := command.CompressReader(responseBody, opts.compression)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	if opts.output == "" {
+		_, err := io.Copy(dockerCli.Out(), archive)
+		return err
+	}
+
+	return command.CopyToFile(opts.output, archive)
+}
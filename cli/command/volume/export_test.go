@@ -0,0 +1,73 @@
+package volume
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/testutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeExportErrors(t *testing.T) {
+	testCases := []struct {
+		args             []string
+		volumeExportFunc func(string) (io.ReadCloser, error)
+		expectedError    string
+	}{
+		{
+			expectedError: "requires exactly 1 argument",
+		},
+		{
+			args: []string{"volumeName"},
+			volumeExportFunc: func(volumeID string) (io.ReadCloser, error) {
+				return nil, errors.Errorf("error exporting volume")
+			},
+			expectedError: "error exporting volume",
+		},
+	}
+	for _, tc := range testCases {
+		buf := new(bytes.Buffer)
+		cmd := newExportCommand(
+			test.NewFakeCli(&fakeClient{
+				volumeExportFunc: tc.volumeExportFunc,
+			}, buf),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeExportToFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-export-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		volumeExportFunc: func(volumeID string) (io.ReadCloser, error) {
+			if volumeID != "data" {
+				return nil, errors.Errorf("expected volume %q, got %q", "data", volumeID)
+			}
+			return ioutil.NopCloser(strings.NewReader("archive")), nil
+		},
+	}, buf)
+
+	output := filepath.Join(dir, "data.tar")
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{"data"})
+	cmd.Flags().Set("output", output)
+	assert.NoError(t, cmd.Execute())
+
+	content, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(content))
+}
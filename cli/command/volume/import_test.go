@@ -0,0 +1,79 @@
+package volume
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/testutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeImportErrors(t *testing.T) {
+	testCases := []struct {
+		args             []string
+		flags            map[string]string
+		volumeImportFunc func(string, io.Reader) error
+		expectedError    string
+	}{
+		{
+			expectedError: "requires exactly 1 argument",
+		},
+		{
+			args: []string{"volumeName"},
+			flags: map[string]string{
+				"input": "/invalid/path/to/archive.tar",
+			},
+			expectedError: "no such file or directory",
+		},
+		{
+			args: []string{"volumeName"},
+			volumeImportFunc: func(volumeID string, content io.Reader) error {
+				return errors.Errorf("error importing volume")
+			},
+			expectedError: "error importing volume",
+		},
+	}
+	for _, tc := range testCases {
+		buf := new(bytes.Buffer)
+		cli := test.NewFakeCli(&fakeClient{
+			volumeImportFunc: tc.volumeImportFunc,
+		}, buf)
+		cli.SetIn(ioutil.NopCloser(strings.NewReader("")))
+		cmd := newImportCommand(cli)
+		cmd.SetArgs(tc.args)
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		cmd.SetOutput(ioutil.Discard)
+		testutil.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeImportFromStdin(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		volumeImportFunc: func(volumeID string, content io.Reader) error {
+			if volumeID != "data" {
+				return errors.Errorf("expected volume %q, got %q", "data", volumeID)
+			}
+			b, err := ioutil.ReadAll(content)
+			if err != nil {
+				return err
+			}
+			if string(b) != "archive" {
+				return errors.Errorf("expected the archive from stdin, got %q", b)
+			}
+			return nil
+		},
+	}, buf)
+	cli.SetIn(ioutil.NopCloser(strings.NewReader("archive")))
+
+	cmd := newImportCommand(cli)
+	cmd.SetArgs([]string{"data"})
+	assert.NoError(t, cmd.Execute())
+}
@@ -131,6 +131,20 @@ func Load(configDetails types.ConfigDetails) (*types.Config, error) {
 		cfg.Secrets = secretsMapping
 	}
 
+	if configs, ok := configDict["configs"]; ok {
+		configsConfig, err := interpolation.Interpolate(configs.(map[string]interface{}), "config", lookupEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		configsMapping, err := LoadConfigObjs(configsConfig, configDetails.WorkingDir)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Configs = configsMapping
+	}
+
 	return &cfg, nil
 }
 
@@ -247,6 +261,8 @@ func transformHook(
 		return transformServicePort(data)
 	case reflect.TypeOf(types.ServiceSecretConfig{}):
 		return transformServiceSecret(data)
+	case reflect.TypeOf(types.ServiceConfigObjConfig{}):
+		return transformServiceConfigObj(data)
 	case reflect.TypeOf(types.StringOrNumberList{}):
 		return transformStringOrNumberList(data)
 	case reflect.TypeOf(map[string]*types.ServiceNetworkConfig{}):
@@ -487,6 +503,25 @@ func LoadSecrets(source map[string]interface{}, workingDir string) (map[string]t
 	return secrets, nil
 }
 
+// LoadConfigObjs produces a ConfigObjConfig map from a compose file Dict
+// the source Dict is not validated if directly used. Use Load() to enable validation
+func LoadConfigObjs(source map[string]interface{}, workingDir string) (map[string]types.ConfigObjConfig, error) {
+	configs := make(map[string]types.ConfigObjConfig)
+	if err := transform(source, &configs); err != nil {
+		return configs, err
+	}
+	for name, config := range configs {
+		if config.External.External && config.External.Name == "" {
+			config.External.Name = name
+		}
+		if config.File != "" {
+			config.File = absPath(workingDir, config.File)
+		}
+		configs[name] = config
+	}
+	return configs, nil
+}
+
 func absPath(workingDir string, filepath string) string {
 	if path.IsAbs(filepath) {
 		return filepath
@@ -560,6 +595,17 @@ func transformServiceSecret(data interface{}) (interface{}, error) {
 	}
 }
 
+func transformServiceConfigObj(data interface{}) (interface{}, error) {
+	switch value := data.(type) {
+	case string:
+		return map[string]interface{}{"source": value}, nil
+	case map[string]interface{}:
+		return data, nil
+	default:
+		return data, errors.Errorf("invalid type %T for config", value)
+	}
+}
+
 func transformServiceVolumeConfig(data interface{}) (interface{}, error) {
 	switch value := data.(type) {
 	case string:
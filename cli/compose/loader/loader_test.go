@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 	"time"
@@ -198,6 +199,41 @@ secrets:
 	assert.Equal(t, len(actual.Secrets), 1)
 }
 
+func TestLoadV33(t *testing.T) {
+	actual, err := loadYAML(`
+version: "3.3"
+services:
+  foo:
+    image: busybox
+    configs:
+      - super
+      - source: nginx
+        target: /etc/nginx/nginx.conf
+        mode: 0440
+configs:
+  super:
+    external: true
+  nginx:
+    file: ./nginx.conf
+`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, len(actual.Services), 1)
+	assert.Equal(t, len(actual.Configs), 2)
+
+	mode := uint32(0440)
+	assert.Equal(t, []types.ServiceConfigObjConfig{
+		{Source: "super"},
+		{Source: "nginx", Target: "/etc/nginx/nginx.conf", Mode: &mode},
+	}, actual.Services[0].Configs)
+
+	workingDir, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, "super", actual.Configs["super"].External.Name)
+	assert.Equal(t, filepath.Join(workingDir, "nginx.conf"), actual.Configs["nginx"].File)
+}
+
 func TestParseAndLoad(t *testing.T) {
 	actual, err := loadYAML(sampleYAML)
 	if !assert.NoError(t, err) {
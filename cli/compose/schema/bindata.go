@@ -3,6 +3,7 @@
 // data/config_schema_v3.0.json
 // data/config_schema_v3.1.json
 // data/config_schema_v3.2.json
+// data/config_schema_v3.3.json
 // DO NOT EDIT!
 
 package schema
@@ -130,6 +131,26 @@ func dataConfig_schema_v32Json() (*asset, error) {
 	return a, nil
 }
 
+var _dataConfig_schema_v33Json = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x1b\x4d\x73\xe3\x28\xf6\xae\x5f\xa1\xa2\xfb\xd6\x4e\x32\x55\x33\xb5\x55\xdb\xb7\x3d\xee\x69\xf7\xbc\x29\x8f\x0a\x4b\xcf\x36\x13\x09\x34\x80\xdc\xf1\x74\xf9\xbf\x6f\x61\x81\x04\x08\x09\xe4\x78\x93\xde\x9a\x89\x72\xb0\xe1\x7d\x7f\xf1\x00\xf9\x7b\x96\xe7\xe8\xb3\x28\x8f\xd0\x60\xf4\x35\x47\x47\x29\xdb\xaf\x4f\x4f\xbf\x09\x46\x1f\xfa\xd1\x47\xc6\x0f\x4f\x15\xc7\x7b\xf9\xf0\xd3\x2f\x4f\xfd\xd8\x27\xb4\x51\x78\xa4\x52\x28\x25\xa3\x7b\x72\x28\xfa\x99\xe2\xf4\xf3\xe3\xcf\x8f\x0a\xbd\x07\x91\xe7\x16\x14\x10\xdb\xfd\x06\xa5\xec\xc7\x38\xfc\xde\x11\x0e\x0a\xf9\x19\x9d\x80\x0b\xc2\x28\xda\x6e\x32\x35\xd7\x72\xd6\x02\x97\x04\x04\xfa\x9a\x2b\xe1\xf2\x7c\x00\x31\x03\x16\x59\x21\x39\xa1\x07\x74\x85\xbb\x5c\x29\xe4\x39\x12\xc0\x4f\xa4\xb4\x28\x0c\xa2\x7e\x7a\x1a\xe9\x3f\x0d\x60\x1b\x9f\xaa\x25\xec\x75\xbc\xc5\x52\x02\xa7\xff\x9e\xca\xa6\x1e\xf4\xeb\x33\x7e\xf8\xe3\x1f\x0f\xff\xf9\xe9\xe1\xef\x8f\xc5\xc3\xf6\xcb\x67\x67\x5a\xd9\x97\xc3\x5e\x19\xe1\xd3\x53\x05\x7b\x42\x89\x24\x8c\x0e\xfc\xd1\x00\x79\xd1\x9f\x2e\x03\x63\x5c\x55\x57\x60\x5c\x3b\xbc\xf7\xb8\x16\xe0\xea\x4c\x41\x7e\x63\xfc\x25\xa6\xf3\x00\xf6\x41\x3a\x6b\xfe\x01\x9d\x5d\x75\x4e\xac\xee\x1a\x88\x69\x63\xa0\x3e\x48\x99\x9e\xfd\x7d\xfc\x27\xa0\xe4\x20\x63\x0a\x1b\xa8\x0f\x52\xb8\x67\x7f\x1f\x85\xfb\xaa\x11\x53\xd8\x40\x7d\x90\xc2\x3d\xfb\xb7\x29\x9c\x19\xa5\x17\x61\xaf\x66\x41\x16\xef\xab\xfc\x26\x36\xfa\x42\x11\x30\x55\xa8\x9e\xcc\xdb\xca\x4c\x8c\x06\x76\xcc\x80\x2a\x68\x6b\x76\x56\x63\x33\xf6\xe8\x01\x1a\xa0\x12\x0d\x26\xc8\x73\xb4\xeb\x48\x5d\x39\xa4\xf2\x1c\x31\x0a\xff\x52\x24\x9e\xad\xc1\x3c\xff\xee\x97\x6e\x8b\x8e\xfa\xb7\x49\x2c\x39\x3c\xcf\x97\x75\x31\x7f\xa8\x64\x54\xc2\xab\x44\x5f\xa3\xac\xd5\x3f\xaa\x58\xf9\x02\x7c\x4f\x6a\x48\xc5\xc0\xfc\x20\x16\x4c\x56\x13\x21\x0b\xc6\x8b\x8a\x94\x32\x88\x5f\xe2\xf2\x08\xc5\x9e\xb3\x26\x4a\x65\x5f\xf4\x72\x08\x74\xc9\x1c\x22\x63\x40\xe6\x79\x6a\x60\xfa\x31\xad\x9e\x6d\x16\x20\x88\x4a\xdc\x16\xb8\xaa\x1c\x83\x60\xce\xf1\x19\x6d\x72\x44\x24\x34\x22\x6c\xab\x1c\x75\x94\xfc\xde\xc1\x3f\x35\x88\xe4\x1d\xf8\x74\x2b\xce\xda\xfb\x13\x3e\x70\xd6\xb5\x45\x8b\xb9\x8a\xd4\x20\x09\x0b\x98\x35\x0d\xa6\xf7\x0a\xdf\x35\x7a\x24\x58\x7e\x52\x24\x9d\x9c\xd0\x3c\xec\xa9\x81\x9b\x35\x38\xab\x4d\x5c\x9f\x69\x42\xc6\x53\x32\x9e\x94\xaa\x47\x63\x1d\x2f\x53\x73\x4c\xf1\xc4\xfc\x00\xa9\x59\x9c\xe7\xa8\x23\x55\x3a\xf0\x61\x0d\x70\xc3\x2a\x57\x6e\xda\x35\x3b\xe0\x93\x94\x74\x33\x6b\xfa\x7d\x9b\x85\x66\x2c\x9e\xd7\xd2\x85\x09\x05\x5e\x50\xdc\xc4\x6c\xa5\x8a\x37\xd0\x4a\x14\x8c\xae\xa9\x23\x0e\x81\xa1\x6b\xf6\x23\xec\x4d\xd9\x58\xd1\xa5\xfa\xd8\x93\x51\x15\x52\x55\x4a\x57\x20\x2a\x0a\x01\x98\x97\xc7\x1b\xf1\x59\x83\x09\x4d\xb1\x1d\x50\xc9\xcf\x2d\x23\x7d\xb5\xc8\xa2\x89\xb3\x44\xcc\x9d\x4f\x30\xe0\x25\x0b\x05\x85\x2b\xde\xa9\x18\x96\xa4\xd5\x66\x00\x7a\x22\x9c\xd1\xc6\xd4\xc2\xb4\x75\xca\xc2\x7f\x6d\x99\x80\xb7\xd7\x20\x8d\xf1\x6c\x14\xdf\x0c\xa9\xb3\xb5\xd1\xf3\x1c\xed\x19\x6f\xb0\x72\x85\xe1\x6d\x4d\x5b\x9a\xe5\xa1\xc8\x1b\x66\x3d\x1d\x54\x77\x88\xeb\xa2\x26\xf4\xc5\x75\xc3\x3d\x42\x1c\x5e\x25\xc7\xc5\x91\x09\x79\x4b\x2b\x80\x8e\x80\x6b\x79\x2c\x8f\x50\xbe\x2c\xa0\xdb\x50\x0e\x36\x13\x32\x25\xc8\x49\x83\x0f\x71\xa0\xb6\x8c\x81\xd4\x78\x07\xf5\x4d\x7a\xde\xd5\xf8\x16\x59\x76\x38\x28\xd0\xb9\x88\x1b\x17\xaa\x6c\xcd\x2a\x85\x2a\x4e\x4e\xc0\xc3\x42\x4d\xa1\x59\x3b\xf6\xed\x66\x70\x49\x16\x33\x33\xfe\x45\x36\x31\xf6\x83\x7e\x7d\xfc\xf2\xd9\x96\x2c\x90\x55\xd7\xfc\xaa\x6b\xb4\xbd\x64\x13\x7c\x6f\x2d\x9a\x8e\x78\x1a\xa6\xb5\x93\x8e\x57\x1a\x5c\xaa\xae\x91\x83\x98\xf1\xeb\x08\xaa\x4f\x05\x8a\xc9\xd2\x3a\xc2\x4e\x80\x45\x6a\xa5\x5e\xbd\x10\xde\xb6\x0d\x49\x72\x5d\x74\x1f\x1a\xd1\xc6\x3c\x21\x94\xd4\x28\x4b\x6b\xd0\x34\x1c\xae\x09\x16\x10\x4f\xf6\x59\x43\xda\x0f\x22\xed\xe9\x97\xc4\x98\xf0\x1f\x85\xfb\xb7\x45\xdc\x19\xd4\x59\x9a\xe9\x3b\xa4\x08\xa9\x51\x14\xda\xd5\x75\x50\x90\x6d\x36\xa1\x95\x45\x68\xa7\x8b\x77\xc9\x42\x8c\x2c\x82\xa8\x25\xd5\x7c\xad\xb8\x56\x08\x3b\xc1\x5a\xc6\x9d\x83\x28\x27\xb0\x74\xc1\xb6\xa7\x86\xd2\x9d\x25\x45\xb0\x6d\x2e\x53\xa7\xc6\x05\xbf\x67\x7e\xd9\xcc\x22\x8d\xa2\xc7\x91\xb2\xf5\xf9\x11\xcf\x8c\xe9\x06\x40\x8b\x94\xbc\x71\x21\x54\xc2\x01\xf8\x0c\x42\xdb\xed\x6a\x22\x8e\x50\xad\xc1\xe1\x4c\xb2\x92\xd5\x41\xb1\x26\x08\x01\x1a\x6b\x92\xe1\x92\xcd\x85\xb6\x43\x38\xb0\x6a\x87\x17\x8a\x96\x93\x13\xa9\xe1\xe0\x69\xbc\x63\xac\x06\x4c\x6d\x8d\x11\x07\x5c\x15\x8c\xd6\xe7\x04\x48\x21\x31\x8f\x6d\x18\x91\x80\xb2\xe3\x44\x9e\x0b\xd6\xca\x7b\x35\x26\x23\xf1\x63\x53\x08\xf2\x87\x13\x2c\xcf\x56\xd4\x6b\x42\x5b\x4f\x20\x0e\xef\x93\x7e\x83\x1e\x3e\xc4\xff\x26\x6d\xfe\xda\xf1\xc7\x77\xfc\xe2\x2c\x4a\x79\x5b\x6f\x2d\x64\x45\x68\xc1\x5a\xa0\xd1\xdc\x10\x92\xb5\xc5\x81\xe3\x12\x8a\x16\x38\x61\x41\x53\x38\x05\xb6\xea\x38\x56\x7d\xd3\x94\x8c\x20\x07\x8a\xc3\x75\xc7\x02\x95\x4d\xbb\x17\xb7\xed\x5e\xa5\x8c\x27\x7b\x57\x93\x86\xcc\x27\x4d\x20\x6a\x13\xfa\xb5\xbe\x57\x0b\xb7\x68\xb3\xd9\x95\xa7\x95\x6c\x9f\x9e\x25\xee\x7c\x8e\xa5\x64\x59\x9e\xa3\x23\xe6\x2b\x96\x0e\xe5\x47\xb6\x97\x61\x84\x00\x7c\x90\x88\x7b\xa3\x7b\xa5\xb7\xd1\x82\x6c\x83\xf0\x2b\x56\x1b\x3f\x89\xdc\x34\x72\x67\x2f\x59\x40\x4c\xd4\x89\xe8\x26\xee\x0a\x43\xc5\xd2\x06\x64\x00\x35\x97\x8e\xae\x03\x7e\xfc\x0a\xed\xf8\xe8\x0a\xbe\xbd\xa9\x8e\x6b\x4e\x71\x29\xdf\xa5\xea\x27\x77\x04\xe3\xa3\xce\x55\x05\x11\x12\x68\x79\x4e\x67\xb4\x23\x93\x3b\x82\xf1\x89\x9b\x3f\x35\x7d\x35\x14\x3e\xf4\xf5\x36\x24\x5e\x10\x2f\x34\x1a\x56\x44\xdf\x5a\xbf\x8b\x2a\x94\x95\xac\x9d\x71\x4d\xba\x1a\x59\x6c\xc4\xfd\xee\x85\xf5\x52\x1f\x6a\xa3\x5a\xd6\x42\xdf\x18\x7f\x51\xa7\xca\x15\x09\x57\x8e\xcc\x43\x89\x17\x34\xd3\xf1\xfa\x67\x7d\x4b\x17\xba\x36\xe8\xc0\x69\xc6\x3d\x8b\x12\x6c\xb2\x65\xaf\xa1\x8a\x08\xbc\xab\x21\xec\x28\x83\xad\x7a\x4d\x2a\x81\x9f\xe2\xeb\x3d\x07\xc9\x35\x93\x49\xd3\x64\x81\x49\x10\x3f\xe6\x81\xbb\x24\x0d\xb0\x2e\x5c\x86\x34\xd4\xc5\xb8\xd5\x5c\xbe\x98\x8b\xf1\x88\x53\x2d\x48\xc3\xd0\xb0\x78\x1e\x9c\x6a\xf6\xe5\x51\xc7\xa5\x2c\x58\x40\xab\xeb\xd5\x46\xd2\xea\xc6\xa1\xad\x49\x89\x45\xb8\x21\xb8\xcb\x29\x70\xd7\x56\x58\x42\xa1\xdf\xad\xb0\xd5\x99\x0f\xef\x25\x23\xe8\x7e\x8e\xe3\xba\x86\x9a\x88\x26\x26\xba\x76\x58\x8d\xcf\x37\xf5\xbd\xea\x41\x7b\x4c\xea\x8e\x43\x81\xcb\xd9\x32\xed\x61\x34\x8c\x12\xc9\xf8\xed\x2c\x1b\xfc\x5a\x18\xb6\x57\x90\x60\x76\x59\x38\x0e\x81\x78\x85\xf2\x50\x10\x87\x7e\xc7\xe6\x1b\xfb\x66\x17\x8d\x4d\xfa\x4c\xc4\x18\x8e\x13\xd5\x39\xa8\x77\x5b\xf0\x70\xbe\x1e\xc5\xb7\xd0\x2f\x73\xca\xa9\xe3\x81\xa2\x65\x35\xe9\xbb\x80\x7b\x68\x58\x32\xda\xf7\xb5\x21\x2f\xdf\x39\x02\x55\x38\xa8\x3d\x4c\xd3\x4a\xe1\x50\x99\x8b\xf8\x6f\x84\x56\xec\xdb\x0a\x86\x16\xfa\x1b\x43\xa9\xad\x71\x09\x5e\x71\x7c\xab\xa1\x85\xe4\x98\x50\x4f\xf7\x95\xc5\xff\x16\xb5\x32\x0f\x75\xc5\xd2\x3f\xc4\x67\x64\x89\x18\xe0\x06\x1e\x33\x76\x9a\xb3\x11\x2a\xdb\x2e\xac\xbd\xc1\x54\x01\x04\x0d\xe3\xc1\x00\x7c\x8b\x8e\xfa\x76\x26\xa6\xa2\x01\x1b\x38\xdc\xbe\x04\x26\xdd\xd2\x69\x28\x75\xcc\xe7\xa2\xdf\xe1\x98\x20\x7e\x13\xe7\xdc\xc0\x8d\x9f\x6d\xf9\x48\x8b\x9b\x55\x82\x2d\x48\x94\x64\x11\x93\x47\xd3\x35\xd8\xe1\xad\x33\xca\x9f\x1e\xf2\xcb\x9b\x58\x96\x3a\x2e\xbb\x86\x10\xdd\x8e\xce\xec\x02\x27\xe0\x9e\x4e\x69\xf1\x1a\x72\x87\xff\xcd\x21\x9c\x46\xf2\xb2\x99\xbe\x72\xe0\xe9\x68\x14\x7a\x1e\x1a\xec\xcd\x60\xab\x6d\xb2\x8b\x67\xef\xfb\xef\x27\x3f\xa1\xa3\xfc\x8b\x9b\x02\x2c\x25\x2e\x8f\x49\xfb\x87\x95\x4d\xa3\x46\x1c\x28\xac\xa8\x43\x93\x5d\x6e\xb0\x0c\x69\xa8\x81\xfe\x9f\xbd\x0a\xfd\xbf\xc7\xec\xfb\xc5\x97\x7e\x15\x3e\x12\x5f\x1a\x6a\x93\xb9\x76\xf4\x9d\x3c\x1b\x55\x09\xef\x21\xff\x00\x3e\x1b\x3e\x7f\x8c\x2b\x26\x8b\x58\xd0\x15\x1a\x6a\x93\xb9\xe6\xf9\xcb\x15\xf7\x74\x85\x77\x8f\x33\xea\x10\x38\xd6\x59\xb2\x64\xf2\xcb\x26\x1a\x63\xeb\x8a\xe1\x83\x59\x72\x84\xfb\x9a\x85\xfd\xc2\x26\x5b\x3e\x46\xf4\x98\x6a\x23\x2e\x6b\x1e\x88\x8d\x5b\xeb\xfe\xe3\x97\xc9\x58\x9e\x2f\x2c\x02\xc3\x8a\xe6\xa0\x8c\x71\xe3\x44\x4e\x8a\xef\x3d\x94\xef\xbe\x85\xd7\xdf\xa0\x87\x7d\xea\x6d\xf9\x34\x50\xe0\xb7\x31\xf3\xf9\x6f\xf0\x27\xbf\x94\x51\x7a\xd2\xb3\xe7\x25\x15\x85\xce\x9d\x49\xff\x2b\x17\xfb\xb6\x7e\x02\xd2\xbf\x62\x69\x2d\xb4\x56\x7a\xcf\x27\x77\xf0\xf7\x33\xfe\x8d\x8d\xf9\x1d\x8b\x7d\xfb\x75\xc9\xfc\x4f\xfa\x54\x32\xcb\xf3\x4b\x76\xc9\xfe\x3b\x00\xfc\xa1\x89\x17\xdd\x39\x00\x00")
+
+func dataConfig_schema_v33JsonBytes() ([]byte, error) {
+	return bindataRead(
+		_dataConfig_schema_v33Json,
+		"data/config_schema_v3.3.json",
+	)
+}
+
+func dataConfig_schema_v33Json() (*asset, error) {
+	bytes, err := dataConfig_schema_v33JsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "data/config_schema_v3.3.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -185,6 +206,7 @@ var _bindata = map[string]func() (*asset, error){
 	"data/config_schema_v3.0.json": dataConfig_schema_v30Json,
 	"data/config_schema_v3.1.json": dataConfig_schema_v31Json,
 	"data/config_schema_v3.2.json": dataConfig_schema_v32Json,
+	"data/config_schema_v3.3.json": dataConfig_schema_v33Json,
 }
 
 // AssetDir returns the file names below a certain
@@ -231,6 +253,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"config_schema_v3.0.json": &bintree{dataConfig_schema_v30Json, map[string]*bintree{}},
 		"config_schema_v3.1.json": &bintree{dataConfig_schema_v31Json, map[string]*bintree{}},
 		"config_schema_v3.2.json": &bintree{dataConfig_schema_v32Json, map[string]*bintree{}},
+		"config_schema_v3.3.json": &bintree{dataConfig_schema_v33Json, map[string]*bintree{}},
 	}},
 }}
 
@@ -67,6 +67,7 @@ type Config struct {
 	Networks map[string]NetworkConfig
 	Volumes  map[string]VolumeConfig
 	Secrets  map[string]SecretConfig
+	Configs  map[string]ConfigObjConfig
 }
 
 // ServiceConfig is the configuration of one service
@@ -77,6 +78,7 @@ type ServiceConfig struct {
 	CapDrop         []string `mapstructure:"cap_drop"`
 	CgroupParent    string   `mapstructure:"cgroup_parent"`
 	Command         ShellCommand
+	Configs         []ServiceConfigObjConfig
 	ContainerName   string   `mapstructure:"container_name"`
 	DependsOn       []string `mapstructure:"depends_on"`
 	Deploy          DeployConfig
@@ -255,6 +257,15 @@ type ServiceSecretConfig struct {
 	Mode   *uint32
 }
 
+// ServiceConfigObjConfig is the config obj configuration for a service
+type ServiceConfigObjConfig struct {
+	Source string
+	Target string
+	UID    string
+	GID    string
+	Mode   *uint32
+}
+
 // UlimitsConfig the ulimit configuration
 type UlimitsConfig struct {
 	Single int
@@ -305,3 +316,10 @@ type SecretConfig struct {
 	External External
 	Labels   Labels
 }
+
+// ConfigObjConfig is the config for the swarm "Config" object
+type ConfigObjConfig struct {
+	File     string
+	External External
+	Labels   Labels
+}
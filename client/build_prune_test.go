@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestBuildCachePruneError(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.30",
+	}
+
+	_, err := client.BuildCachePrune(context.Background(), filters.NewArgs())
+	assert.EqualError(t, err, "Error response from daemon: Server error")
+}
+
+func TestBuildCachePruneVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.29",
+	}
+
+	_, err := client.BuildCachePrune(context.Background(), filters.NewArgs())
+	assert.EqualError(t, err, `"builder prune" requires API version 1.30, but the Docker daemon API version is 1.29`)
+}
+
+func TestBuildCachePrune(t *testing.T) {
+	expectedURL := "/v1.30/build/prune"
+
+	pruneFilters := filters.NewArgs()
+	pruneFilters.Add("until", "24h")
+	pruneFilters.Add("keep-storage", "1GB")
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			assert.Equal(t, `{"keep-storage":{"1GB":true},"until":{"24h":true}}`, req.URL.Query().Get("filters"))
+			content, err := json.Marshal(types.BuildCachePruneReport{
+				CachesDeleted:  []string{"sha256:abc", "sha256:def"},
+				SpaceReclaimed: 9999,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+		version: "1.30",
+	}
+
+	report, err := client.BuildCachePrune(context.Background(), pruneFilters)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sha256:abc", "sha256:def"}, report.CachesDeleted)
+	assert.Equal(t, uint64(9999), report.SpaceReclaimed)
+}
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"strconv"
 
@@ -31,7 +34,21 @@ func (cli *Client) ImageBuild(ctx context.Context, buildContext io.Reader, optio
 	headers.Add("X-Registry-Config", base64.URLEncoding.EncodeToString(buf))
 	headers.Set("Content-Type", "application/x-tar")
 
-	serverResp, err := cli.postRaw(ctx, "/build", query, buildContext, headers)
+	body := buildContext
+	if len(options.Secrets) > 0 {
+		if err := cli.NewVersionError("1.30", "secret"); err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+		secrets, err := json.Marshal(options.Secrets)
+		if err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+		var contentType string
+		body, contentType = buildBodyWithSecrets(secrets, buildContext)
+		headers.Set("Content-Type", contentType)
+	}
+
+	serverResp, err := cli.postRaw(ctx, "/build", query, body, headers)
 	if err != nil {
 		return types.ImageBuildResponse{}, err
 	}
@@ -44,6 +61,38 @@ func (cli *Client) ImageBuild(ctx context.Context, buildContext io.Reader, optio
 	}, nil
 }
 
+// buildBodyWithSecrets returns a multipart body holding the JSON encoded
+// secrets of the build followed by the build context, along with its content
+// type. The secrets are sent in the body rather than in a header, so that they
+// are neither limited in size nor logged along with the request.
+func buildBodyWithSecrets(secrets []byte, buildContext io.Reader) (io.Reader, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		err := writeBuildPart(mw, "application/json", bytes.NewReader(secrets))
+		if err == nil {
+			err = writeBuildPart(mw, "application/x-tar", buildContext)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, "multipart/mixed; boundary=" + mw.Boundary()
+}
+
+func writeBuildPart(mw *multipart.Writer, contentType string, r io.Reader) error {
+	part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {contentType}})
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+	_, err = io.Copy(part, r)
+	return err
+}
+
 func (cli *Client) imageBuildOptionsToQuery(options types.ImageBuildOptions) (url.Values, error) {
 	query := url.Values{
 		"t":           options.Tags,
@@ -2,8 +2,11 @@ package client
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"reflect"
 	"strings"
@@ -218,6 +221,65 @@ func TestImageBuild(t *testing.T) {
 	}
 }
 
+func TestImageBuildSecrets(t *testing.T) {
+	secrets := map[string][]byte{"foo": []byte("secret")}
+	client := &Client{
+		version: "1.30",
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil {
+				return nil, err
+			}
+			if mediaType != "multipart/mixed" {
+				return nil, fmt.Errorf("expected a multipart/mixed body, got %s", mediaType)
+			}
+			mr := multipart.NewReader(r.Body, params["boundary"])
+			part, err := mr.NextPart()
+			if err != nil {
+				return nil, err
+			}
+			var actual map[string][]byte
+			if err := json.NewDecoder(part).Decode(&actual); err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(actual, secrets) {
+				return nil, fmt.Errorf("secrets not properly set in the request. Expected %q, got %q", secrets, actual)
+			}
+			part, err = mr.NextPart()
+			if err != nil {
+				return nil, err
+			}
+			if ct := part.Header.Get("Content-Type"); ct != "application/x-tar" {
+				return nil, fmt.Errorf("expected the build context to be a tar archive, got %s", ct)
+			}
+			buildContext, err := ioutil.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			if string(buildContext) != "context" {
+				return nil, fmt.Errorf("expected the build context to follow the secrets, got %q", buildContext)
+			}
+			if r.Header.Get("X-Build-Secrets") != "" {
+				return nil, fmt.Errorf("secrets must not be sent in a header")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("body"))),
+			}, nil
+		}),
+	}
+	buildResponse, err := client.ImageBuild(context.Background(), strings.NewReader("context"), types.ImageBuildOptions{Secrets: secrets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildResponse.Body.Close()
+
+	client.version = "1.29"
+	if _, err := client.ImageBuild(context.Background(), nil, types.ImageBuildOptions{Secrets: secrets}); err == nil || !strings.Contains(err.Error(), "requires API version 1.30") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
 func TestGetDockerOS(t *testing.T) {
 	cases := map[string]string{
 		"Docker/v1.22 (linux)":   "linux",
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"golang.org/x/net/context"
 
@@ -30,6 +31,12 @@ func (cli *Client) ImagePull(ctx context.Context, refStr string, options types.I
 	if !options.All {
 		query.Set("tag", getAPITagFromNamedRef(ref))
 	}
+	if options.MaxBandwidth != 0 {
+		if err := cli.NewVersionError("1.30", "max bandwidth"); err != nil {
+			return nil, err
+		}
+		query.Set("maxbandwidth", strconv.FormatInt(options.MaxBandwidth, 10))
+	}
 
 	resp, err := cli.tryImageCreate(ctx, query, options.RegistryAuth)
 	if resp.statusCode == http.StatusUnauthorized && options.PrivilegeFunc != nil {
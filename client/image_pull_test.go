@@ -197,3 +197,28 @@ func TestImagePullWithoutErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestImagePullMaxBandwidth(t *testing.T) {
+	client := &Client{
+		version: "1.30",
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if maxBandwidth := req.URL.Query().Get("maxbandwidth"); maxBandwidth != "1048576" {
+				return nil, fmt.Errorf("maxbandwidth not set in URL query properly. Expected '1048576', got %s", maxBandwidth)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+	resp, err := client.ImagePull(context.Background(), "myimage:tag", types.ImagePullOptions{MaxBandwidth: 1024 * 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+
+	client.version = "1.29"
+	if _, err := client.ImagePull(context.Background(), "myimage:tag", types.ImagePullOptions{MaxBandwidth: 1024 * 1024}); err == nil || !strings.Contains(err.Error(), "requires API version 1.30") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
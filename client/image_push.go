@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"golang.org/x/net/context"
 
@@ -35,6 +36,18 @@ func (cli *Client) ImagePush(ctx context.Context, image string, options types.Im
 
 	query := url.Values{}
 	query.Set("tag", tag)
+	if options.Compression != "" {
+		if err := cli.NewVersionError("1.30", "layer compression"); err != nil {
+			return nil, err
+		}
+		query.Set("compression", options.Compression)
+	}
+	if options.MaxBandwidth != 0 {
+		if err := cli.NewVersionError("1.30", "max bandwidth"); err != nil {
+			return nil, err
+		}
+		query.Set("maxbandwidth", strconv.FormatInt(options.MaxBandwidth, 10))
+	}
 
 	resp, err := cli.tryImagePush(ctx, name, query, options.RegistryAuth)
 	if resp.statusCode == http.StatusUnauthorized && options.PrivilegeFunc != nil {
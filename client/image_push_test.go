@@ -178,3 +178,28 @@ func TestImagePushWithoutErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestImagePushLayerCompression(t *testing.T) {
+	client := &Client{
+		version: "1.30",
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if compression := req.URL.Query().Get("compression"); compression != "zstd" {
+				return nil, fmt.Errorf("compression not set in URL query properly. Expected 'zstd', got %s", compression)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+	resp, err := client.ImagePush(context.Background(), "myimage:tag", types.ImagePushOptions{Compression: "zstd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Close()
+
+	client.version = "1.29"
+	if _, err := client.ImagePush(context.Background(), "myimage:tag", types.ImagePushOptions{Compression: "zstd"}); err == nil || !strings.Contains(err.Error(), "requires API version 1.30") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 
 	"strings"
@@ -56,3 +57,28 @@ func TestImageSave(t *testing.T) {
 		t.Fatalf("expected response to contain 'response', got %s", string(response))
 	}
 }
+
+func TestImageSaveFormat(t *testing.T) {
+	client := &Client{
+		version: "1.30",
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if format := r.URL.Query().Get("format"); format != "oci" {
+				return nil, fmt.Errorf("format not set in URL query properly. Expected oci, got %s", format)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	saveResponse, err := client.ImageSaveWithOptions(context.Background(), []string{"image_id"}, types.ImageSaveOptions{Format: "oci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveResponse.Close()
+
+	client.version = "1.29"
+	if _, err := client.ImageSaveWithOptions(context.Background(), []string{"image_id"}, types.ImageSaveOptions{Format: "oci"}); err == nil {
+		t.Fatal("expected an error for an API version older than 1.30")
+	}
+}
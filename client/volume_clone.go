@@ -0,0 +1,24 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// VolumeClone creates a volume in the docker host with a copy of the data
+// of an existing volume.
+func (cli *Client) VolumeClone(ctx context.Context, volumeID string, options types.VolumeCloneRequest) (types.Volume, error) {
+	var volume types.Volume
+	if err := cli.NewVersionError("1.30", "volume clone"); err != nil {
+		return volume, err
+	}
+	resp, err := cli.post(ctx, "/volumes/"+volumeID+"/clone", nil, options, nil)
+	if err != nil {
+		return volume, err
+	}
+	err = json.NewDecoder(resp.body).Decode(&volume)
+	ensureReaderClosed(resp)
+	return volume, err
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestVolumeCloneUnsupported(t *testing.T) {
+	client := &Client{
+		version: "1.29",
+		client:  &http.Client{},
+	}
+	_, err := client.VolumeClone(context.Background(), "volume_id", types.VolumeCloneRequest{})
+	assert.EqualError(t, err, `"volume clone" requires API version 1.30, but the Docker daemon API version is 1.29`)
+}
+
+func TestVolumeCloneError(t *testing.T) {
+	client := &Client{
+		version: "1.30",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.VolumeClone(context.Background(), "volume_id", types.VolumeCloneRequest{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestVolumeClone(t *testing.T) {
+	expectedURL := "/v1.30/volumes/volume_id/clone"
+
+	client := &Client{
+		version: "1.30",
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			var cloneReq types.VolumeCloneRequest
+			if err := json.NewDecoder(req.Body).Decode(&cloneReq); err != nil {
+				return nil, err
+			}
+			if cloneReq.Name != "backup" {
+				return nil, fmt.Errorf("expected name 'backup', got %s", cloneReq.Name)
+			}
+			content, err := json.Marshal(types.Volume{
+				Name:   "backup",
+				Driver: "local",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	volume, err := client.VolumeClone(context.Background(), "volume_id", types.VolumeCloneRequest{Name: "backup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "backup", volume.Name)
+	assert.Equal(t, "local", volume.Driver)
+}
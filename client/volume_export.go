@@ -0,0 +1,22 @@
+package client
+
+import (
+	"io"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// VolumeExport retrieves the data of a volume as a tar archive and returns
+// it as an io.ReadCloser. It's up to the caller to close the stream.
+func (cli *Client) VolumeExport(ctx context.Context, volumeID string) (io.ReadCloser, error) {
+	if err := cli.NewVersionError("1.30", "volume export"); err != nil {
+		return nil, err
+	}
+	serverResp, err := cli.get(ctx, "/volumes/"+volumeID+"/export", url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverResp.body, nil
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"io"
+
+	"golang.org/x/net/context"
+)
+
+// VolumeImport extracts a tar archive into a volume in the docker host.
+func (cli *Client) VolumeImport(ctx context.Context, volumeID string, content io.Reader) error {
+	if err := cli.NewVersionError("1.30", "volume import"); err != nil {
+		return err
+	}
+	resp, err := cli.putRaw(ctx, "/volumes/"+volumeID+"/import", nil, content, nil)
+	if err != nil {
+		return err
+	}
+	ensureReaderClosed(resp)
+	return nil
+}
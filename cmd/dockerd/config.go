@@ -13,7 +13,7 @@ const (
 
 // installCommonConfigFlags adds flags to the pflag.FlagSet to configure the daemon
 func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
-	var maxConcurrentDownloads, maxConcurrentUploads int
+	var maxConcurrentDownloads, maxConcurrentUploads, eventsLogMaxFiles int
 
 	conf.ServiceOptions.InstallCliFlags(flags)
 
@@ -46,13 +46,22 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVar(&conf.CorsHeaders, "api-cors-header", "", "Set CORS headers in the Engine API")
 	flags.IntVar(&maxConcurrentDownloads, "max-concurrent-downloads", config.DefaultMaxConcurrentDownloads, "Set the max concurrent downloads for each pull")
 	flags.IntVar(&maxConcurrentUploads, "max-concurrent-uploads", config.DefaultMaxConcurrentUploads, "Set the max concurrent uploads for each push")
+	flags.Var(&conf.MaxDownloadBandwidth, "max-download-bandwidth", "Set the max bytes per second downloaded by all the pulls (0 for no limit)")
+	flags.Var(&conf.MaxUploadBandwidth, "max-upload-bandwidth", "Set the max bytes per second uploaded by all the pushes (0 for no limit)")
 	flags.IntVar(&conf.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout, "Set the default shutdown timeout")
+	flags.IntVar(&eventsLogMaxFiles, "events-log-max-files", config.DefaultEventsLogMaxFiles, "Set the max number of files of the events journal (0 to disable)")
+	conf.EventsLogMaxSize = opts.MemBytes(config.DefaultEventsLogMaxSize)
+	flags.Var(&conf.EventsLogMaxSize, "events-log-max-size", "Set the max size of each file of the events journal")
+	flags.IntVar(&conf.ImageGCHighThreshold, "image-gc-high-threshold", 0, "Set the disk usage percent of the data-root above which unused images are deleted (0 to disable)")
+	flags.IntVar(&conf.ImageGCLowThreshold, "image-gc-low-threshold", 0, "Set the disk usage percent of the data-root at which unused images stop being deleted (default 10 below the high threshold)")
 
 	flags.StringVar(&conf.SwarmDefaultAdvertiseAddr, "swarm-default-advertise-addr", "", "Set default address or interface for swarm advertised address")
 	flags.BoolVar(&conf.Experimental, "experimental", false, "Enable experimental features")
 
 	flags.StringVar(&conf.MetricsAddress, "metrics-addr", "", "Set default address and port to serve the metrics api on")
+	flags.Var(opts.NewNamedListOptsRef("metrics-container-dimensions", &conf.MetricsContainerDimensions, config.ValidateMetricsContainerDimension), "metrics-container-dimension", "Set the labels of the per-container metrics (id, name, image or label=<key>)")
 
 	conf.MaxConcurrentDownloads = &maxConcurrentDownloads
 	conf.MaxConcurrentUploads = &maxConcurrentUploads
+	conf.EventsLogMaxFiles = &eventsLogMaxFiles
 }
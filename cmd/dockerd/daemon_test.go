@@ -145,3 +145,34 @@ func TestLoadDaemonConfigWithRegistryOptions(t *testing.T) {
 	assert.Len(t, loadedConfig.Mirrors, 1)
 	assert.Len(t, loadedConfig.InsecureRegistries, 1)
 }
+
+func TestLoadDaemonConfigWithRegistryHostMirrors(t *testing.T) {
+	content := `{
+		"registry-host-mirrors": {
+			"gcr.io": ["https://gcr-mirror.example.com", "https://gcr-mirror-2.example.com"],
+			"quay.io": ["https://quay-mirror.example.com"]
+		}
+	}`
+	tempFile := tempfile.NewTempFile(t, "config", content)
+	defer tempFile.Remove()
+
+	opts := defaultOptions(tempFile.Name())
+	loadedConfig, err := loadDaemonCliConfig(opts)
+	require.NoError(t, err)
+	require.NotNil(t, loadedConfig)
+
+	assert.Equal(t, map[string][]string{
+		"gcr.io":  {"https://gcr-mirror.example.com", "https://gcr-mirror-2.example.com"},
+		"quay.io": {"https://quay-mirror.example.com"},
+	}, loadedConfig.HostMirrors)
+}
+
+func TestLoadDaemonConfigWithInvalidRegistryHostMirrors(t *testing.T) {
+	content := `{"registry-host-mirrors": {"docker.io": ["https://mirror.example.com"]}}`
+	tempFile := tempfile.NewTempFile(t, "config", content)
+	defer tempFile.Remove()
+
+	opts := defaultOptions(tempFile.Name())
+	_, err := loadDaemonCliConfig(opts)
+	testutil.ErrorContains(t, err, "use registry-mirrors")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	"github.com/docker/docker/daemon/logger/local"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -87,8 +88,9 @@ type CommonContainer struct {
 	MountPoints            map[string]*volume.MountPoint
 	HostConfig             *containertypes.HostConfig `json:"-"` // do not serialize the host config in the json, otherwise we'll make the container unportable
 	ExecCommands           *exec.Store                `json:"-"`
-	SecretStore            agentexec.SecretGetter     `json:"-"`
+	DependencyStore        agentexec.DependencyGetter `json:"-"`
 	SecretReferences       []*swarmtypes.SecretReference
+	ConfigReferences       []*swarmtypes.ConfigReference
 	// logDriver for closing
 	LogDriver      logger.Logger  `json:"-"`
 	LogCopier      *logger.Copier `json:"-"`
@@ -336,12 +338,30 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 			return nil, err
 		}
 	}
+	// Set logging file for "local"
+	if cfg.Type == local.Name {
+		info.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s-local.log", container.ID))
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	l, err := initDriver(info)
 	if err != nil {
 		return nil, err
 	}
 
+	// Keep the last messages in memory for the drivers which cannot read
+	// logs back when enabled, so that `docker logs` keeps working
+	if _, ok := l.(logger.LogReader); !ok && logger.ShouldUseCache(cfg.Config) {
+		cl, err := logger.NewCachedLogger(l, info, -1)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l = cl
+	}
+
 	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
 		bufferSize := int64(-1)
 		if s, exists := cfg.Config["max-buffer-size"]; exists {
@@ -820,6 +840,29 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 	return createOptions, nil
 }
 
+// updateRestartPolicy replaces the restart policy of the container. The
+// backoff settings which are not set in the new policy are kept, as
+// `docker update --restart` only sets the name and the maximum retry count.
+func (container *Container) updateRestartPolicy(policy containertypes.RestartPolicy) {
+	old := container.HostConfig.RestartPolicy
+	if policy.InitialDelay == 0 {
+		policy.InitialDelay = old.InitialDelay
+	}
+	if policy.MaxDelay == 0 {
+		policy.MaxDelay = old.MaxDelay
+	}
+	if policy.Multiplier == 0 {
+		policy.Multiplier = old.Multiplier
+	}
+	if policy.ResetWindow == 0 {
+		policy.ResetWindow = old.ResetWindow
+	}
+	if policy.Jitter == 0 {
+		policy.Jitter = old.Jitter
+	}
+	container.HostConfig.RestartPolicy = policy
+}
+
 // UpdateMonitor updates monitor configure for running container
 func (container *Container) UpdateMonitor(restartPolicy containertypes.RestartPolicy) {
 	type policySetter interface {
@@ -855,6 +898,7 @@ func (container *Container) ResetRestartManager(resetCount bool) {
 	}
 	if resetCount {
 		container.RestartCount = 0
+		container.CrashLooping = false
 	}
 	container.restartManager = nil
 }
@@ -2,6 +2,7 @@ package container
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/signal"
@@ -58,3 +59,31 @@ func TestContainerStopTimeout(t *testing.T) {
 		t.Fatalf("Expected 15, got %v", s)
 	}
 }
+
+func TestContainerUpdateRestartPolicyKeepsBackoff(t *testing.T) {
+	c := &Container{
+		CommonContainer: CommonContainer{
+			HostConfig: &container.HostConfig{
+				RestartPolicy: container.RestartPolicy{
+					Name:         "always",
+					InitialDelay: time.Second,
+					MaxDelay:     time.Minute,
+					Multiplier:   3,
+				},
+			},
+		},
+	}
+
+	c.updateRestartPolicy(container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3, MaxDelay: 2 * time.Minute})
+
+	expected := container.RestartPolicy{
+		Name:              "on-failure",
+		MaximumRetryCount: 3,
+		InitialDelay:      time.Second,
+		MaxDelay:          2 * time.Minute,
+		Multiplier:        3,
+	}
+	if c.HostConfig.RestartPolicy != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, c.HostConfig.RestartPolicy)
+	}
+}
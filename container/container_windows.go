@@ -48,6 +48,11 @@ func (container *Container) SecretMount() *Mount {
 	return nil
 }
 
+// ConfigMounts returns the mounts for configs.
+func (container *Container) ConfigMounts() []Mount {
+	return nil
+}
+
 // UnmountSecrets unmounts the fs for secrets
 func (container *Container) UnmountSecrets() error {
 	return nil
@@ -109,7 +114,7 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 		if container.HostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
 			return fmt.Errorf("Restart policy cannot be updated because AutoRemove is enabled for the container")
 		}
-		container.HostConfig.RestartPolicy = hostConfig.RestartPolicy
+		container.updateRestartPolicy(hostConfig.RestartPolicy)
 	}
 	return nil
 }
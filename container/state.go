@@ -21,6 +21,7 @@ type State struct {
 	Running           bool
 	Paused            bool
 	Restarting        bool
+	CrashLooping      bool
 	OOMKilled         bool
 	RemovalInProgress bool // Not need for this to be persistent on disk.
 	Dead              bool
@@ -73,6 +74,9 @@ func (s *State) String() string {
 			return fmt.Sprintf("Up %s (Paused)", units.HumanDuration(time.Now().UTC().Sub(s.StartedAt)))
 		}
 		if s.Restarting {
+			if s.CrashLooping {
+				return fmt.Sprintf("Restarting (%d, crash loop) %s ago", s.ExitCodeValue, units.HumanDuration(time.Now().UTC().Sub(s.FinishedAt)))
+			}
 			return fmt.Sprintf("Restarting (%d) %s ago", s.ExitCodeValue, units.HumanDuration(time.Now().UTC().Sub(s.FinishedAt)))
 		}
 
@@ -286,6 +290,14 @@ func (s *State) SetRestarting(exitStatus *ExitStatus) {
 	s.waitChan = make(chan struct{})
 }
 
+// SetCrashLooping records whether the container is restarting in a tight
+// loop. It returns true if the container just entered the crash loop.
+func (s *State) SetCrashLooping(crashLooping bool) bool {
+	entered := crashLooping && !s.CrashLooping
+	s.CrashLooping = crashLooping
+	return entered
+}
+
 // SetError sets the container's error state. This is useful when we want to
 // know the error that occurred when container transits to another state
 // when inspecting it
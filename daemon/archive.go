@@ -366,14 +366,15 @@ func (daemon *Daemon) containerCopy(container *container.Container, resource str
 }
 
 // CopyOnBuild copies/extracts a source FileInfo to a destination path inside a container
-// specified by a container object.
+// specified by a container object. The files are owned by the user and group
+// of chown, in the user[:group] format, or by root if chown is empty.
 // TODO: make sure callers don't unnecessarily convert destPath with filepath.FromSlash (Copy does it already).
 // CopyOnBuild should take in abstract paths (with slashes) and the implementation should convert it to OS-specific paths.
-func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileInfo, decompress bool) error {
+func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileInfo, decompress bool, chown string) error {
 	srcPath := src.Path()
 	destExists := true
 	destDir := false
-	rootUID, rootGID := daemon.GetRemappedUIDGID()
+	uid, gid := daemon.GetRemappedUIDGID()
 
 	// Work in daemon-local OS specific file paths
 	destPath = filepath.FromSlash(destPath)
@@ -388,6 +389,12 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 	}
 	defer daemon.Unmount(c)
 
+	if chown != "" {
+		if uid, gid, err = daemon.getChownIDs(c, chown); err != nil {
+			return err
+		}
+	}
+
 	dest, err := c.GetResourcePath(destPath)
 	if err != nil {
 		return err
@@ -423,7 +430,7 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		if err := archiver.CopyWithTar(srcPath, destPath); err != nil {
 			return err
 		}
-		return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
+		return fixPermissions(srcPath, destPath, uid, gid, destExists)
 	}
 	if decompress && archive.IsArchivePath(srcPath) {
 		// Only try to untar if it is a file and that we've been told to decompress (when ADD-ing a remote file)
@@ -438,6 +445,9 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		}
 
 		// try to successfully untar the orig
+		if chown != "" {
+			return untarPathAs(archiver, srcPath, tarDest, uid, gid)
+		}
 		err := archiver.UntarPath(srcPath, tarDest)
 		/*
 			if err != nil {
@@ -452,14 +462,29 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		destPath = filepath.Join(destPath, src.Name())
 	}
 
-	if err := idtools.MkdirAllNewAs(filepath.Dir(destPath), 0755, rootUID, rootGID); err != nil {
+	if err := idtools.MkdirAllNewAs(filepath.Dir(destPath), 0755, uid, gid); err != nil {
 		return err
 	}
 	if err := archiver.CopyFileWithTar(srcPath, destPath); err != nil {
 		return err
 	}
 
-	return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
+	return fixPermissions(srcPath, destPath, uid, gid, destExists)
+}
+
+// untarPathAs unpacks the archive at src to dst like archiver.UntarPath, with
+// the unpacked files owned by uid and gid.
+func untarPathAs(archiver *archive.Archiver, src, dst string, uid, gid int) error {
+	r, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return archiver.Untar(r, dst, &archive.TarOptions{
+		UIDMaps:   archiver.UIDMaps,
+		GIDMaps:   archiver.GIDMaps,
+		ChownOpts: &archive.TarChownOptions{UID: uid, GID: gid},
+	})
 }
 
 // MountImage returns mounted path with rootfs of an image.
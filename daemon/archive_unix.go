@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/pkg/errors"
 )
 
 // checkIfPathIsInAVolume checks if the path is in a volume. If it is, it
@@ -57,6 +59,28 @@ func fixPermissions(source, destination string, uid, gid int, destExisted bool)
 	})
 }
 
+// getChownIDs returns the host UID and GID for the user and group of chown, in
+// the user[:group] format of the --chown flag of COPY and ADD. The names are
+// looked up in the /etc/passwd and /etc/group files of the container, which
+// must be mounted.
+func (daemon *Daemon) getChownIDs(c *container.Container, chown string) (int, int, error) {
+	uid, gid, _, err := getUser(c, chown)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "unable to resolve --chown=%s", chown)
+	}
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	hostUID, err := idtools.ToHost(int(uid), uidMaps)
+	if err != nil {
+		return 0, 0, err
+	}
+	hostGID, err := idtools.ToHost(int(gid), gidMaps)
+	if err != nil {
+		return 0, 0, err
+	}
+	return hostUID, hostGID, nil
+}
+
 // isOnlineFSOperationPermitted returns an error if an online filesystem operation
 // is not permitted.
 func (daemon *Daemon) isOnlineFSOperationPermitted(container *container.Container) error {
@@ -0,0 +1,58 @@
+// +build !windows
+
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChownIDs(t *testing.T) {
+	baseFS, err := ioutil.TempDir("", "chown-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(baseFS)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(baseFS, "etc"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(baseFS, "etc", "passwd"), []byte("root:x:0:0::/root:/bin/sh\napp:x:1001:1002::/home/app:/bin/sh\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(baseFS, "etc", "group"), []byte("root:x:0:\napp:x:1002:\nstaff:x:50:\n"), 0644))
+
+	c := &container.Container{CommonContainer: container.CommonContainer{
+		BaseFS:     baseFS,
+		HostConfig: &containertypes.HostConfig{},
+	}}
+	daemon := &Daemon{}
+
+	for _, tc := range []struct {
+		chown    string
+		uid, gid int
+	}{
+		{chown: "app", uid: 1001, gid: 1002},
+		{chown: "app:staff", uid: 1001, gid: 50},
+		{chown: "1234:5678", uid: 1234, gid: 5678},
+		{chown: "app:5678", uid: 1001, gid: 5678},
+	} {
+		uid, gid, err := daemon.getChownIDs(c, tc.chown)
+		require.NoError(t, err, tc.chown)
+		assert.Equal(t, tc.uid, uid, tc.chown)
+		assert.Equal(t, tc.gid, gid, tc.chown)
+	}
+
+	_, _, err = daemon.getChownIDs(c, "nosuchuser")
+	assert.Error(t, err)
+
+	// The IDs are remapped with user namespaces.
+	daemon.uidMaps = []idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	daemon.gidMaps = []idtools.IDMap{{ContainerID: 0, HostID: 200000, Size: 65536}}
+	uid, gid, err := daemon.getChownIDs(c, "app")
+	require.NoError(t, err)
+	assert.Equal(t, 101001, uid)
+	assert.Equal(t, 201002, gid)
+}
@@ -22,6 +22,10 @@ func fixPermissions(source, destination string, uid, gid int, destExisted bool)
 	return nil
 }
 
+func (daemon *Daemon) getChownIDs(c *container.Container, chown string) (int, int, error) {
+	return 0, 0, errors.New("the --chown flag is not supported on Windows")
+}
+
 // isOnlineFSOperationPermitted returns an error if an online filesystem operation
 // is not permitted (such as stat or for copying). Running Hyper-V containers
 // cannot have their file-system interrogated from the host as the filter is
@@ -0,0 +1,243 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/go-units"
+)
+
+var acceptedBuildCachePruneFilterTags = map[string]bool{
+	"until":        true,
+	"keep-storage": true,
+}
+
+// buildCacheEntry is an image in the build cache.
+type buildCacheEntry struct {
+	id       image.ID
+	parent   image.ID
+	size     int64
+	inUse    bool
+	children int
+	created  time.Time
+	lastUsed time.Time
+}
+
+type buildCacheByLastUsed []buildCacheEntry
+
+func (r buildCacheByLastUsed) Len() int           { return len(r) }
+func (r buildCacheByLastUsed) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r buildCacheByLastUsed) Less(i, j int) bool { return r[i].lastUsed.Before(r[j].lastUsed) }
+
+// isBuildCache returns whether the image is an untagged image committed by
+// the builder. Tagging an image of the build cache takes it out of the
+// cache.
+func (daemon *Daemon) isBuildCache(id image.ID) bool {
+	return daemon.imageStore.IsBuildCache(id) && len(daemon.referenceStore.References(id.Digest())) == 0
+}
+
+// UnmarkBuildCache marks an image as the result of a build rather than build
+// cache.
+func (daemon *Daemon) UnmarkBuildCache(id image.ID) error {
+	return daemon.imageStore.SetBuildCache(id, false)
+}
+
+// buildCacheEntries returns the images of the build cache. The size of an
+// entry is the size of the layer it adds on top of its parent. An entry is in
+// use if a container or an image outside of the build cache is built on top
+// of it.
+func (daemon *Daemon) buildCacheEntries() []buildCacheEntry {
+	usedByContainers := make(map[image.ID]bool)
+	for _, c := range daemon.List() {
+		usedByContainers[c.ImageID] = true
+	}
+
+	inUse := make(map[image.ID]bool)
+	var isInUse func(id image.ID) bool
+	isInUse = func(id image.ID) bool {
+		if used, ok := inUse[id]; ok {
+			return used
+		}
+		used := usedByContainers[id]
+		if !used {
+			for _, child := range daemon.imageStore.Children(id) {
+				if !daemon.isBuildCache(child) || isInUse(child) {
+					used = true
+					break
+				}
+			}
+		}
+		inUse[id] = used
+		return used
+	}
+
+	allImages := daemon.imageStore.Map()
+	allLayers := daemon.layerStore.Map()
+	var entries []buildCacheEntry
+	for id, img := range allImages {
+		if !daemon.isBuildCache(id) {
+			continue
+		}
+
+		var size int64
+		if chainID := img.RootFS.ChainID(); chainID != "" {
+			parent, ok := allImages[img.Parent]
+			if l, exists := allLayers[chainID]; exists && (!ok || parent.RootFS.ChainID() != chainID) {
+				var err error
+				if size, err = l.DiffSize(); err != nil {
+					logrus.Warnf("failed to get diff size for layer %v", chainID)
+				}
+			}
+		}
+
+		lastUsed, err := daemon.imageStore.GetLastUsed(id)
+		if err != nil {
+			lastUsed = img.Created
+		}
+
+		entries = append(entries, buildCacheEntry{
+			id:       id,
+			parent:   img.Parent,
+			size:     size,
+			inUse:    isInUse(id),
+			children: len(daemon.imageStore.Children(id)),
+			created:  img.Created,
+			lastUsed: lastUsed,
+		})
+	}
+	return entries
+}
+
+// buildCache returns the build cache for the disk usage.
+func (daemon *Daemon) buildCache() []*types.BuildCache {
+	caches := []*types.BuildCache{}
+	for _, e := range daemon.buildCacheEntries() {
+		caches = append(caches, &types.BuildCache{
+			ID:       e.id.String(),
+			ParentID: e.parent.String(),
+			Size:     e.size,
+			InUse:    e.inUse,
+			Created:  e.created.Unix(),
+			LastUsed: e.lastUsed.Unix(),
+		})
+	}
+	return caches
+}
+
+// BuildCachePrune removes the build cache which is not in use, from the least
+// to the most recently used. The until filter only removes the cache last
+// used before the given time, the keep-storage filter stops removing cache
+// once the size of the cache which could be removed is down to the given
+// number of bytes.
+func (daemon *Daemon) BuildCachePrune(pruneFilters filters.Args) (*types.BuildCachePruneReport, error) {
+	if err := pruneFilters.Validate(acceptedBuildCachePruneFilterTags); err != nil {
+		return nil, err
+	}
+	until, err := getUntilFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+	keepStorage, err := getKeepStorageFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &types.BuildCachePruneReport{}
+	allLayers := daemon.layerStore.Map()
+	pruneBuildCache(daemon.buildCacheEntries(), until, keepStorage, func(e buildCacheEntry) bool {
+		imgDel, err := daemon.ImageDelete(e.id.String(), false, false)
+		if err != nil {
+			logrus.Warnf("could not delete build cache %s: %v", e.id, err)
+			return false
+		}
+		rep.CachesDeleted = append(rep.CachesDeleted, e.id.String())
+		for _, d := range imgDel {
+			if d.Deleted == "" {
+				continue
+			}
+			chid := layer.ChainID(d.Deleted)
+			if l, ok := allLayers[chid]; ok {
+				diffSize, err := l.DiffSize()
+				if err != nil {
+					logrus.Warnf("failed to get layer %s size: %v", chid, err)
+					continue
+				}
+				rep.SpaceReclaimed += uint64(diffSize)
+			}
+		}
+		return true
+	})
+
+	return rep, nil
+}
+
+// pruneBuildCache calls remove on the entries which are not in use and have
+// no children, from the least to the most recently used, until the size of
+// the entries which could be removed is down to keepStorage, or none is left.
+// Removing an entry can leave its parent without children, so that it can be
+// removed in turn.
+func pruneBuildCache(entries []buildCacheEntry, until time.Time, keepStorage int64, remove func(buildCacheEntry) bool) {
+	sort.Sort(buildCacheByLastUsed(entries))
+
+	children := make(map[image.ID]int)
+	removable := make(map[image.ID]bool)
+	var total int64
+	for _, e := range entries {
+		children[e.id] = e.children
+		if e.inUse || (!until.IsZero() && e.lastUsed.After(until)) {
+			continue
+		}
+		removable[e.id] = true
+		total += e.size
+	}
+
+	for len(removable) > 0 {
+		if keepStorage >= 0 && total <= keepStorage {
+			return
+		}
+		i := -1
+		for j, e := range entries {
+			if removable[e.id] && children[e.id] == 0 {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return
+		}
+		e := entries[i]
+		// An entry which failed to be removed is not attempted again, and
+		// does not count as removable anymore.
+		delete(removable, e.id)
+		total -= e.size
+		if remove(e) {
+			children[e.parent]--
+		}
+	}
+}
+
+// getKeepStorageFromPruneFilters returns the number of bytes of build cache
+// to keep, or -1 if the keep-storage filter is not set.
+func getKeepStorageFromPruneFilters(pruneFilters filters.Args) (int64, error) {
+	if !pruneFilters.Include("keep-storage") {
+		return -1, nil
+	}
+	values := pruneFilters.Get("keep-storage")
+	if len(values) > 1 {
+		return -1, fmt.Errorf("more than one keep-storage filter specified")
+	}
+	keepStorage, err := units.RAMInBytes(values[0])
+	if err != nil {
+		return -1, fmt.Errorf("invalid keep-storage filter '%s': %v", values[0], err)
+	}
+	if keepStorage < 0 {
+		return -1, fmt.Errorf("invalid keep-storage filter '%s': must not be negative", values[0])
+	}
+	return keepStorage, nil
+}
@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/image"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeepStorageFromPruneFilters(t *testing.T) {
+	keepStorage, err := getKeepStorageFromPruneFilters(filters.NewArgs())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), keepStorage)
+
+	args := filters.NewArgs()
+	args.Add("keep-storage", "512m")
+	keepStorage, err = getKeepStorageFromPruneFilters(args)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(512*1024*1024), keepStorage)
+
+	args.Add("keep-storage", "1g")
+	_, err = getKeepStorageFromPruneFilters(args)
+	assert.EqualError(t, err, "more than one keep-storage filter specified")
+
+	args = filters.NewArgs()
+	args.Add("keep-storage", "lots")
+	_, err = getKeepStorageFromPruneFilters(args)
+	assert.Error(t, err)
+}
+
+func TestPruneBuildCacheKeepStorage(t *testing.T) {
+	now := time.Now()
+	entries := []buildCacheEntry{
+		// in use, never removed, and not counted in the removable size
+		{id: "used", size: 100, inUse: true, lastUsed: now.Add(-4 * time.Hour)},
+		// removable once its child is removed
+		{id: "parent", size: 10, children: 1, lastUsed: now.Add(-3 * time.Hour)},
+		{id: "child", parent: "parent", size: 10, lastUsed: now.Add(-2 * time.Hour)},
+		{id: "recent", size: 10, lastUsed: now.Add(-1 * time.Hour)},
+	}
+
+	var removed []image.ID
+	remove := func(e buildCacheEntry) bool {
+		removed = append(removed, e.id)
+		return true
+	}
+
+	pruneBuildCache(append([]buildCacheEntry(nil), entries...), time.Time{}, 15, remove)
+	assert.Equal(t, []image.ID{"child", "parent"}, removed)
+
+	removed = nil
+	pruneBuildCache(append([]buildCacheEntry(nil), entries...), time.Time{}, -1, remove)
+	assert.Equal(t, []image.ID{"child", "parent", "recent"}, removed)
+
+	// entries failing to be removed are not attempted again
+	removed = nil
+	pruneBuildCache(append([]buildCacheEntry(nil), entries...), time.Time{}, 0, func(e buildCacheEntry) bool {
+		removed = append(removed, e.id)
+		return false
+	})
+	assert.Equal(t, []image.ID{"child", "recent"}, removed)
+}
@@ -1,27 +1,137 @@
 package daemon
 
 import (
+	"io"
+
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/builder"
+	"github.com/docker/docker/distribution"
+	progressutils "github.com/docker/docker/distribution/utils"
+	"github.com/docker/docker/image"
 	"github.com/docker/docker/image/cache"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/net/context"
 )
 
-// MakeImageCache creates a stateful image cache.
-func (daemon *Daemon) MakeImageCache(sourceRefs []string) builder.ImageCache {
+// MakeImageCache creates a stateful image cache. Only the configs of the
+// images of sourceRefs which are not present locally are pulled, their layers
+// are pulled when a cache hit reuses them.
+func (daemon *Daemon) MakeImageCache(ctx context.Context, sourceRefs []string, authConfigs map[string]types.AuthConfig, output io.Writer) builder.ImageCache {
 	if len(sourceRefs) == 0 {
-		return cache.NewLocal(daemon.imageStore)
+		return &usedImageCache{ImageCache: cache.NewLocal(daemon.imageStore), daemon: daemon}
 	}
 
 	cache := cache.New(daemon.imageStore)
 
 	for _, ref := range sourceRefs {
 		img, err := daemon.GetImage(ref)
+		if err == nil {
+			cache.Populate(img)
+			continue
+		}
+
+		img, remote, err := daemon.lookupRemoteCacheImage(ctx, ref, authConfigs, output)
 		if err != nil {
 			logrus.Warnf("Could not look up %s for cache resolution, skipping: %+v", ref, err)
 			continue
 		}
-		cache.Populate(img)
+		if remote == nil {
+			cache.Populate(img)
+		} else {
+			cache.PopulateRemote(img, remote)
+		}
+	}
+
+	return &usedImageCache{ImageCache: cache, daemon: daemon}
+}
+
+// lookupRemoteCacheImage pulls the config of an image of --cache-from from its
+// registry. The image is returned without a remote image if it turns out to
+// be present locally.
+func (daemon *Daemon) lookupRemoteCacheImage(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (*image.Image, *remoteCacheImage, error) {
+	ref, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref = reference.TagNameOnly(ref)
+
+	authConfig, err := daemon.resolveBuildAuthConfig(ref, authConfigs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remoteImage, err := daemon.pullImageConfig(ctx, ref, authConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The image may be present locally under another name.
+	if img, err := daemon.imageStore.Get(image.IDFromDigest(digest.FromBytes(remoteImage.Config))); err == nil {
+		return img, nil, nil
+	}
+
+	img, err := image.NewFromJSON(remoteImage.Config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logrus.Debugf("Using the config of %s from its registry for cache resolution", reference.FamiliarString(ref))
+	return img, &remoteCacheImage{ctx: ctx, image: remoteImage, output: output}, nil
+}
+
+// remoteCacheImage pulls the layers of an image of --cache-from which is not
+// present locally when a cache hit reuses them.
+type remoteCacheImage struct {
+	ctx    context.Context
+	image  *distribution.RemoteImage
+	output io.Writer
+}
+
+// FetchLayers pulls the layers of rootFS on top of parent.
+func (r *remoteCacheImage) FetchLayers(parent, rootFS image.RootFS) (func(), error) {
+	// Include a buffer so that slow client connections don't affect
+	// transfer performance.
+	progressChan := make(chan progress.Progress, 100)
+
+	writesDone := make(chan struct{})
+
+	ctx, cancelFunc := context.WithCancel(r.ctx)
+
+	go func() {
+		progressutils.WriteDistributionProgress(cancelFunc, r.output, progressChan)
+		close(writesDone)
+	}()
+
+	release, err := r.image.PullLayers(ctx, parent, rootFS, progress.ChanOutput(progressChan))
+	close(progressChan)
+	<-writesDone
+	return release, err
+}
+
+// usedImageCache records the cache hits as uses of the cached images, for
+// the build cache to be pruned from the least recently used images.
+type usedImageCache struct {
+	builder.ImageCache
+	daemon *Daemon
+}
+
+// Clone returns a copy of the cache, in the state it had when created.
+func (c *usedImageCache) Clone() builder.ImageCache {
+	if ic, ok := c.ImageCache.(*cache.ImageCache); ok {
+		return &usedImageCache{ImageCache: ic.Clone(), daemon: c.daemon}
 	}
+	// the local image cache is stateless
+	return c
+}
 
-	return cache
+// GetCache returns the image id found in the cache
+func (c *usedImageCache) GetCache(parentID string, cfg *containertypes.Config) (string, error) {
+	imgID, err := c.ImageCache.GetCache(parentID, cfg)
+	if err == nil && imgID != "" {
+		c.daemon.markImageUsed(image.ID(imgID))
+	}
+	return imgID, err
 }
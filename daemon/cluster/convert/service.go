@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	types "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/pkg/namesgenerator"
@@ -16,6 +17,16 @@ var (
 	ErrUnsupportedRuntime = errors.New("unsupported runtime")
 )
 
+// The update settings which have no equivalent in the swarmkit update config
+// are stored in reserved labels of the service.
+const (
+	updateLabelPrefix = "com.docker.swarm.update."
+
+	// healthGracePeriodLabel holds the HealthGracePeriod of the update
+	// config, read by the executor.
+	healthGracePeriodLabel = updateLabelPrefix + "health-grace-period"
+)
+
 // ServiceFromGRPC converts a grpc Service to a Service.
 func ServiceFromGRPC(s swarmapi.Service) (types.Service, error) {
 	curSpec, err := serviceSpecFromGRPC(&s.Spec)
@@ -113,6 +124,7 @@ func serviceSpecFromGRPC(spec *swarmapi.ServiceSpec) (*types.ServiceSpec, error)
 	// UpdateConfig
 	convertedSpec.UpdateConfig = updateConfigFromGRPC(spec.Update)
 	convertedSpec.RollbackConfig = updateConfigFromGRPC(spec.Rollback)
+	updateHealthFromLabels(convertedSpec)
 
 	// Mode
 	switch t := spec.GetMode().(type) {
@@ -144,10 +156,15 @@ func ServiceSpecToGRPC(s types.ServiceSpec) (swarmapi.ServiceSpec, error) {
 		taskNetworks = append(taskNetworks, &swarmapi.NetworkAttachmentConfig{Target: n.Target, Aliases: n.Aliases})
 	}
 
+	labels, err := updateHealthToLabels(s)
+	if err != nil {
+		return swarmapi.ServiceSpec{}, err
+	}
+
 	spec := swarmapi.ServiceSpec{
 		Annotations: swarmapi.Annotations{
 			Name:   name,
-			Labels: s.Labels,
+			Labels: labels,
 		},
 		Task: swarmapi.TaskSpec{
 			Resources:   resourcesToGRPC(s.TaskTemplate.Resources),
@@ -489,6 +506,63 @@ func updateConfigToGRPC(updateConfig *types.UpdateConfig) (*swarmapi.UpdateConfi
 	return converted, nil
 }
 
+// updateHealthToLabels returns the labels of the service, with the health
+// grace period of its update config. The labels with the reserved prefix
+// cannot be set by users.
+func updateHealthToLabels(s types.ServiceSpec) (map[string]string, error) {
+	for k := range s.Labels {
+		if strings.HasPrefix(k, updateLabelPrefix) {
+			return nil, fmt.Errorf("invalid label %s, the prefix %s is reserved", k, updateLabelPrefix)
+		}
+	}
+	if c := s.RollbackConfig; c != nil && c.HealthGracePeriod != 0 {
+		return nil, errors.New("the health grace period can only be set in the update config")
+	}
+	c := s.UpdateConfig
+	if c == nil || c.HealthGracePeriod == 0 {
+		return s.Labels, nil
+	}
+	if c.HealthGracePeriod < 0 {
+		return nil, fmt.Errorf("invalid health grace period %s, it must not be negative", c.HealthGracePeriod)
+	}
+
+	labels := make(map[string]string, len(s.Labels)+1)
+	for k, v := range s.Labels {
+		labels[k] = v
+	}
+	labels[healthGracePeriodLabel] = c.HealthGracePeriod.String()
+	return labels, nil
+}
+
+// updateHealthFromLabels moves the health grace period of the update config
+// from the labels of the service to its update config.
+func updateHealthFromLabels(spec *types.ServiceSpec) {
+	labels := make(map[string]string, len(spec.Labels))
+	for k, v := range spec.Labels {
+		if !strings.HasPrefix(k, updateLabelPrefix) {
+			labels[k] = v
+		}
+	}
+	if len(labels) == len(spec.Labels) {
+		return
+	}
+
+	if spec.UpdateConfig != nil {
+		spec.UpdateConfig.HealthGracePeriod = HealthGracePeriod(spec.Labels)
+	}
+	spec.Labels = labels
+}
+
+// HealthGracePeriod returns the health grace period of the update config of
+// a service from its labels, or zero if it is not set.
+func HealthGracePeriod(serviceLabels map[string]string) time.Duration {
+	d, err := time.ParseDuration(serviceLabels[healthGracePeriodLabel])
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
 func taskSpecFromGRPC(taskSpec swarmapi.TaskSpec) types.TaskSpec {
 	taskNetworks := make([]types.NetworkAttachmentConfig, 0, len(taskSpec.Networks))
 	for _, n := range taskSpec.Networks {
@@ -1,7 +1,9 @@
 package convert
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	swarmtypes "github.com/docker/docker/api/types/swarm"
 	swarmapi "github.com/docker/swarmkit/api"
@@ -146,3 +148,85 @@ func TestServiceConvertToGRPCGenericRuntimeCustom(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestServiceConvertUpdateHealth(t *testing.T) {
+	s := swarmtypes.ServiceSpec{
+		Annotations: swarmtypes.Annotations{
+			Name:   "web",
+			Labels: map[string]string{"tier": "front"},
+		},
+		TaskTemplate: swarmtypes.TaskSpec{
+			ContainerSpec: swarmtypes.ContainerSpec{
+				Image: "alpine:latest",
+			},
+		},
+		Mode: swarmtypes.ServiceMode{
+			Global: &swarmtypes.GlobalService{},
+		},
+		UpdateConfig: &swarmtypes.UpdateConfig{
+			FailureAction:     swarmtypes.UpdateFailureActionRollback,
+			MaxFailureRatio:   0.2,
+			HealthGracePeriod: 30 * time.Second,
+		},
+	}
+
+	spec, err := ServiceSpecToGRPC(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Update.FailureAction != swarmapi.UpdateConfig_ROLLBACK || spec.Update.MaxFailureRatio != 0.2 {
+		t.Fatalf("expected a rollback on a failure ratio of 0.2, got %v", spec.Update)
+	}
+	if d := HealthGracePeriod(spec.Annotations.Labels); d != 30*time.Second {
+		t.Fatalf("expected a health grace period of 30s, got %s", d)
+	}
+	if len(s.Labels) != 1 {
+		t.Fatalf("expected the labels of the spec to be left unchanged, got %v", s.Labels)
+	}
+
+	converted, err := ServiceFromGRPC(swarmapi.Service{Spec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(converted.Spec.Labels, s.Labels) {
+		t.Fatalf("expected the labels %v, got %v", s.Labels, converted.Spec.Labels)
+	}
+	c := converted.Spec.UpdateConfig
+	if c.HealthGracePeriod != 30*time.Second {
+		t.Fatalf("expected a health grace period of 30s, got %s", c.HealthGracePeriod)
+	}
+	if _, err := ServiceSpecToGRPC(converted.Spec); err != nil {
+		t.Fatalf("expected the converted spec to be valid, got %v", err)
+	}
+}
+
+func TestServiceConvertUpdateHealthInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		labels   map[string]string
+		update   *swarmtypes.UpdateConfig
+		rollback *swarmtypes.UpdateConfig
+	}{
+		{labels: map[string]string{"com.docker.swarm.update.health-grace-period": "1s"}},
+		{update: &swarmtypes.UpdateConfig{HealthGracePeriod: -time.Second}},
+		{rollback: &swarmtypes.UpdateConfig{HealthGracePeriod: time.Second}},
+	} {
+		s := swarmtypes.ServiceSpec{
+			Annotations: swarmtypes.Annotations{
+				Labels: tc.labels,
+			},
+			TaskTemplate: swarmtypes.TaskSpec{
+				ContainerSpec: swarmtypes.ContainerSpec{
+					Image: "alpine:latest",
+				},
+			},
+			Mode: swarmtypes.ServiceMode{
+				Global: &swarmtypes.GlobalService{},
+			},
+			UpdateConfig:   tc.update,
+			RollbackConfig: tc.rollback,
+		}
+		if _, err := ServiceSpecToGRPC(s); err == nil {
+			t.Fatalf("expected an error for the labels %v, the update config %v and the rollback config %v", tc.labels, tc.update, tc.rollback)
+		}
+	}
+}
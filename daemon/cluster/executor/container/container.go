@@ -18,6 +18,7 @@ import (
 	enginemount "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/daemon/cluster/convert"
 	clustertypes "github.com/docker/docker/daemon/cluster/provider"
 	"github.com/docker/go-connections/nat"
 	"github.com/docker/swarmkit/agent/exec"
@@ -336,6 +337,12 @@ func (c *containerConfig) healthcheck() *enginecontainer.HealthConfig {
 	}
 }
 
+// healthGracePeriod returns the time the container has to become healthy
+// once started, or zero if there is no limit.
+func (c *containerConfig) healthGracePeriod() time.Duration {
+	return convert.HealthGracePeriod(c.task.ServiceAnnotations.Labels)
+}
+
 func (c *containerConfig) hostConfig() *enginecontainer.HostConfig {
 	hc := &enginecontainer.HostConfig{
 		Resources:      c.resources(),
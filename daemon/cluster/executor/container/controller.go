@@ -39,8 +39,8 @@ type controller struct {
 var _ exec.Controller = &controller{}
 
 // NewController returns a docker exec runner for the provided task.
-func newController(b executorpkg.Backend, task *api.Task, secrets exec.SecretGetter) (*controller, error) {
-	adapter, err := newContainerAdapter(b, task, secrets)
+func newController(b executorpkg.Backend, task *api.Task, dependencies exec.DependencyGetter) (*controller, error) {
+	adapter, err := newContainerAdapter(b, task, dependencies)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +181,12 @@ func (r *controller) Start(ctx context.Context) error {
 		return exec.ErrTaskStarted
 	}
 
+	// Subscribe to the events of the container before starting it, so that a
+	// health status reported right after the start is not missed.
+	ectx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	eventq := r.adapter.events(ectx)
+
 	for {
 		if err := r.adapter.start(ctx); err != nil {
 			if _, ok := err.(libnetwork.ErrNoSuchNetwork); ok {
@@ -209,10 +215,16 @@ func (r *controller) Start(ctx context.Context) error {
 		return nil
 	}
 
-	// wait for container to be healthy
-	eventq := r.adapter.events(ctx)
-
-	var healthErr error
+	// wait for container to be healthy, within the grace period if any
+	var (
+		healthErr    error
+		gracePeriodC <-chan time.Time
+	)
+	if gracePeriod := r.adapter.container.healthGracePeriod(); gracePeriod > 0 {
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		gracePeriodC = timer.C
+	}
 	for {
 		select {
 		case event := <-eventq:
@@ -225,7 +237,7 @@ func (r *controller) Start(ctx context.Context) error {
 				ctnr, err := r.adapter.inspect(ctx)
 				if err != nil {
 					return errors.Wrap(err, "die event received")
-				} else if ctnr.State.ExitCode != 0 {
+				} else if ctnr.State.ExitCode != 0 || healthErr != nil {
 					return &exitError{code: ctnr.State.ExitCode, cause: healthErr}
 				}
 
@@ -235,6 +247,9 @@ func (r *controller) Start(ctx context.Context) error {
 				// and report anyways.
 				return ErrContainerDestroyed
 			case "health_status: unhealthy":
+				// the container is being shut down, the grace period must
+				// not shut it down again nor replace the health check error
+				gracePeriodC = nil
 				// in this case, we stop the container and report unhealthy status
 				if err := r.Shutdown(ctx); err != nil {
 					return errors.Wrap(err, "unhealthy container shutdown failed")
@@ -248,6 +263,13 @@ func (r *controller) Start(ctx context.Context) error {
 				}
 				return nil
 			}
+		case <-gracePeriodC:
+			gracePeriodC = nil
+			if err := r.Shutdown(ctx); err != nil {
+				return errors.Wrap(err, "shutdown of container not healthy within the grace period failed")
+			}
+			// wait for container to fully exit ("die" event)
+			healthErr = ErrHealthGracePeriodExceeded
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-r.closed:
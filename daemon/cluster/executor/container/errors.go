@@ -14,4 +14,8 @@ var (
 
 	// ErrContainerUnhealthy returned if controller detects the health check failure
 	ErrContainerUnhealthy = errors.New("dockerexec: unhealthy container")
+
+	// ErrHealthGracePeriodExceeded returned if the container does not become
+	// healthy within the health grace period of the service
+	ErrHealthGracePeriodExceeded = errors.New("dockerexec: container not healthy within the health grace period")
 )
@@ -100,3 +100,36 @@ func TestHealthStates(t *testing.T) {
 	// unhealthy event will be caught by checkHealth
 	logAndExpect("health_status: unhealthy", ErrContainerUnhealthy)
 }
+
+func TestHealthGracePeriod(t *testing.T) {
+	task := &api.Task{
+		ID:        "id",
+		ServiceID: "sid",
+		Spec: api.TaskSpec{
+			Runtime: &api.TaskSpec_Container{
+				Container: &api.ContainerSpec{
+					Image: "image_name",
+				},
+			},
+		},
+		ServiceAnnotations: api.Annotations{
+			Name: "service",
+			Labels: map[string]string{
+				"com.docker.swarm.update.health-grace-period": "1m30s",
+			},
+		},
+	}
+
+	c, err := newContainerConfig(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := c.healthGracePeriod(); d != 90*time.Second {
+		t.Fatalf("expected a health grace period of 1m30s, got %s", d)
+	}
+
+	task.ServiceAnnotations.Labels = nil
+	if d := c.healthGracePeriod(); d != 0 {
+		t.Fatalf("expected no health grace period, got %s", d)
+	}
+}
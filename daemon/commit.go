@@ -229,6 +229,12 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		}
 	}
 
+	if c.BuildCache {
+		if err := daemon.imageStore.SetBuildCache(id, true); err != nil {
+			return "", err
+		}
+	}
+
 	imageRef := ""
 	if c.Repo != "" {
 		newTag, err := reference.ParseNormalizedNamed(c.Repo) // todo: should move this to API layer
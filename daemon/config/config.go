@@ -43,6 +43,16 @@ const (
 	DisableNetworkBridge = "none"
 	// DefaultInitBinary is the name of the default init binary
 	DefaultInitBinary = "docker-init"
+	// DefaultEventsLogMaxFiles is the default number of files kept for
+	// the on-disk events journal.
+	DefaultEventsLogMaxFiles = 5
+	// DefaultEventsLogMaxSize is the default maximum size of each file
+	// of the on-disk events journal.
+	DefaultEventsLogMaxSize = int64(10 * 1024 * 1024)
+	// DefaultImageGCThresholdGap is the default difference, in percent,
+	// between the image garbage collection high threshold and the disk
+	// usage it brings the data-root down to, when no low threshold is set.
+	DefaultImageGCThresholdGap = 10
 )
 
 // flatOptions contains configuration keys
@@ -50,10 +60,11 @@ const (
 // Use this to differentiate these options
 // with others like the ones in CommonTLSOptions.
 var flatOptions = map[string]bool{
-	"cluster-store-opts": true,
-	"log-opts":           true,
-	"runtimes":           true,
-	"default-ulimits":    true,
+	"cluster-store-opts":    true,
+	"log-opts":              true,
+	"runtimes":              true,
+	"default-ulimits":       true,
+	"registry-host-mirrors": true,
 }
 
 // LogConfig represents the default log configuration.
@@ -133,6 +144,32 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// MaxDownloadBandwidth is the maximum number of bytes per second
+	// downloaded by all the pulls. Zero means no limit.
+	MaxDownloadBandwidth opts.MemBytes `json:"max-download-bandwidth,omitempty"`
+
+	// MaxUploadBandwidth is the maximum number of bytes per second
+	// uploaded by all the pushes. Zero means no limit.
+	MaxUploadBandwidth opts.MemBytes `json:"max-upload-bandwidth,omitempty"`
+
+	// EventsLogMaxFiles is the maximum number of files of the on-disk
+	// events journal. Zero disables the journal.
+	EventsLogMaxFiles *int `json:"events-log-max-files,omitempty"`
+
+	// EventsLogMaxSize is the maximum size of each file of the on-disk
+	// events journal.
+	EventsLogMaxSize opts.MemBytes `json:"events-log-max-size,omitempty"`
+
+	// ImageGCHighThreshold is the disk usage of the data-root, in percent,
+	// above which the least recently used images are deleted. Zero
+	// disables the image garbage collection.
+	ImageGCHighThreshold int `json:"image-gc-high-threshold,omitempty"`
+
+	// ImageGCLowThreshold is the disk usage of the data-root, in percent,
+	// the image garbage collection stops at. Zero means
+	// DefaultImageGCThresholdGap below the high threshold.
+	ImageGCLowThreshold int `json:"image-gc-low-threshold,omitempty"`
+
 	// ShutdownTimeout is the timeout value (in seconds) the daemon will wait for the container
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
@@ -154,6 +191,11 @@ type CommonConfig struct {
 	SwarmDefaultAdvertiseAddr string `json:"swarm-default-advertise-addr"`
 	MetricsAddress            string `json:"metrics-addr"`
 
+	// MetricsContainerDimensions are the labels of the per-container
+	// metrics: "id", "name", "image" or "label=<key>" for the value of a
+	// container label. They control the cardinality of the metrics.
+	MetricsContainerDimensions []string `json:"metrics-container-dimensions,omitempty"`
+
 	LogConfig
 	BridgeConfig // bridgeConfig holds bridge network specific configuration.
 	registry.ServiceOptions
@@ -453,6 +495,19 @@ func findConfigurationConflicts(config map[string]interface{}, flags *pflag.Flag
 	return nil
 }
 
+// ImageGCThresholds returns the disk usage, in percent, above which the image
+// garbage collection starts deleting images, and the one it stops at.
+func (config *Config) ImageGCThresholds() (high, low int) {
+	high, low = config.ImageGCHighThreshold, config.ImageGCLowThreshold
+	if low == 0 {
+		low = high - DefaultImageGCThresholdGap
+		if low < 0 {
+			low = 0
+		}
+	}
+	return high, low
+}
+
 // Validate validates some specific configs.
 // such as config.DNS, config.Labels, config.DNSSearch,
 // as well as config.MaxConcurrentDownloads, config.MaxConcurrentUploads.
@@ -485,6 +540,41 @@ func Validate(config *Config) error {
 	if config.MaxConcurrentUploads != nil && *config.MaxConcurrentUploads < 0 {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
+	// validate MaxDownloadBandwidth
+	if config.MaxDownloadBandwidth < 0 {
+		return fmt.Errorf("invalid max download bandwidth: %d", config.MaxDownloadBandwidth)
+	}
+	// validate MaxUploadBandwidth
+	if config.MaxUploadBandwidth < 0 {
+		return fmt.Errorf("invalid max upload bandwidth: %d", config.MaxUploadBandwidth)
+	}
+	// validate EventsLogMaxFiles
+	if config.EventsLogMaxFiles != nil && *config.EventsLogMaxFiles < 0 {
+		return fmt.Errorf("invalid events log max files: %d", *config.EventsLogMaxFiles)
+	}
+	// validate EventsLogMaxSize
+	if config.EventsLogMaxSize < 0 {
+		return fmt.Errorf("invalid events log max size: %d", config.EventsLogMaxSize)
+	}
+	// validate ImageGCHighThreshold and ImageGCLowThreshold
+	if config.ImageGCHighThreshold < 0 || config.ImageGCHighThreshold > 100 {
+		return fmt.Errorf("invalid image GC high threshold: %d", config.ImageGCHighThreshold)
+	}
+	if config.ImageGCLowThreshold < 0 || config.ImageGCLowThreshold > 100 {
+		return fmt.Errorf("invalid image GC low threshold: %d", config.ImageGCLowThreshold)
+	}
+	if config.ImageGCHighThreshold > 0 && config.ImageGCLowThreshold > 0 && config.ImageGCLowThreshold >= config.ImageGCHighThreshold {
+		return fmt.Errorf("image GC low threshold (%d) must be lower than the high threshold (%d)", config.ImageGCLowThreshold, config.ImageGCHighThreshold)
+	}
+	// validate MetricsContainerDimensions
+	if err := ValidateMetricsContainerDimensions(config.MetricsContainerDimensions); err != nil {
+		return err
+	}
+
+	// validate HostMirrors
+	if _, err := registry.ValidateHostMirrors(config.HostMirrors); err != nil {
+		return err
+	}
 
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
@@ -503,6 +593,56 @@ func Validate(config *Config) error {
 	return nil
 }
 
+// ValidateMetricsContainerDimension validates a dimension of the
+// per-container metrics.
+func ValidateMetricsContainerDimension(val string) (string, error) {
+	switch val {
+	case "id", "name", "image":
+		return val, nil
+	}
+	if strings.HasPrefix(val, "label=") && len(val) > len("label=") {
+		return val, nil
+	}
+	return "", fmt.Errorf("invalid container metrics dimension %q: must be one of id, name, image or label=<key>", val)
+}
+
+// ValidateMetricsContainerDimensions validates the dimensions of the
+// per-container metrics, which must be exported as distinct labels.
+func ValidateMetricsContainerDimensions(dimensions []string) error {
+	seen := make(map[string]string, len(dimensions))
+	for _, d := range dimensions {
+		if _, err := ValidateMetricsContainerDimension(d); err != nil {
+			return err
+		}
+		label := MetricsContainerDimensionLabel(d)
+		if prev, ok := seen[label]; ok {
+			return fmt.Errorf("container metrics dimensions %q and %q are both exported as label %s", prev, d, label)
+		}
+		seen[label] = d
+	}
+	return nil
+}
+
+// MetricsContainerDimensionLabel returns the name of the label a valid
+// dimension of the per-container metrics is exported as.
+func MetricsContainerDimensionLabel(d string) string {
+	switch d {
+	case "id":
+		return "container_id"
+	case "name":
+		return "container_name"
+	case "image":
+		return "image"
+	}
+	// the characters which are not allowed in the name of a label are replaced
+	return "container_label_" + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.TrimPrefix(d, "label="))
+}
+
 // GetAuthorizationPlugins returns daemon's sorted authorization plugins
 func (conf *Config) GetAuthorizationPlugins() []string {
 	conf.Lock()
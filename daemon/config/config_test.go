@@ -263,6 +263,42 @@ func TestValidateConfigurationErrors(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					MetricsContainerDimensions: []string{"name", "label="},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					MetricsContainerDimensions: []string{"command"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					MetricsContainerDimensions: []string{"label=com.example.team", "label=com_example.team"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCHighThreshold: 101,
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCHighThreshold: 70,
+					ImageGCLowThreshold:  80,
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -320,6 +356,28 @@ func TestValidateConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					MetricsContainerDimensions: []string{"id", "name", "image", "label=com.example.team"},
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCHighThreshold: 90,
+					ImageGCLowThreshold:  80,
+				},
+			},
+		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					ImageGCHighThreshold: 50,
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -329,6 +387,22 @@ func TestValidateConfiguration(t *testing.T) {
 	}
 }
 
+func TestImageGCThresholds(t *testing.T) {
+	config := &Config{CommonConfig: CommonConfig{ImageGCHighThreshold: 50}}
+	high, low := config.ImageGCThresholds()
+	assert.Equal(t, 50, high)
+	assert.Equal(t, 40, low)
+
+	config.ImageGCLowThreshold = 30
+	high, low = config.ImageGCThresholds()
+	assert.Equal(t, 50, high)
+	assert.Equal(t, 30, low)
+
+	config = &Config{CommonConfig: CommonConfig{ImageGCHighThreshold: 5}}
+	_, low = config.ImageGCThresholds()
+	assert.Equal(t, 0, low)
+}
+
 func TestModifiedDiscoverySettings(t *testing.T) {
 	cases := []struct {
 		current  *Config
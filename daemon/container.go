@@ -259,6 +259,10 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 			if config.Healthcheck.StartPeriod < 0 {
 				return nil, fmt.Errorf("StartPeriod in Healthcheck cannot be negative")
 			}
+
+			if err := validateHealthcheckTest(config.Healthcheck.Test); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -305,6 +309,24 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 	default:
 		return nil, fmt.Errorf("invalid restart policy '%s'", p.Name)
 	}
+	if p.InitialDelay < 0 {
+		return nil, fmt.Errorf("restart initial delay cannot be negative")
+	}
+	if p.MaxDelay < 0 {
+		return nil, fmt.Errorf("restart maximum delay cannot be negative")
+	}
+	if p.InitialDelay != 0 && p.MaxDelay != 0 && p.MaxDelay < p.InitialDelay {
+		return nil, fmt.Errorf("restart maximum delay cannot be less than the initial delay")
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return nil, fmt.Errorf("restart delay multiplier must be at least 1")
+	}
+	if p.ResetWindow < 0 {
+		return nil, fmt.Errorf("restart reset window cannot be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return nil, fmt.Errorf("restart jitter must be between 0 and 1")
+	}
 
 	// Now do platform-specific verification
 	return verifyPlatformContainerSettings(daemon, hostConfig, config, update)
@@ -168,7 +168,7 @@ func (daemon *Daemon) setupSecretDir(c *container.Container) (setupErr error) {
 	}
 
 	for _, s := range c.SecretReferences {
-		if c.SecretStore == nil {
+		if c.DependencyStore == nil {
 			return fmt.Errorf("secret store is not initialized")
 		}
 
@@ -192,7 +192,7 @@ func (daemon *Daemon) setupSecretDir(c *container.Container) (setupErr error) {
 			"name": s.File.Name,
 			"path": fPath,
 		}).Debug("injecting secret")
-		secret := c.SecretStore.Get(s.SecretID)
+		secret := c.DependencyStore.Secrets().Get(s.SecretID)
 		if secret == nil {
 			return fmt.Errorf("unable to get secret from secret store")
 		}
@@ -224,6 +224,82 @@ func (daemon *Daemon) setupSecretDir(c *container.Container) (setupErr error) {
 	return nil
 }
 
+func (daemon *Daemon) setupConfigDir(c *container.Container) (setupErr error) {
+	if len(c.ConfigReferences) == 0 {
+		return nil
+	}
+
+	localPath := c.ConfigsDirPath()
+	logrus.Debugf("configs: setting up config dir: %s", localPath)
+
+	defer func() {
+		if setupErr != nil {
+			if err := os.RemoveAll(localPath); err != nil {
+				logrus.Errorf("error cleaning up config dir: %s", err)
+			}
+		}
+	}()
+
+	// retrieve possible remapped range start for root UID, GID
+	rootUID, rootGID := daemon.GetRemappedUIDGID()
+	// create the config dir
+	if err := idtools.MkdirAllAs(localPath, 0700, rootUID, rootGID); err != nil {
+		return errors.Wrap(err, "error creating config dir")
+	}
+
+	for _, configRef := range c.ConfigReferences {
+		if c.DependencyStore == nil {
+			return fmt.Errorf("config store is not initialized")
+		}
+
+		// TODO: use type switch when more are supported
+		if configRef.File == nil {
+			return fmt.Errorf("config target type is not a file target")
+		}
+
+		fPath := c.ConfigFilePath(*configRef)
+
+		log := logrus.WithFields(logrus.Fields{"name": configRef.File.Name, "path": fPath})
+
+		log.Debug("injecting config")
+		config := c.DependencyStore.Configs().Get(configRef.ConfigID)
+		if config == nil {
+			return fmt.Errorf("unable to get config from config store")
+		}
+		if err := ioutil.WriteFile(fPath, config.Spec.Data, configRef.File.Mode); err != nil {
+			return errors.Wrap(err, "error injecting config")
+		}
+
+		uid, err := strconv.Atoi(configRef.File.UID)
+		if err != nil {
+			return err
+		}
+		gid, err := strconv.Atoi(configRef.File.GID)
+		if err != nil {
+			return err
+		}
+
+		// map the ids in the container to the host, with the remapped ranges
+		uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+		hostUID, err := idtools.ToHost(uid, uidMaps)
+		if err != nil {
+			return errors.Wrap(err, "error setting ownership for config")
+		}
+		hostGID, err := idtools.ToHost(gid, gidMaps)
+		if err != nil {
+			return errors.Wrap(err, "error setting ownership for config")
+		}
+
+		if err := os.Chown(fPath, hostUID, hostGID); err != nil {
+			return errors.Wrap(err, "error setting ownership for config")
+		}
+
+		label.Relabel(fPath, c.MountLabel, false)
+	}
+
+	return nil
+}
+
 func killProcessDirectly(container *container.Container) error {
 	if _, err := container.WaitStop(10 * time.Second); err != nil {
 		// Ensure that we don't kill ourselves
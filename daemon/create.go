@@ -85,6 +85,7 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 			return nil, errors.New("Platform on which parent image was created is not Solaris")
 		}
 		imgID = img.ID()
+		daemon.markImageUsed(imgID)
 	}
 
 	if err := daemon.mergeAndVerifyConfig(params.Config, img); err != nil {
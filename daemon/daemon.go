@@ -32,6 +32,7 @@ import (
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	"github.com/docker/docker/daemon/initlayer"
 	"github.com/docker/docker/daemon/stats"
+	"github.com/docker/docker/distribution"
 	dmetadata "github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/dockerversion"
@@ -59,6 +60,7 @@ import (
 	nwconfig "github.com/docker/libnetwork/config"
 	"github.com/docker/libtrust"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var (
@@ -75,14 +77,17 @@ type Daemon struct {
 	repository                string
 	containers                container.Store
 	execCommands              *exec.Store
+	imageGCStop               chan struct{}
 	referenceStore            refstore.Store
 	downloadManager           *xfer.LayerDownloadManager
 	uploadManager             *xfer.LayerUploadManager
 	distributionMetadataStore dmetadata.Store
+	downloadDir               string
 	trustKey                  libtrust.PrivateKey
 	idIndex                   *truncindex.TruncIndex
 	configStore               *config.Config
 	statsCollector            *stats.Collector
+	containerMetrics          *containerMetrics
 	defaultLogConfig          containertypes.LogConfig
 	RegistryService           registry.Service
 	EventsService             *events.Events
@@ -630,6 +635,8 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	d.downloadManager = xfer.NewLayerDownloadManager(d.layerStore, *config.MaxConcurrentDownloads)
 	logrus.Debugf("Max Concurrent Uploads: %d", *config.MaxConcurrentUploads)
 	d.uploadManager = xfer.NewLayerUploadManager(*config.MaxConcurrentUploads)
+	d.downloadManager.SetBandwidthLimit(config.MaxDownloadBandwidth.Value())
+	d.uploadManager.SetBandwidthLimit(config.MaxUploadBandwidth.Value())
 
 	ifs, err := image.NewFSStoreBackend(filepath.Join(imageRoot, "imagedb"))
 	if err != nil {
@@ -642,7 +649,7 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	}
 
 	// Configure the volumes driver
-	volStore, err := d.configureVolumes(rootUID, rootGID)
+	volStore, err := d.configureVolumes(uidMaps, gidMaps)
 	if err != nil {
 		return nil, err
 	}
@@ -663,7 +670,14 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 		return nil, err
 	}
 
-	eventsService := events.New()
+	// Partial downloads of the layers are kept to be resumed by a later
+	// pull, until they were not resumed for a week.
+	downloadDir := filepath.Join(imageRoot, "downloads")
+	if err := distribution.RemoveStaleDownloads(downloadDir, 7*24*time.Hour); err != nil {
+		logrus.Warnf("Failed to remove stale partial downloads: %v", err)
+	}
+
+	eventsService := newEventsService(config)
 
 	referenceStore, err := refstore.NewReferenceStore(filepath.Join(imageRoot, "repositories.json"))
 	if err != nil {
@@ -695,9 +709,17 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	d.execCommands = exec.NewStore()
 	d.referenceStore = referenceStore
 	d.distributionMetadataStore = distributionMetadataStore
+	d.downloadDir = downloadDir
 	d.trustKey = trustKey
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
+	d.containerMetrics, err = newContainerMetrics(d, config.MetricsContainerDimensions)
+	if err != nil {
+		return nil, err
+	}
+	if err := prometheus.Register(d.containerMetrics); err != nil {
+		logrus.Warnf("Failed to register the container metrics: %v", err)
+	}
 	d.defaultLogConfig = containertypes.LogConfig{
 		Type:   config.LogConfig.Type,
 		Config: config.LogConfig.Config,
@@ -715,6 +737,10 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	d.containerdRemote = containerdRemote
 
 	go d.execCommandGC()
+	if config.ImageGCHighThreshold > 0 {
+		d.imageGCStop = make(chan struct{})
+		go d.imageGC(d.imageGCStop)
+	}
 
 	d.containerd, err = containerdRemote.Client(d)
 	if err != nil {
@@ -809,6 +835,10 @@ func (daemon *Daemon) ShutdownTimeout() int {
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	if daemon.imageGCStop != nil {
+		close(daemon.imageGCStop)
+		daemon.imageGCStop = nil
+	}
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -867,6 +897,12 @@ func (daemon *Daemon) Shutdown() error {
 		return err
 	}
 
+	if daemon.EventsService != nil {
+		if err := daemon.EventsService.Close(); err != nil {
+			logrus.Errorf("Failed to close events journal: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -986,8 +1022,8 @@ func setDefaultMtu(conf *config.Config) {
 	conf.Mtu = config.DefaultNetworkMtu
 }
 
-func (daemon *Daemon) configureVolumes(rootUID, rootGID int) (*store.VolumeStore, error) {
-	volumesDriver, err := local.New(daemon.configStore.Root, rootUID, rootGID)
+func (daemon *Daemon) configureVolumes(uidMaps, gidMaps []idtools.IDMap) (*store.VolumeStore, error) {
+	volumesDriver, err := local.New(daemon.configStore.Root, uidMaps, gidMaps)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,43 @@
+package daemon
+
+import (
+	"github.com/docker/swarmkit/agent/exec"
+	swarmapi "github.com/docker/swarmkit/api"
+)
+
+// SetContainerDependencyStore sets the dependency store backend for the container
+func (daemon *Daemon) SetContainerDependencyStore(name string, store exec.DependencyGetter) error {
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	c.DependencyStore = store
+
+	return nil
+}
+
+// SetContainerSecretStore sets the secret store backend for the container,
+// for containers which don't use any other dependency, like configs.
+func (daemon *Daemon) SetContainerSecretStore(name string, store exec.SecretGetter) error {
+	return daemon.SetContainerDependencyStore(name, secretStore{secrets: store})
+}
+
+// secretStore is a dependency store serving secrets only.
+type secretStore struct {
+	secrets exec.SecretGetter
+}
+
+func (s secretStore) Secrets() exec.SecretGetter {
+	return s.secrets
+}
+
+func (s secretStore) Configs() exec.ConfigGetter {
+	return noConfigs{}
+}
+
+type noConfigs struct{}
+
+func (noConfigs) Get(configID string) *swarmapi.Config {
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/layer"
-	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/volume"
 	"github.com/opencontainers/go-digest"
 )
@@ -53,16 +52,8 @@ func (daemon *Daemon) SystemDiskUsage() (*types.DiskUsage, error) {
 	// Get all local volumes
 	allVolumes := []*types.Volume{}
 	getLocalVols := func(v volume.Volume) error {
-		name := v.Name()
-		refs := daemon.volumes.Refs(v)
-
 		tv := volumeToAPIType(v)
-		sz, err := directory.Size(v.Path())
-		if err != nil {
-			logrus.Warnf("failed to determine size of volume %v", name)
-			sz = -1
-		}
-		tv.UsageData = &types.VolumeUsageData{Size: sz, RefCount: int64(len(refs))}
+		tv.UsageData = daemon.volumeUsage(v, false)
 		allVolumes = append(allVolumes, tv)
 
 		return nil
@@ -96,5 +87,6 @@ func (daemon *Daemon) SystemDiskUsage() (*types.DiskUsage, error) {
 		Containers: allContainers,
 		Volumes:    allVolumes,
 		Images:     allImages,
+		BuildCache: daemon.buildCache(),
 	}, nil
 }
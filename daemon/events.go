@@ -1,16 +1,37 @@
 package daemon
 
 import (
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
 	daemonevents "github.com/docker/docker/daemon/events"
 	"github.com/docker/libnetwork"
 )
 
+// newEventsService creates the events service of the daemon. Events are
+// recorded in a journal under the daemon root, unless it has been disabled.
+func newEventsService(conf *config.Config) *daemonevents.Events {
+	if conf.EventsLogMaxFiles == nil || *conf.EventsLogMaxFiles == 0 {
+		return daemonevents.New()
+	}
+	maxSize := conf.EventsLogMaxSize.Value()
+	if maxSize <= 0 {
+		maxSize = config.DefaultEventsLogMaxSize
+	}
+	journal, err := daemonevents.NewJournal(filepath.Join(conf.Root, "events"), maxSize, *conf.EventsLogMaxFiles)
+	if err != nil {
+		logrus.Warnf("Failed to open events journal, events will only be kept in memory: %v", err)
+		return daemonevents.New()
+	}
+	return daemonevents.NewWithJournal(journal)
+}
+
 // LogContainerEvent generates an event related to a container with only the default attributes.
 func (daemon *Daemon) LogContainerEvent(container *container.Container, action string) {
 	daemon.LogContainerEventWithAttributes(container, action, map[string]string{})
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/pkg/pubsub"
 )
@@ -15,9 +16,20 @@ const (
 
 // Events is pubsub channel for events generated by the engine.
 type Events struct {
-	mu     sync.Mutex
-	events []eventtypes.Message
-	pub    *pubsub.Publisher
+	mu      sync.Mutex
+	events  []eventtypes.Message
+	pub     *pubsub.Publisher
+	journal *Journal
+	// queue feeds the goroutine writing events to the journal, so that
+	// logging an event does not wait for the disk. queued counts the events
+	// sent on the queue, and written the ones the goroutine is done with.
+	queue       chan eventtypes.Message
+	queued      uint64
+	journalDone chan struct{}
+
+	writtenMu   sync.Mutex
+	writtenCond *sync.Cond
+	written     uint64
 }
 
 // New returns new *Events instance
@@ -28,6 +40,44 @@ func New() *Events {
 	}
 }
 
+// NewWithJournal returns new *Events instance which records events in the
+// journal, and replays them from it to subscribers asking for events older
+// than the ones buffered in memory.
+func NewWithJournal(journal *Journal) *Events {
+	e := New()
+	e.journal = journal
+	e.queue = make(chan eventtypes.Message, bufferSize)
+	e.journalDone = make(chan struct{})
+	e.writtenCond = sync.NewCond(&e.writtenMu)
+	go e.writeJournal(journal, e.queue, e.journalDone)
+	return e
+}
+
+// writeJournal writes the events of the queue to the journal, until the
+// queue is closed.
+func (e *Events) writeJournal(journal *Journal, queue <-chan eventtypes.Message, done chan<- struct{}) {
+	defer close(done)
+	for m := range queue {
+		if err := journal.Write(m); err != nil {
+			logrus.Warnf("Failed to write event to journal: %v", err)
+		}
+		e.writtenMu.Lock()
+		e.written++
+		e.writtenMu.Unlock()
+		e.writtenCond.Broadcast()
+	}
+}
+
+// waitWritten waits until the first n events sent on the queue have been
+// written to the journal.
+func (e *Events) waitWritten(n uint64) {
+	e.writtenMu.Lock()
+	for e.written < n {
+		e.writtenCond.Wait()
+	}
+	e.writtenMu.Unlock()
+}
+
 // Subscribe adds new listener to events, returns slice of 64 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
@@ -46,9 +96,11 @@ func (e *Events) Subscribe() ([]eventtypes.Message, chan interface{}, func()) {
 	return current, l, cancel
 }
 
-// SubscribeTopic adds new listener to events, returns slice of 64 stored
-// last events, a channel in which you can expect new events (in form
-// of interface{}, so you need type assertion).
+// SubscribeTopic adds new listener to events, returns slice of stored
+// events emitted between since and until, a channel in which you can
+// expect new events (in form of interface{}, so you need type assertion).
+// Events older than the 64 last ones are replayed from the journal, if any,
+// when since is set.
 func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtypes.Message, chan interface{}) {
 	eventSubscribers.Inc()
 	e.mu.Lock()
@@ -60,6 +112,15 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 
 	buffered := e.loadBufferedEvents(since, until, topic)
 
+	// the journal holds every event logged before the oldest buffered one
+	before := time.Now().UTC().UnixNano()
+	if len(e.events) > 0 {
+		before = e.events[0].TimeNano
+	}
+	// the events queued so far must be on disk before the journal is read,
+	// or the ones which already left the buffer would be missed
+	journal, queued := e.journal, e.queued
+
 	var ch chan interface{}
 	if topic != nil {
 		ch = e.pub.SubscribeTopic(topic)
@@ -69,6 +130,15 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 	}
 
 	e.mu.Unlock()
+
+	if journal != nil && !since.IsZero() && since.UnixNano() < before {
+		e.waitWritten(queued)
+		journaled, err := loadJournaledEvents(journal, since, until, before, topic)
+		if err != nil {
+			logrus.Warnf("Failed to replay events from journal: %v", err)
+		}
+		buffered = append(journaled, buffered...)
+	}
 	return buffered, ch
 }
 
@@ -82,13 +152,10 @@ func (e *Events) Evict(l chan interface{}) {
 // receive the event or it will be skipped.
 func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	eventsCounter.Inc()
-	now := time.Now().UTC()
 	jm := eventtypes.Message{
-		Action:   action,
-		Type:     eventType,
-		Actor:    actor,
-		Time:     now.Unix(),
-		TimeNano: now.UnixNano(),
+		Action: action,
+		Type:   eventType,
+		Actor:  actor,
 	}
 
 	// fill deprecated fields for container and images
@@ -103,6 +170,21 @@ func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	}
 
 	e.mu.Lock()
+	// timestamped under the lock, so that the journal is in time order
+	now := time.Now().UTC()
+	jm.Time = now.Unix()
+	jm.TimeNano = now.UnixNano()
+	if e.queue != nil {
+		// sent under the lock to keep the journal in the order of the
+		// buffer, but without waiting: when the journal cannot keep up, the
+		// event is only buffered in memory
+		select {
+		case e.queue <- jm:
+			e.queued++
+		default:
+			journalDropped.Inc()
+		}
+	}
 	if len(e.events) == cap(e.events) {
 		// discard oldest event
 		copy(e.events, e.events[1:])
@@ -114,6 +196,23 @@ func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	e.pub.Publish(jm)
 }
 
+// Close writes the pending events to the journal, if any, and closes it.
+// Events logged afterwards are only buffered in memory.
+func (e *Events) Close() error {
+	e.mu.Lock()
+	journal, done := e.journal, e.journalDone
+	if journal == nil {
+		e.mu.Unlock()
+		return nil
+	}
+	close(e.queue)
+	e.journal, e.queue, e.journalDone = nil, nil, nil
+	e.mu.Unlock()
+
+	<-done
+	return journal.Close()
+}
+
 // SubscribersCount returns number of event listeners
 func (e *Events) SubscribersCount() int {
 	return e.pub.Len()
@@ -156,3 +255,19 @@ func (e *Events) loadBufferedEvents(since, until time.Time, topic func(interface
 	}
 	return buffered
 }
+
+// loadJournaledEvents reads the events emitted between two specific dates,
+// and before the oldest buffered event, from the journal.
+func loadJournaledEvents(journal *Journal, since, until time.Time, before int64, topic func(interface{}) bool) ([]eventtypes.Message, error) {
+	var sinceNanoUnix int64
+	if !since.IsZero() {
+		sinceNanoUnix = since.UnixNano()
+	}
+
+	var untilNanoUnix int64
+	if !until.IsZero() {
+		untilNanoUnix = until.UnixNano()
+	}
+
+	return journal.Read(sinceNanoUnix, untilNanoUnix, before, topic)
+}
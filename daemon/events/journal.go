@@ -0,0 +1,212 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/daemon/logger/loggerutils"
+)
+
+const journalFileName = "events.log"
+
+// Journal is a size-bounded, rotated log of events stored on disk, so that
+// events can be replayed to subscribers after the in-memory buffer has been
+// overrun or the daemon has been restarted.
+type Journal struct {
+	mu       sync.Mutex
+	path     string
+	maxFiles int
+	w        *loggerutils.RotateFileWriter
+}
+
+// NewJournal opens (or creates) the events journal in the root directory.
+// Each file of the journal holds up to maxSize bytes, and at most maxFiles
+// files are kept.
+func NewJournal(root string, maxSize int64, maxFiles int) (*Journal, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	if maxFiles < 1 {
+		maxFiles = 1
+	}
+	path := filepath.Join(root, journalFileName)
+	w, err := loggerutils.NewRotateFileWriter(path, maxSize, maxFiles, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{
+		path:     path,
+		maxFiles: maxFiles,
+		w:        w,
+	}, nil
+}
+
+// Write appends the event to the journal.
+func (j *Journal) Write(m eventtypes.Message) error {
+	buf, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, err = j.w.Write(buf)
+	return err
+}
+
+// Read returns the events of the journal emitted between since and until
+// (expressed in nanoseconds, zero meaning unbounded) for which topic returns
+// true. Events emitted at or after before are not returned, so that callers
+// can complete the result with the events they buffered in memory. The
+// events are logged in time order, so each file is read from the first event
+// emitted at or after since, and reading stops at the first one after until.
+func (j *Journal) Read(since, until, before int64, topic func(interface{}) bool) ([]eventtypes.Message, error) {
+	files, err := j.openFiles()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	var messages []eventtypes.Message
+	for _, f := range files {
+		if since > 0 {
+			if err := seek(f, since); err != nil {
+				return nil, err
+			}
+		}
+		s := bufio.NewScanner(f)
+		s.Buffer(nil, 1024*1024)
+		for s.Scan() {
+			var m eventtypes.Message
+			if err := json.Unmarshal(s.Bytes(), &m); err != nil {
+				// a torn write, most likely after a crash of the daemon
+				logrus.Debugf("Skipping invalid entry in events journal %s: %v", f.Name(), err)
+				continue
+			}
+			if m.TimeNano >= before || (until > 0 && m.TimeNano > until) {
+				return messages, nil
+			}
+			if m.TimeNano < since {
+				continue
+			}
+			if topic == nil || topic(m) {
+				messages = append(messages, m)
+			}
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
+
+// seek positions f at the first event emitted at or after since, or at its
+// end if there is none, by a binary search over the offsets of the file.
+func seek(f *os.File, since int64) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	var searchErr error
+	off := sort.Search(int(fi.Size()), func(i int) bool {
+		_, t, ok, err := entryAfter(f, int64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return !ok || t >= since
+	})
+	if searchErr != nil {
+		return searchErr
+	}
+	start, _, _, err := entryAfter(f, int64(off))
+	if err != nil {
+		return err
+	}
+	_, err = f.Seek(start, io.SeekStart)
+	return err
+}
+
+// entryAfter returns the offset and the time of the first valid event of the
+// journal file starting at or after off. ok is false if there is none, in
+// which case the offset is the end of the file.
+func entryAfter(f *os.File, off int64) (start, timeNano int64, ok bool, err error) {
+	start = off
+	if off > 0 {
+		// off is in the middle of an entry unless it follows a newline
+		start--
+	}
+	r := bufio.NewReader(io.NewSectionReader(f, start, 1<<62))
+	if off > 0 {
+		skipped, err := r.ReadBytes('\n')
+		start += int64(len(skipped))
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return start, 0, false, err
+		}
+	}
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// a partial last line is a torn write, not an event
+			if err == io.EOF {
+				err = nil
+			}
+			return start + int64(len(line)), 0, false, err
+		}
+		var m eventtypes.Message
+		if json.Unmarshal(line, &m) == nil {
+			return start, m.TimeNano, true, nil
+		}
+		start += int64(len(line))
+	}
+}
+
+// openFiles opens the files of the journal from the oldest to the most
+// recent. The files are opened while holding the lock, so that a concurrent
+// rotation cannot cause events to be read twice or skipped.
+func (j *Journal) openFiles() ([]*os.File, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var files []*os.File
+	for i := j.maxFiles - 1; i >= 0; i-- {
+		name := j.path
+		if i > 0 {
+			name = name + "." + strconv.Itoa(i)
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			for _, f := range files {
+				f.Close()
+			}
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// Close closes the journal.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.w.Close()
+}
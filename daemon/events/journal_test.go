@@ -0,0 +1,263 @@
+package events
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestJournalReplayBeyondBuffer(t *testing.T) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	journal, err := NewJournal(root, 1024*1024, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewWithJournal(journal)
+	since := time.Now().Add(-time.Second)
+
+	total := eventsLimit * 2
+	for i := 0; i < total; i++ {
+		e.Log("action_"+strconv.Itoa(i), events.ContainerEventType, events.Actor{ID: "cont"})
+	}
+
+	buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+	defer e.Evict(l)
+	if len(buffered) != total {
+		t.Fatalf("Must replay %d events, got %d", total, len(buffered))
+	}
+	for i, ev := range buffered {
+		if expected := "action_" + strconv.Itoa(i); ev.Action != expected {
+			t.Fatalf("Expected event %d to be %s, got %s", i, expected, ev.Action)
+		}
+	}
+
+	// the journal is only replayed when since is set
+	buffered, l3 := e.SubscribeTopic(time.Time{}, time.Now(), nil)
+	defer e.Evict(l3)
+	if len(buffered) != eventsLimit {
+		t.Fatalf("Must only return the %d buffered events without since, got %d", eventsLimit, len(buffered))
+	}
+
+	// events survive a restart of the daemon
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	journal, err = NewJournal(root, 1024*1024, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e = NewWithJournal(journal)
+	defer e.Close()
+
+	buffered, l2 := e.SubscribeTopic(since, time.Time{}, nil)
+	defer e.Evict(l2)
+	if len(buffered) != total {
+		t.Fatalf("Must replay %d events after restart, got %d", total, len(buffered))
+	}
+}
+
+func TestJournalRotation(t *testing.T) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	journal, err := NewJournal(root, 512, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	for i := 0; i < 100; i++ {
+		if err := journal.Write(events.Message{Action: "action_" + strconv.Itoa(i), TimeNano: int64(i + 1)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, journalFileName+".2")); !os.IsNotExist(err) {
+		t.Fatalf("Journal must keep at most 2 files, got %v", err)
+	}
+
+	messages, err := journal.Read(0, 0, 101, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) == 0 || len(messages) == 100 {
+		t.Fatalf("Journal must have dropped the oldest events, got %d events", len(messages))
+	}
+	if last := messages[len(messages)-1].Action; last != "action_99" {
+		t.Fatalf("Last event must be action_99, got %s", last)
+	}
+
+	messages, err = journal.Read(0, 95, 98, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := messages[len(messages)-1].Action; last != "action_94" {
+		t.Fatalf("Last event must be action_94, got %s", last)
+	}
+}
+
+func TestJournalConcurrentReplay(t *testing.T) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	journal, err := NewJournal(root, 1024*1024, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewWithJournal(journal)
+	defer e.Close()
+	since := time.Now().Add(-time.Second)
+
+	total := eventsLimit * 4
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < total; i++ {
+			e.Log("action_"+strconv.Itoa(i), events.ContainerEventType, events.Actor{ID: "cont"})
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+			e.Evict(l)
+			if len(buffered) != total {
+				t.Fatalf("Must replay %d events, got %d", total, len(buffered))
+			}
+			return
+		default:
+		}
+		// every event logged so far is replayed, without gaps
+		buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+		e.Evict(l)
+		for i, ev := range buffered {
+			if expected := "action_" + strconv.Itoa(i); ev.Action != expected {
+				t.Fatalf("Expected event %d to be %s, got %s", i, expected, ev.Action)
+			}
+		}
+	}
+}
+
+func TestJournalReadSeeksSince(t *testing.T) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	journal, err := NewJournal(root, 1024*1024, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	for i := 0; i < 200; i++ {
+		if i == 120 {
+			// a torn write is skipped
+			if _, err := journal.w.Write([]byte(`{"Action":"torn`)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := journal.Write(events.Message{Action: "action_" + strconv.Itoa(i), TimeNano: int64(i + 1)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, since := range []int64{1, 50, 120, 121, 122, 200} {
+		messages, err := journal.Read(since, 0, 1000, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := 201 - since
+		if since <= 121 {
+			// the event following the torn write is lost with it
+			expected--
+		}
+		if int64(len(messages)) != expected {
+			t.Fatalf("Must read %d events since %d, got %d", expected, since, len(messages))
+		}
+		if since != 121 {
+			if first := messages[0].Action; first != "action_"+strconv.FormatInt(since-1, 10) {
+				t.Fatalf("First event since %d must be action_%d, got %s", since, since-1, first)
+			}
+		}
+	}
+
+	messages, err := journal.Read(150, 160, 1000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 11 {
+		t.Fatalf("Must read 11 events between 150 and 160, got %d", len(messages))
+	}
+
+	messages, err = journal.Read(1000, 0, 2000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("Must read no event after the last one, got %d", len(messages))
+	}
+}
+
+func TestJournalBlockedWriter(t *testing.T) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	journal, err := NewJournal(root, 1024*1024, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewWithJournal(journal)
+	defer e.Close()
+	since := time.Now().Add(-time.Second)
+
+	// block the goroutine writing to the journal, as a slow or full disk does
+	journal.mu.Lock()
+	total := bufferSize * 2
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < total; i++ {
+			e.Log("action_"+strconv.Itoa(i), events.ContainerEventType, events.Actor{ID: "cont"})
+		}
+		_, l := e.SubscribeTopic(time.Time{}, time.Time{}, nil)
+		e.Evict(l)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		journal.mu.Unlock()
+		t.Fatal("Logging events must not wait for the journal")
+	}
+	journal.mu.Unlock()
+
+	// the events which did not fit in the queue are not journaled
+	buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+	defer e.Evict(l)
+	if len(buffered) >= total || len(buffered) < bufferSize {
+		t.Fatalf("Must replay the queued and buffered events, got %d", len(buffered))
+	}
+	if last := buffered[len(buffered)-1].Action; last != "action_"+strconv.Itoa(total-1) {
+		t.Fatalf("Last event must be action_%d, got %s", total-1, last)
+	}
+}
@@ -5,11 +5,13 @@ import "github.com/docker/go-metrics"
 var (
 	eventsCounter    metrics.Counter
 	eventSubscribers metrics.Gauge
+	journalDropped   metrics.Counter
 )
 
 func init() {
 	ns := metrics.NewNamespace("engine", "daemon", nil)
 	eventsCounter = ns.NewCounter("events", "The number of events logged")
 	eventSubscribers = ns.NewGauge("events_subscribers", "The number of current subscribers to events", metrics.Total)
+	journalDropped = ns.NewCounter("events_journal_dropped", "The number of events not written to the journal because it could not keep up")
 	metrics.Register(ns)
 }
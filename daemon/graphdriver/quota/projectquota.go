@@ -52,6 +52,7 @@ import "C"
 import (
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -66,6 +67,12 @@ type Quota struct {
 	Size uint64
 }
 
+// Usage params - the space and the number of inodes used by a project
+type Usage struct {
+	Size   uint64
+	Inodes uint64
+}
+
 // Control - Context to be used by storage driver (e.g. overlay)
 // who wants to apply project quotas to container dirs
 type Control struct {
@@ -97,6 +104,15 @@ type Control struct {
 // project ids.
 //
 func NewControl(basePath string) (*Control, error) {
+	return NewControlWithOffset(basePath, 0)
+}
+
+// NewControlWithOffset - initialize project quota support like NewControl,
+// but with project ids starting offset ids after the project id of the home
+// directory. Users of project quotas on the same filesystem, such as the
+// storage driver and the local volumes, use different offsets, so that they
+// assign project ids from separate ranges.
+func NewControlWithOffset(basePath string, offset uint32) (*Control, error) {
 	//
 	// Get project id of parent dir as minimal id to be used by driver
 	//
@@ -104,7 +120,11 @@ func NewControl(basePath string) (*Control, error) {
 	if err != nil {
 		return nil, err
 	}
-	minProjectID++
+	// offset+1 is added to the project id of the parent dir
+	if offset == math.MaxUint32 || minProjectID > math.MaxUint32-offset-1 {
+		return nil, fmt.Errorf("project id %d of %s is too large for the offset %d", minProjectID, basePath, offset)
+	}
+	minProjectID += offset + 1
 
 	//
 	// create backing filesystem device node
@@ -150,15 +170,18 @@ func (q *Control) SetQuota(targetPath string, quota Quota) error {
 	projectID, ok := q.quotas[targetPath]
 	if !ok {
 		projectID = q.nextProjectID
+	}
 
-		//
-		// assign project id to new container directory
-		//
-		err := setProjectID(targetPath, projectID)
-		if err != nil {
-			return err
-		}
+	//
+	// assign project id to the directory, even if it was already assigned
+	// one, as the directory may have been re-created with the same path
+	//
+	err := setProjectID(targetPath, projectID)
+	if err != nil {
+		return err
+	}
 
+	if !ok {
 		q.quotas[targetPath] = projectID
 		q.nextProjectID++
 	}
@@ -206,21 +229,50 @@ func (q *Control) GetQuota(targetPath string, quota *Quota) error {
 	//
 	// get the quota limit for the container's project id
 	//
+	d, err := getProjectQuota(q.backingFsBlockDev, projectID)
+	if err != nil {
+		return err
+	}
+	quota.Size = uint64(d.d_blk_hardlimit) * 512
+
+	return nil
+}
+
+// GetUsage - get the space and the number of inodes used by a directory that
+// was configured with SetQuota, as accounted by its project quota
+func (q *Control) GetUsage(targetPath string, usage *Usage) error {
+
+	projectID, ok := q.quotas[targetPath]
+	if !ok {
+		return fmt.Errorf("quota not found for path : %s", targetPath)
+	}
+
+	d, err := getProjectQuota(q.backingFsBlockDev, projectID)
+	if err != nil {
+		return err
+	}
+	usage.Size = uint64(d.d_bcount) * 512
+	usage.Inodes = uint64(d.d_icount)
+
+	return nil
+}
+
+// getProjectQuota - get the quota limits and usage of project id on xfs block device
+func getProjectQuota(backingFsBlockDev string, projectID uint32) (C.fs_disk_quota_t, error) {
 	var d C.fs_disk_quota_t
 
-	var cs = C.CString(q.backingFsBlockDev)
+	var cs = C.CString(backingFsBlockDev)
 	defer C.free(unsafe.Pointer(cs))
 
 	_, _, errno := syscall.Syscall6(syscall.SYS_QUOTACTL, C.Q_XGETPQUOTA,
 		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
 		uintptr(unsafe.Pointer(&d)), 0, 0)
 	if errno != 0 {
-		return fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
-			projectID, q.backingFsBlockDev, errno.Error())
+		return d, fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
+			projectID, backingFsBlockDev, errno.Error())
 	}
-	quota.Size = uint64(d.d_blk_hardlimit) * 512
 
-	return nil
+	return d, nil
 }
 
 // getProjectID - get the project id of path on xfs
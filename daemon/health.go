@@ -2,8 +2,15 @@ package daemon
 
 import (
 	"bytes"
+	gocontext "context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -108,6 +115,132 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, container *container.Cont
 	}, nil
 }
 
+// dialFunc opens the connections of the HTTP and TCP probes. Its context is
+// the one of the standard library, as used by http.Transport.
+type dialFunc func(ctx gocontext.Context, network, address string) (net.Conn, error)
+
+// httpProbe implements the "HTTP" probe type.
+type httpProbe struct {
+	dial dialFunc
+}
+
+// GET the healthcheck URL from within the container's network namespace.
+// Any 2xx or 3xx response is considered healthy.
+func (p *httpProbe) run(ctx context.Context, d *Daemon, container *container.Container) (*types.HealthcheckResult, error) {
+	target := container.Config.Healthcheck.Test[1]
+	req, err := http.NewRequest("GET", target, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: p.dial,
+			// Like CMD probes, HTTPS probes only check that the service
+			// answers; certificates are commonly self-signed.
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		// like a refused TCP probe, a failed request makes the container
+		// unhealthy.
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	output := &limitedBuffer{}
+	fmt.Fprintf(output, "GET %s: %s\n", target, resp.Status)
+	if _, err := io.Copy(output, resp.Body); err != nil {
+		fmt.Fprintf(output, "\n%v", err)
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   output.String(),
+		}, nil
+	}
+
+	exitCode := exitStatusUnhealthy
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest {
+		exitCode = exitStatusHealthy
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Output:   output.String(),
+	}, nil
+}
+
+// tcpProbe implements the "TCP" probe type.
+type tcpProbe struct {
+	dial dialFunc
+}
+
+// Connect to the healthcheck address from within the container's network
+// namespace. The container is healthy if the connection is accepted.
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, container *container.Container) (*types.HealthcheckResult, error) {
+	address := tcpProbeAddress(container.Config.Healthcheck.Test[1])
+	conn, err := p.dial(ctx, "tcp", address)
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	conn.Close()
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   fmt.Sprintf("Connected to %s", address),
+	}, nil
+}
+
+// tcpProbeAddress returns the address to connect to for a TCP probe. A bare
+// port is relative to the container's loopback interface.
+func tcpProbeAddress(address string) string {
+	if !strings.Contains(address, ":") {
+		return net.JoinHostPort("127.0.0.1", address)
+	}
+	if strings.HasPrefix(address, ":") {
+		return "127.0.0.1" + address
+	}
+	return address
+}
+
+// validateHealthcheckTest checks the argument of the HTTP and TCP probe types.
+func validateHealthcheckTest(test []string) error {
+	if len(test) == 0 {
+		return nil
+	}
+	switch test[0] {
+	case "HTTP":
+		if len(test) != 2 {
+			return fmt.Errorf("HTTP in Healthcheck requires exactly one URL")
+		}
+		u, err := url.Parse(test[1])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("HTTP in Healthcheck requires an http:// or https:// URL, got %q", test[1])
+		}
+	case "TCP":
+		if len(test) != 2 {
+			return fmt.Errorf("TCP in Healthcheck requires exactly one address")
+		}
+		_, port, err := net.SplitHostPort(tcpProbeAddress(test[1]))
+		if err == nil {
+			_, err = strconv.ParseUint(port, 10, 16)
+		}
+		if err != nil {
+			return fmt.Errorf("TCP in Healthcheck requires a [host:]port address, got %q", test[1])
+		}
+	}
+	return nil
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
 	c.Lock()
@@ -241,8 +374,20 @@ func getProbe(c *container.Container) probe {
 		return &cmdProbe{shell: false}
 	case "CMD-SHELL":
 		return &cmdProbe{shell: true}
+	case "HTTP":
+		if len(config.Test) != 2 {
+			logrus.Warnf("Healthcheck type 'HTTP' expects a single URL in container %s", c.ID)
+			return nil
+		}
+		return &httpProbe{dial: (&containerDialer{c: c}).DialContext}
+	case "TCP":
+		if len(config.Test) != 2 {
+			logrus.Warnf("Healthcheck type 'TCP' expects a single address in container %s", c.ID)
+			return nil
+		}
+		return &tcpProbe{dial: (&containerDialer{c: c}).DialContext}
 	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
+		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD', 'HTTP' or 'TCP') in container %s", config.Test[0], c.ID)
 		return nil
 	}
 }
@@ -0,0 +1,148 @@
+// +build linux
+
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork/resolvconf"
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netns"
+)
+
+// containerDialer opens connections from within the network namespace of a
+// container, so that probes can reach services that only listen on the
+// container's loopback interface.
+type containerDialer struct {
+	c *container.Container
+}
+
+// DialContext connects to the address on the named network from within the
+// network namespace of the container. Host names are resolved like in the
+// container: with its hosts file, then with its nameservers.
+func (d *containerDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) == nil {
+		ip, err := d.lookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		address = net.JoinHostPort(ip, port)
+	}
+	return d.dial(ctx, network, address)
+}
+
+// dial connects to the address, which must not contain a host name, from
+// within the network namespace of the container.
+func (d *containerDialer) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	c := d.c
+	pid := c.GetPID()
+	if pid == 0 {
+		return nil, fmt.Errorf("container %s is not running", c.ID)
+	}
+
+	runtime.LockOSThread()
+
+	origns, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return nil, fmt.Errorf("failed to get current network namespace: %v", err)
+	}
+	defer origns.Close()
+
+	ns, err := netns.GetFromPid(pid)
+	if err != nil {
+		runtime.UnlockOSThread()
+		return nil, fmt.Errorf("failed to get network namespace of container %s: %v", c.ID, err)
+	}
+	defer ns.Close()
+
+	if err := netns.Set(ns); err != nil {
+		runtime.UnlockOSThread()
+		return nil, fmt.Errorf("failed to enter network namespace of container %s: %v", c.ID, err)
+	}
+	defer func() {
+		if err := netns.Set(origns); err != nil {
+			// Leave the thread locked, so that it is terminated with the
+			// goroutine instead of running other goroutines in the
+			// network namespace of the container.
+			logrus.Errorf("failed to restore network namespace after health check of container %s: %v", c.ID, err)
+			return
+		}
+		runtime.UnlockOSThread()
+	}()
+
+	// The socket is bound to the namespace it is created in, so make sure
+	// it is created on this thread by not racing several addresses.
+	dialer := net.Dialer{FallbackDelay: -1}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// lookupHost returns an address of the host, as resolved in the container.
+func (d *containerDialer) lookupHost(ctx context.Context, host string) (string, error) {
+	if ip := lookupHostsFile(d.c.HostsPath, host); ip != "" {
+		return ip, nil
+	}
+
+	content, err := resolvconf.GetSpecific(d.c.ResolvConfPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read resolv.conf of container %s: %v", d.c.ID, err)
+	}
+	nameservers := resolvconf.GetNameservers(content.Content, types.IP)
+	if len(nameservers) == 0 {
+		return "", fmt.Errorf("no nameserver to resolve %s in container %s", host, d.c.ID)
+	}
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return d.dial(ctx, network, net.JoinHostPort(nameservers[0], "53"))
+		},
+	}
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	return addrs[0], nil
+}
+
+// lookupHostsFile returns the address of the host in the hosts file at path,
+// or an empty string if it is not found.
+func lookupHostsFile(path, host string) string {
+	if path == "" {
+		return ""
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		for _, name := range fields[1:] {
+			if strings.EqualFold(name, host) {
+				return fields[0]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+// +build linux
+
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLookupHostsFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "hosts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("# comment 10.0.0.9 commented\n127.0.0.1\tlocalhost\n10.0.0.2\tweb web.local # alias\n")
+	f.Close()
+
+	for host, expected := range map[string]string{
+		"localhost": "127.0.0.1",
+		"web.local": "10.0.0.2",
+		"WEB":       "10.0.0.2",
+		"commented": "",
+		"db":        "",
+	} {
+		if actual := lookupHostsFile(f.Name(), host); actual != expected {
+			t.Errorf("Expecting %q for %q, got %q", expected, host, actual)
+		}
+	}
+}
@@ -1,9 +1,15 @@
 package daemon
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"golang.org/x/net/context"
+
 	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
 	eventtypes "github.com/docker/docker/api/types/events"
@@ -143,3 +149,126 @@ func TestHealthStates(t *testing.T) {
 		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
 	}
 }
+
+func TestValidateHealthcheckTest(t *testing.T) {
+	valid := [][]string{
+		{"CMD", "true"},
+		{"HTTP", "http://localhost:8080/health"},
+		{"HTTP", "https://127.0.0.1/"},
+		{"TCP", "5432"},
+		{"TCP", ":5432"},
+		{"TCP", "127.0.0.1:5432"},
+	}
+	for _, test := range valid {
+		if err := validateHealthcheckTest(test); err != nil {
+			t.Errorf("Expecting %v to be valid, got %v", test, err)
+		}
+	}
+
+	invalid := [][]string{
+		{"HTTP"},
+		{"HTTP", "localhost:8080/health"},
+		{"HTTP", "ftp://localhost/"},
+		{"TCP", "http"},
+		{"TCP", "127.0.0.1:99999"},
+		{"TCP", "1", "2"},
+	}
+	for _, test := range invalid {
+		if err := validateHealthcheckTest(test); err == nil {
+			t.Errorf("Expecting %v to be invalid", test)
+		}
+	}
+}
+
+func TestTCPProbeAddress(t *testing.T) {
+	for address, expected := range map[string]string{
+		"5432":          "127.0.0.1:5432",
+		":5432":         "127.0.0.1:5432",
+		"10.0.0.1:5432": "10.0.0.1:5432",
+	} {
+		if actual := tcpProbeAddress(address); actual != expected {
+			t.Errorf("Expecting %q for %q, got %q", expected, address, actual)
+		}
+	}
+}
+
+func probeContainer(test ...string) *container.Container {
+	return &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID: "container_id",
+			Config: &containertypes.Config{
+				Healthcheck: &containertypes.HealthConfig{Test: test},
+			},
+		},
+	}
+}
+
+func TestHTTPProbe(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "fail", http.StatusServiceUnavailable)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// closed listener, to make the connection fail
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedURL := "http://" + l.Addr().String() + "/"
+	l.Close()
+
+	probe := &httpProbe{dial: (&net.Dialer{}).DialContext}
+	for url, expected := range map[string]int{
+		server.URL + "/ok":   exitStatusHealthy,
+		server.URL + "/fail": exitStatusUnhealthy,
+		closedURL:            exitStatusUnhealthy,
+	} {
+		result, err := probe.run(context.Background(), nil, probeContainer("HTTP", url))
+		if err != nil {
+			t.Fatalf("Expecting a probe result for %s, got %v", url, err)
+		}
+		if result.ExitCode != expected {
+			t.Errorf("Expecting exit code %d for %s, got %d: %s", expected, url, result.ExitCode, result.Output)
+		}
+	}
+}
+
+func TestTCPProbe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	probe := &tcpProbe{dial: (&net.Dialer{}).DialContext}
+	result, err := probe.run(context.Background(), nil, probeContainer("TCP", address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ExitCode != exitStatusHealthy {
+		t.Errorf("Expecting a healthy result, got %d: %s", result.ExitCode, result.Output)
+	}
+
+	l.Close()
+	result, err = probe.run(context.Background(), nil, probeContainer("TCP", address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ExitCode != exitStatusUnhealthy {
+		t.Errorf("Expecting an unhealthy result after closing the listener, got %d: %s", result.ExitCode, result.Output)
+	}
+}
@@ -0,0 +1,19 @@
+// +build !linux
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/docker/docker/container"
+)
+
+type containerDialer struct {
+	c *container.Container
+}
+
+func (d *containerDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return nil, fmt.Errorf("network health checks are not supported on this platform")
+}
@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/image"
+	"github.com/opencontainers/go-digest"
+)
+
+// imageGCInterval is the interval at which the disk usage of the data-root
+// is checked against the image GC thresholds.
+const imageGCInterval = time.Minute
+
+// imageGCCandidate is an image the image GC can delete.
+type imageGCCandidate struct {
+	id       image.ID
+	lastUsed time.Time
+}
+
+type byLastUsed []imageGCCandidate
+
+func (r byLastUsed) Len() int           { return len(r) }
+func (r byLastUsed) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byLastUsed) Less(i, j int) bool { return r[i].lastUsed.Before(r[j].lastUsed) }
+
+// markImageUsed records that a container was created or started from the
+// image, or that the builder used it as cache, for the image GC and the build
+// cache prune to delete the least recently used images first.
+func (daemon *Daemon) markImageUsed(id image.ID) {
+	if id == "" {
+		return
+	}
+	if err := daemon.imageStore.SetLastUsed(id, time.Now()); err != nil {
+		logrus.Warnf("failed to set last used time of image %s: %v", id, err)
+	}
+}
+
+// imageGC periodically deletes the least recently used images when the disk
+// usage of the data-root is above the high threshold, until stop is closed.
+func (daemon *Daemon) imageGC(stop <-chan struct{}) {
+	ticker := time.NewTicker(imageGCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+		if err := daemon.collectImages(daemon.configStore.ImageGCThresholds()); err != nil {
+			logrus.Warnf("image garbage collection failed: %v", err)
+		}
+	}
+}
+
+// collectImages deletes the least recently used images which are not used
+// by any container, if the disk usage of the data-root is above high
+// percent, until it is down to low percent. The deletions are logged as
+// untag and delete image events.
+func (daemon *Daemon) collectImages(high, low int) error {
+	usage, err := diskUsage(daemon.configStore.Root)
+	if err != nil {
+		return err
+	}
+	if usage < high {
+		return nil
+	}
+	logrus.Infof("disk usage of %s is %d%%, deleting unused images down to %d%%", daemon.configStore.Root, usage, low)
+
+	// Images which fail to be deleted are skipped on the next attempts,
+	// for the collection to move on to the next candidates.
+	failed := make(map[image.ID]bool)
+	for usage > low {
+		var candidate *imageGCCandidate
+		candidates := daemon.imageGCCandidates()
+		for i := range candidates {
+			if !failed[candidates[i].id] {
+				candidate = &candidates[i]
+				break
+			}
+		}
+		if candidate == nil {
+			logrus.Warnf("no unused image left to delete, disk usage of %s is %d%%", daemon.configStore.Root, usage)
+			return nil
+		}
+		if err := daemon.deleteUnusedImage(candidate.id); err != nil {
+			logrus.Warnf("image garbage collection could not delete %s: %v", candidate.id, err)
+			failed[candidate.id] = true
+			continue
+		}
+		logrus.Infof("image garbage collection deleted %s, last used %s", candidate.id, candidate.lastUsed.Format(time.RFC3339))
+
+		if usage, err = diskUsage(daemon.configStore.Root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageGCCandidates returns the images which are not used by any container,
+// from the least to the most recently used. Intermediate images are left out
+// as they are deleted with the images built on top of them, as are the images
+// which other images are built on top of.
+func (daemon *Daemon) imageGCCandidates() []imageGCCandidate {
+	used := make(map[image.ID]bool)
+	for _, c := range daemon.List() {
+		used[c.ImageID] = true
+	}
+
+	var candidates []imageGCCandidate
+	for id, img := range daemon.imageStore.Heads() {
+		if used[id] {
+			continue
+		}
+		lastUsed, err := daemon.imageStore.GetLastUsed(id)
+		if err != nil {
+			// The image was created before the last used time was
+			// recorded.
+			lastUsed = img.Created
+		}
+		candidates = append(candidates, imageGCCandidate{id: id, lastUsed: lastUsed})
+	}
+	sort.Sort(byLastUsed(candidates))
+	return candidates
+}
+
+// deleteUnusedImage deletes an image with all its references.
+func (daemon *Daemon) deleteUnusedImage(id image.ID) error {
+	refs := daemon.referenceStore.References(digest.Digest(id))
+	if len(refs) == 0 {
+		_, err := daemon.ImageDelete(id.String(), false, true)
+		return err
+	}
+	for _, ref := range refs {
+		if _, err := daemon.ImageDelete(ref.String(), false, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/image"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageGCCandidates(t *testing.T) {
+	d, cleanup := newDaemonWithTmpRoot(t)
+	defer cleanup()
+
+	fs, err := image.NewFSStoreBackend(d.root)
+	require.NoError(t, err)
+	d.imageStore, err = image.NewImageStore(fs, nil)
+	require.NoError(t, err)
+
+	var ids []image.ID
+	for i := 0; i < 5; i++ {
+		id, err := d.imageStore.Create([]byte(fmt.Sprintf(`{"comment": "image %d", "rootfs": {"type": "layers"}}`, i)))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// ids[0] was used last, ids[4] first.
+	now := time.Now()
+	for i, id := range ids {
+		require.NoError(t, d.imageStore.SetLastUsed(id, now.Add(-time.Duration(i)*time.Hour)))
+	}
+	// ids[1] is used by a container.
+	c := &container.Container{CommonContainer: container.CommonContainer{ID: "test", ImageID: ids[1], State: &container.State{}}}
+	d.containers.Add(c.ID, c)
+	// ids[3] is the parent of ids[2].
+	require.NoError(t, d.imageStore.SetParent(ids[2], ids[3]))
+
+	var candidates []image.ID
+	for _, c := range d.imageGCCandidates() {
+		candidates = append(candidates, c.id)
+	}
+	assert.Equal(t, []image.ID{ids[4], ids[2], ids[0]}, candidates)
+}
@@ -0,0 +1,19 @@
+// +build linux freebsd
+
+package daemon
+
+import "syscall"
+
+// diskUsage returns the usage, in percent, of the filesystem holding path.
+func diskUsage(path string) (int, error) {
+	var buf syscall.Statfs_t
+	if err := syscall.Statfs(path, &buf); err != nil {
+		return 0, err
+	}
+	used := uint64(buf.Blocks) - uint64(buf.Bfree)
+	total := used + uint64(buf.Bavail)
+	if total == 0 {
+		return 0, nil
+	}
+	return int((used*100 + total - 1) / total), nil
+}
@@ -0,0 +1,10 @@
+// +build !linux,!freebsd
+
+package daemon
+
+import "errors"
+
+// diskUsage is not supported on this platform.
+func diskUsage(path string) (int, error) {
+	return 0, errors.New("disk usage is not supported on this platform")
+}
@@ -17,8 +17,9 @@ import (
 )
 
 // PullImage initiates a pull operation. image is the repository name to pull, and
-// tag may be either empty, or indicate a specific tag to pull.
-func (daemon *Daemon) PullImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// tag may be either empty, or indicate a specific tag to pull. The layers are
+// downloaded at maxBandwidth bytes per second at most, unless it is zero.
+func (daemon *Daemon) PullImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, maxBandwidth int64, outStream io.Writer) error {
 	// Special case: "pull -a" may send an image name with a
 	// trailing :. This is ugly, but let's not break API
 	// compatibility.
@@ -43,7 +44,7 @@ func (daemon *Daemon) PullImage(ctx context.Context, image, tag string, metaHead
 		}
 	}
 
-	return daemon.pullImageWithReference(ctx, ref, metaHeaders, authConfig, outStream)
+	return daemon.pullImageWithReference(ctx, ref, metaHeaders, authConfig, maxBandwidth, outStream)
 }
 
 // PullOnBuild tells Docker to pull image referenced by `name`.
@@ -54,28 +55,38 @@ func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs
 	}
 	ref = reference.TagNameOnly(ref)
 
-	pullRegistryAuth := &types.AuthConfig{}
-	if len(authConfigs) > 0 {
-		// The request came with a full auth config file, we prefer to use that
-		repoInfo, err := daemon.RegistryService.ResolveRepository(ref)
-		if err != nil {
-			return nil, err
-		}
-
-		resolvedConfig := registry.ResolveAuthConfig(
-			authConfigs,
-			repoInfo.Index,
-		)
-		pullRegistryAuth = &resolvedConfig
+	pullRegistryAuth, err := daemon.resolveBuildAuthConfig(ref, authConfigs)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := daemon.pullImageWithReference(ctx, ref, nil, pullRegistryAuth, output); err != nil {
+	if err := daemon.pullImageWithReference(ctx, ref, nil, pullRegistryAuth, 0, output); err != nil {
 		return nil, err
 	}
 	return daemon.GetImage(name)
 }
 
-func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.Named, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// resolveBuildAuthConfig returns the auth config of the registry of ref among
+// the auth configs sent with a build.
+func (daemon *Daemon) resolveBuildAuthConfig(ref reference.Named, authConfigs map[string]types.AuthConfig) (*types.AuthConfig, error) {
+	if len(authConfigs) == 0 {
+		return &types.AuthConfig{}, nil
+	}
+
+	// The request came with a full auth config file, we prefer to use that
+	repoInfo, err := daemon.RegistryService.ResolveRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedConfig := registry.ResolveAuthConfig(
+		authConfigs,
+		repoInfo.Index,
+	)
+	return &resolvedConfig, nil
+}
+
+func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.Named, metaHeaders map[string][]string, authConfig *types.AuthConfig, maxBandwidth int64, outStream io.Writer) error {
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -99,9 +110,11 @@ func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.
 			MetadataStore:    daemon.distributionMetadataStore,
 			ImageStore:       distribution.NewImageConfigStoreFromStore(daemon.imageStore),
 			ReferenceStore:   daemon.referenceStore,
+			MaxBandwidth:     maxBandwidth,
 		},
 		DownloadManager: daemon.downloadManager,
 		Schema2Types:    distribution.ImageTypes,
+		DownloadDir:     daemon.downloadDir,
 	}
 
 	err := distribution.Pull(ctx, ref, imagePullConfig)
@@ -110,6 +123,25 @@ func (daemon *Daemon) pullImageWithReference(ctx context.Context, ref reference.
 	return err
 }
 
+// pullImageConfig pulls the config of the image referenced by ref, without
+// its layers.
+func (daemon *Daemon) pullImageConfig(ctx context.Context, ref reference.Named, authConfig *types.AuthConfig) (*distribution.RemoteImage, error) {
+	imagePullConfig := &distribution.ImagePullConfig{
+		Config: distribution.Config{
+			AuthConfig:      authConfig,
+			ProgressOutput:  progress.DiscardOutput(),
+			RegistryService: daemon.RegistryService,
+			MetadataStore:   daemon.distributionMetadataStore,
+			ImageStore:      distribution.NewImageConfigStoreFromStore(daemon.imageStore),
+		},
+		DownloadManager: daemon.downloadManager,
+		Schema2Types:    distribution.ImageTypes,
+		DownloadDir:     daemon.downloadDir,
+	}
+
+	return distribution.PullImageConfig(ctx, ref, imagePullConfig)
+}
+
 // GetRepository returns a repository from the registry.
 func (daemon *Daemon) GetRepository(ctx context.Context, ref reference.NamedTagged, authConfig *types.AuthConfig) (dist.Repository, bool, error) {
 	// get repository info
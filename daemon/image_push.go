@@ -8,12 +8,15 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution"
 	progressutils "github.com/docker/docker/distribution/utils"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"golang.org/x/net/context"
 )
 
 // PushImage initiates a push operation on the repository named localName.
-func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// The layers are uploaded at maxBandwidth bytes per second at most, unless it
+// is zero.
+func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, layerCompression archive.Compression, maxBandwidth int64, outStream io.Writer) error {
 	ref, err := reference.ParseNormalizedNamed(image)
 	if err != nil {
 		return err
@@ -49,11 +52,13 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 			MetadataStore:    daemon.distributionMetadataStore,
 			ImageStore:       distribution.NewImageConfigStoreFromStore(daemon.imageStore),
 			ReferenceStore:   daemon.referenceStore,
+			MaxBandwidth:     maxBandwidth,
 		},
-		ConfigMediaType: schema2.MediaTypeImageConfig,
-		LayerStore:      distribution.NewLayerProviderFromStore(daemon.layerStore),
-		TrustKey:        daemon.trustKey,
-		UploadManager:   daemon.uploadManager,
+		ConfigMediaType:  schema2.MediaTypeImageConfig,
+		LayerStore:       distribution.NewLayerProviderFromStore(daemon.layerStore),
+		TrustKey:         daemon.trustKey,
+		UploadManager:    daemon.uploadManager,
+		LayerCompression: layerCompression,
 	}
 
 	err = distribution.Push(ctx, ref, imagePushConfig)
@@ -157,7 +157,9 @@ func (daemon *Daemon) Images(imageFilters filters.Args, all bool, withExtraAttrs
 			}
 		}
 		if newImage.RepoDigests == nil && newImage.RepoTags == nil {
-			if all || len(daemon.imageStore.Children(id)) == 0 {
+			// Untagged build cache is listed with the build cache in the
+			// disk usage rather than as dangling images.
+			if all || (len(daemon.imageStore.Children(id)) == 0 && !daemon.imageStore.IsBuildCache(id)) {
 
 				if imageFilters.Include("dangling") && !danglingOnly {
 					//dangling=false case, so dangling image is not needed
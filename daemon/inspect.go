@@ -145,18 +145,19 @@ func (daemon *Daemon) getInspectData(container *container.Container) (*types.Con
 	}
 
 	containerState := &types.ContainerState{
-		Status:     container.State.StateString(),
-		Running:    container.State.Running,
-		Paused:     container.State.Paused,
-		Restarting: container.State.Restarting,
-		OOMKilled:  container.State.OOMKilled,
-		Dead:       container.State.Dead,
-		Pid:        container.State.Pid,
-		ExitCode:   container.State.ExitCode(),
-		Error:      container.State.Error(),
-		StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
-		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
-		Health:     containerHealth,
+		Status:       container.State.StateString(),
+		Running:      container.State.Running,
+		Paused:       container.State.Paused,
+		Restarting:   container.State.Restarting,
+		CrashLooping: container.State.CrashLooping,
+		OOMKilled:    container.State.OOMKilled,
+		Dead:         container.State.Dead,
+		Pid:          container.State.Pid,
+		ExitCode:     container.State.ExitCode(),
+		Error:        container.State.Error(),
+		StartedAt:    container.State.StartedAt.Format(time.RFC3339Nano),
+		FinishedAt:   container.State.FinishedAt.Format(time.RFC3339Nano),
+		Health:       containerHealth,
 	}
 
 	contJSONBase := &types.ContainerJSONBase{
@@ -219,8 +220,9 @@ func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, err
 }
 
 // VolumeInspect looks up a volume by name. An error is returned if
-// the volume cannot be found.
-func (daemon *Daemon) VolumeInspect(name string) (*types.Volume, error) {
+// the volume cannot be found. The disk usage of the volume is determined
+// again if refreshUsage is set, instead of being read from the cache.
+func (daemon *Daemon) VolumeInspect(name string, refreshUsage bool) (*types.Volume, error) {
 	v, err := daemon.volumes.Get(name)
 	if err != nil {
 		return nil, err
@@ -228,6 +230,7 @@ func (daemon *Daemon) VolumeInspect(name string) (*types.Volume, error) {
 	apiV := volumeToAPIType(v)
 	apiV.Mountpoint = v.Path()
 	apiV.Status = v.Status()
+	apiV.UsageData = daemon.volumeUsage(v, refreshUsage)
 	return apiV, nil
 }
 
@@ -647,8 +647,9 @@ func (daemon *Daemon) transformContainer(container *container.Container, ctx *li
 }
 
 // Volumes lists known volumes, using the filter to restrict the range
-// of volumes returned.
-func (daemon *Daemon) Volumes(filter string) ([]*types.Volume, []string, error) {
+// of volumes returned. The disk usage of the volumes is determined again
+// if refreshUsage is set, instead of being read from the cache.
+func (daemon *Daemon) Volumes(filter string, refreshUsage bool) ([]*types.Volume, []string, error) {
 	var (
 		volumesOut []*types.Volume
 	)
@@ -679,6 +680,11 @@ func (daemon *Daemon) Volumes(filter string) ([]*types.Volume, []string, error)
 		} else {
 			apiV.Mountpoint = v.Path()
 		}
+		if refreshUsage {
+			apiV.UsageData = daemon.volumeUsage(v, true)
+		} else {
+			apiV.UsageData = daemon.cachedVolumeUsage(v)
+		}
 		volumesOut = append(volumesOut, apiV)
 	}
 	return volumesOut, warnings, nil
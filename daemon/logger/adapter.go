@@ -120,6 +120,12 @@ func (a *pluginAdapterWithRead) ReadLogs(config ReadConfig) *LogWatcher {
 			if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
 				continue
 			}
+			if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+				return
+			}
+			if !config.Selects(msg) {
+				continue
+			}
 
 			select {
 			case watcher.Msg <- msg:
@@ -0,0 +1,213 @@
+package logger
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CacheEnabledOpt is the log opt enabling the cache of the messages sent
+	// to the logging drivers which cannot read logs back.
+	CacheEnabledOpt = "cache-enabled"
+	// CacheMaxSizeOpt is the log opt setting the maximum size of the cache.
+	CacheMaxSizeOpt = "cache-max-size"
+)
+
+// ShouldUseCache returns whether the cache is enabled by the log opts.
+func ShouldUseCache(cfg map[string]string) bool {
+	enabled, _ := strconv.ParseBool(cfg[CacheEnabledOpt])
+	return enabled
+}
+
+// validateCacheOpts checks the log opts of the cache.
+func validateCacheOpts(cfg map[string]string) error {
+	if s, ok := cfg[CacheEnabledOpt]; ok {
+		if _, err := strconv.ParseBool(s); err != nil {
+			return errors.Wrap(err, "error parsing option "+CacheEnabledOpt)
+		}
+	}
+	if s, ok := cfg[CacheMaxSizeOpt]; ok {
+		size, err := units.RAMInBytes(s)
+		if err != nil {
+			return errors.Wrap(err, "error parsing option "+CacheMaxSizeOpt)
+		}
+		if size <= 0 {
+			return errors.Errorf("logger: %s must be a positive number", CacheMaxSizeOpt)
+		}
+	}
+	return nil
+}
+
+// CachedLogger is a Logger which keeps the last messages sent to a driver
+// which cannot read logs back in a ring buffer, so that they can be read.
+// The messages are only kept in memory.
+type CachedLogger struct {
+	l      Logger
+	buffer *messageRing
+
+	mu      sync.Mutex
+	readers map[*LogWatcher]chan struct{}
+	closed  bool
+}
+
+// NewCachedLogger creates a new Logger which sends the messages to the
+// driver, and keeps the last maxSize bytes of them to read them back. The
+// size is read from the log opts when maxSize is negative.
+func NewCachedLogger(driver Logger, logInfo Info, maxSize int64) (*CachedLogger, error) {
+	if maxSize < 0 {
+		maxSize = defaultRingMaxSize
+		if s, ok := logInfo.Config[CacheMaxSizeOpt]; ok {
+			var err error
+			maxSize, err = units.RAMInBytes(s)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &CachedLogger{
+		l:       driver,
+		buffer:  newRing(maxSize),
+		readers: make(map[*LogWatcher]chan struct{}),
+	}, nil
+}
+
+// Log keeps a copy of the message in the cache, and sends the message to
+// the driver.
+func (c *CachedLogger) Log(msg *Message) error {
+	// the driver takes ownership of the message, and may put it back in the
+	// pool, so the cache keeps a copy out of the pool
+	dup := &Message{
+		Line:      append([]byte(nil), msg.Line...),
+		Source:    msg.Source,
+		Timestamp: msg.Timestamp,
+		Partial:   msg.Partial,
+	}
+	if err := c.buffer.Push(dup); err == nil {
+		c.notifyReaders()
+	}
+	return c.l.Log(msg)
+}
+
+func (c *CachedLogger) notifyReaders() {
+	c.mu.Lock()
+	for _, notify := range c.readers {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+	c.mu.Unlock()
+}
+
+// Name returns the name of the underlying logger
+func (c *CachedLogger) Name() string {
+	return c.l.Name()
+}
+
+// Close closes the driver. The cached messages can still be read, but
+// following readers are stopped.
+func (c *CachedLogger) Close() error {
+	c.buffer.Close()
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.notifyReaders()
+	return c.l.Close()
+}
+
+// ReadLogs reads the messages of the cache.
+func (c *CachedLogger) ReadLogs(config ReadConfig) *LogWatcher {
+	logWatcher := NewLogWatcher()
+	go c.readLogs(logWatcher, config)
+	return logWatcher
+}
+
+func (c *CachedLogger) readLogs(logWatcher *LogWatcher, config ReadConfig) {
+	defer close(logWatcher.Msg)
+
+	notify := make(chan struct{}, 1)
+	c.mu.Lock()
+	closed := c.closed
+	if config.Follow && !closed {
+		c.readers[logWatcher] = notify
+		defer func() {
+			c.mu.Lock()
+			delete(c.readers, logWatcher)
+			c.mu.Unlock()
+		}()
+	}
+	c.mu.Unlock()
+
+	msgs, dropped := c.buffer.Snapshot()
+	next := dropped + int64(len(msgs))
+	var selected []*Message
+	for _, msg := range msgs {
+		if cacheSelects(msg, &config) {
+			selected = append(selected, msg)
+		}
+	}
+	if config.Tail >= 0 && len(selected) > config.Tail {
+		selected = selected[len(selected)-config.Tail:]
+	}
+	for _, msg := range selected {
+		select {
+		case logWatcher.Msg <- msg:
+		case <-logWatcher.WatchClose():
+			return
+		}
+	}
+	if !config.Follow || closed {
+		return
+	}
+
+	for {
+		select {
+		case <-notify:
+		case <-logWatcher.WatchClose():
+			return
+		}
+
+		// no message is logged once closed, so the messages logged before
+		// are in the snapshot
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+
+		msgs, dropped := c.buffer.Snapshot()
+		if skipped := dropped - next; skipped > 0 {
+			logrus.WithField("driver", c.l.Name()).Warnf("skipping %d log messages dropped from the cache before they could be followed", skipped)
+			next = dropped
+		}
+		for _, msg := range msgs[next-dropped:] {
+			next++
+			if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+				return
+			}
+			if !cacheSelects(msg, &config) {
+				continue
+			}
+			select {
+			case logWatcher.Msg <- msg:
+			case <-logWatcher.WatchClose():
+				return
+			}
+		}
+		if closed {
+			return
+		}
+	}
+}
+
+func cacheSelects(msg *Message, config *ReadConfig) bool {
+	if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+		return false
+	}
+	if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+		return false
+	}
+	return config.Selects(msg)
+}
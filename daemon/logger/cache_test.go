@@ -0,0 +1,175 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type closeLogger struct {
+	lines  []string
+	closed bool
+}
+
+func (l *closeLogger) Log(msg *Message) error {
+	l.lines = append(l.lines, string(msg.Line))
+	PutMessage(msg)
+	return nil
+}
+
+func (l *closeLogger) Name() string {
+	return "close"
+}
+
+func (l *closeLogger) Close() error {
+	l.closed = true
+	return nil
+}
+
+func readCached(t *testing.T, l *CachedLogger, config ReadConfig) []string {
+	lw := l.ReadLogs(config)
+	defer lw.Close()
+	var lines []string
+	for {
+		select {
+		case msg, ok := <-lw.Msg:
+			if !ok {
+				return lines
+			}
+			lines = append(lines, string(msg.Line))
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout reading the cache")
+		}
+	}
+}
+
+func TestCachedLogger(t *testing.T) {
+	driver := &closeLogger{}
+	l, err := NewCachedLogger(driver, Info{}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name() != "close" {
+		t.Fatalf("Expected the name of the driver, got %s", l.Name())
+	}
+
+	// the messages are copied to the cache by the copier, as for containers
+	r := bytes.NewBufferString("line1\nline2\n")
+	c := NewCopier(map[string]io.Reader{"stdout": r}, l)
+	c.Run()
+	c.Wait()
+
+	if len(driver.lines) != 2 || driver.lines[0] != "line1" || driver.lines[1] != "line2" {
+		t.Fatalf("Expected the messages to be sent to the driver, got %q", driver.lines)
+	}
+	lines := readCached(t, l, ReadConfig{Tail: -1})
+	if len(lines) != 2 || lines[0] != "line1" || lines[1] != "line2" {
+		t.Fatalf("Expected the messages to be read from the cache, got %q", lines)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !driver.closed {
+		t.Fatal("Expected the driver to be closed")
+	}
+	// the cache can still be read once closed
+	lines = readCached(t, l, ReadConfig{Tail: 1, Follow: true})
+	if len(lines) != 1 || lines[0] != "line2" {
+		t.Fatalf("Expected the last message to be read from the cache, got %q", lines)
+	}
+}
+
+func TestCachedLoggerBounded(t *testing.T) {
+	l, err := NewCachedLogger(&closeLogger{}, Info{Config: map[string]string{CacheMaxSizeOpt: "10b"}}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	ts := time.Unix(1000, 0)
+	for i := 0; i < 10; i++ {
+		msg := NewMessage()
+		msg.Line = append(msg.Line, "line"+strconv.Itoa(i)...)
+		msg.Timestamp = ts.Add(time.Duration(i) * time.Second)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// only the last 10 bytes of messages are kept
+	lines := readCached(t, l, ReadConfig{Tail: -1})
+	if len(lines) != 2 || lines[0] != "line8" || lines[1] != "line9" {
+		t.Fatalf("Expected the last messages to be kept, got %q", lines)
+	}
+	lines = readCached(t, l, ReadConfig{Tail: -1, Until: ts.Add(8 * time.Second)})
+	if len(lines) != 1 || lines[0] != "line8" {
+		t.Fatalf("Expected the messages until the time to be read, got %q", lines)
+	}
+	lines = readCached(t, l, ReadConfig{Tail: -1, Since: ts.Add(9 * time.Second)})
+	if len(lines) != 1 || lines[0] != "line9" {
+		t.Fatalf("Expected the messages since the time to be read, got %q", lines)
+	}
+}
+
+func TestCachedLoggerFollow(t *testing.T) {
+	l, err := NewCachedLogger(&closeLogger{}, Info{}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lw := l.ReadLogs(ReadConfig{Tail: -1, Follow: true})
+	defer lw.Close()
+	for i := 0; i < 3; i++ {
+		msg := NewMessage()
+		msg.Line = append(msg.Line, "line"+strconv.Itoa(i)...)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	for {
+		select {
+		case msg, ok := <-lw.Msg:
+			if !ok {
+				if len(lines) != 3 || lines[2] != "line2" {
+					t.Fatalf("Expected the followed messages, got %q", lines)
+				}
+				return
+			}
+			lines = append(lines, string(msg.Line))
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Expected following to stop once the logger is closed, got %q", lines)
+		}
+	}
+}
+
+func TestShouldUseCache(t *testing.T) {
+	if ShouldUseCache(map[string]string{}) {
+		t.Fatal("Expected the cache to be disabled by default")
+	}
+	if !ShouldUseCache(map[string]string{CacheEnabledOpt: "true"}) {
+		t.Fatal("Expected the cache to be enabled")
+	}
+}
+
+func TestValidateCacheOpts(t *testing.T) {
+	if err := validateCacheOpts(map[string]string{CacheEnabledOpt: "true", CacheMaxSizeOpt: "10m"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, cfg := range []map[string]string{
+		{CacheEnabledOpt: "maybe"},
+		{CacheMaxSizeOpt: "lots"},
+		{CacheMaxSizeOpt: "0"},
+	} {
+		if err := validateCacheOpts(cfg); err == nil {
+			t.Fatalf("Expected an error for log opts %v", cfg)
+		}
+	}
+}
@@ -102,6 +102,8 @@ func GetLogDriver(name string) (Creator, error) {
 var builtInLogOpts = map[string]bool{
 	"mode":            true,
 	"max-buffer-size": true,
+	CacheEnabledOpt:   true,
+	CacheMaxSizeOpt:   true,
 }
 
 // ValidateLogOpts checks the options for the given log driver. The
@@ -126,6 +128,10 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		}
 	}
 
+	if err := validateCacheOpts(cfg); err != nil {
+		return err
+	}
+
 	if !factory.driverRegistered(name) {
 		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
 	}
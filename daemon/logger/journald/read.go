@@ -221,13 +221,16 @@ drain:
 			if len(attrs) == 0 {
 				attrs = nil
 			}
-			// Send the log message.
-			logWatcher.Msg <- &logger.Message{
+			msg := &logger.Message{
 				Line:      line,
 				Source:    source,
 				Timestamp: timestamp.In(time.UTC),
 				Attrs:     attrs,
 			}
+			// Send the log message, if it is selected.
+			if (config.Until.IsZero() || !msg.Timestamp.After(config.Until)) && config.Selects(msg) {
+				logWatcher.Msg <- msg
+			}
 		}
 		// If we're at the end of the journal, we're done (for now).
 		if C.sd_journal_next(j) <= 0 {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/daemon/logger"
@@ -61,7 +62,33 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
-	writer, err := loggerutils.NewRotateFileWriter(info.LogPath, capval, maxFiles)
+	var compress bool
+	if compressString, ok := info.Config["compress"]; ok {
+		var err error
+		compress, err = strconv.ParseBool(compressString)
+		if err != nil {
+			return nil, err
+		}
+		if compress && maxFiles == 1 {
+			return nil, fmt.Errorf("compress cannot be true when max-file is less than 2")
+		}
+	}
+	var interval time.Duration
+	if intervalString, ok := info.Config["rotate-interval"]; ok {
+		var err error
+		interval, err = time.ParseDuration(intervalString)
+		if err != nil {
+			return nil, err
+		}
+		if interval < time.Minute {
+			return nil, fmt.Errorf("rotate-interval cannot be less than one minute")
+		}
+		if maxFiles == 1 {
+			return nil, fmt.Errorf("rotate-interval cannot be set when max-file is less than 2")
+		}
+	}
+
+	writer, err := loggerutils.NewRotateFileWriter(info.LogPath, capval, maxFiles, compress, interval)
 	if err != nil {
 		return nil, err
 	}
@@ -118,12 +145,15 @@ func (l *JSONFileLogger) Log(msg *logger.Message) error {
 	return err
 }
 
-// ValidateLogOpt looks for json specific log options max-file & max-size.
+// ValidateLogOpt looks for json specific log options max-file, max-size,
+// compress & rotate-interval.
 func ValidateLogOpt(cfg map[string]string) error {
 	for key := range cfg {
 		switch key {
 		case "max-file":
 		case "max-size":
+		case "compress":
+		case "rotate-interval":
 		case "labels":
 		case "env":
 		case "env-regex":
@@ -1,11 +1,13 @@
 package jsonfilelog
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strconv"
 	"testing"
 	"time"
@@ -247,3 +249,160 @@ func BenchmarkJSONFileLoggerWithReader(b *testing.B) {
 		}
 	}
 }
+
+func TestJSONFileLoggerReadCompressed(t *testing.T) {
+	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	filename := filepath.Join(tmp, "container.log")
+	config := map[string]string{"max-file": "3", "max-size": "1k", "compress": "true"}
+	l, err := New(logger.Info{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	for i := 0; i < 40; i++ {
+		if err := l.Log(&logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: "src1", Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// wait for the pending compression
+	l.(*JSONFileLogger).writer.Close()
+	if _, err := os.Stat(filename + ".1.gz"); err != nil {
+		t.Fatal(err)
+	}
+
+	lw := l.(logger.LogReader).ReadLogs(logger.ReadConfig{Tail: 20})
+	var lines []string
+	for msg := range lw.Msg {
+		lines = append(lines, string(msg.Line))
+	}
+	if len(lines) != 20 {
+		t.Fatalf("Expected 20 lines, got %d", len(lines))
+	}
+	if lines[0] != "line20\n" || lines[19] != "line39\n" {
+		t.Fatalf("Wrong tailed lines: %q", lines)
+	}
+}
+
+func TestJSONFileLoggerOpenCompressedLazily(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	filename := filepath.Join(tmp, "container.log")
+	config := map[string]string{"max-file": "2", "max-size": "1k", "compress": "true"}
+	l, err := New(logger.Info{LogPath: filename, Config: config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	for i := 0; i < 20; i++ {
+		if err := l.Log(&logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: "src1", Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// wait for the pending compression
+	l.(*JSONFileLogger).writer.Close()
+
+	f, err := openRotatedFile(filename+".1", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	size, err := f.Seek(0, os.SEEK_END)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := f.(*compressedFile); !ok || c.f != nil {
+		t.Fatalf("Compressed file must not be decompressed before it is read")
+	}
+	if _, err := f.Seek(0, os.SEEK_SET); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(content)) != size || !bytes.HasPrefix(content, []byte(`{"log":"line0\n"`)) {
+		t.Fatalf("Wrong content of compressed file (%d bytes, expected %d): %q", len(content), size, content)
+	}
+}
+
+func TestJSONFileLoggerValidateOpts(t *testing.T) {
+	if err := ValidateLogOpt(map[string]string{"compress": "true", "rotate-interval": "24h"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, config := range []map[string]string{
+		{"compress": "true"},
+		{"compress": "maybe", "max-file": "2"},
+		{"rotate-interval": "1s"},
+		{"rotate-interval": "24h"},
+	} {
+		tmp, err := ioutil.TempDir("", "docker-logger-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmp)
+		if l, err := New(logger.Info{LogPath: filepath.Join(tmp, "container.log"), Config: config}); err == nil {
+			l.Close()
+			t.Fatalf("Expected an error for log opts %v", config)
+		}
+	}
+}
+
+func TestJSONFileLoggerReadFiltered(t *testing.T) {
+	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	l, err := New(logger.Info{
+		ContainerID: cid,
+		LogPath:     filepath.Join(tmp, "container.log"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	ts := time.Unix(1000, 0)
+	for i := 0; i < 20; i++ {
+		source := "stdout"
+		if i%2 == 1 {
+			source = "stderr"
+		}
+		msg := &logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: source, Timestamp: ts.Add(time.Duration(i) * time.Second)}
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(config logger.ReadConfig) []string {
+		var lines []string
+		for msg := range l.(logger.LogReader).ReadLogs(config).Msg {
+			lines = append(lines, string(msg.Line))
+		}
+		return lines
+	}
+	check := func(lines []string, expected ...string) {
+		if !reflect.DeepEqual(lines, expected) {
+			t.Fatalf("Expected lines %q, got %q", expected, lines)
+		}
+	}
+
+	// tail applies to the selected lines
+	check(read(logger.ReadConfig{Tail: 2, Sources: []string{"stderr"}}), "line17\n", "line19\n")
+	check(read(logger.ReadConfig{Tail: -1, Match: regexp.MustCompile("^line1[24]$")}), "line12\n", "line14\n")
+	check(read(logger.ReadConfig{Tail: 2, Until: ts.Add(5 * time.Second)}), "line4\n", "line5\n")
+	check(read(logger.ReadConfig{Tail: -1, Since: ts.Add(3 * time.Second), Until: ts.Add(5 * time.Second)}), "line3\n", "line4\n", "line5\n")
+}
@@ -2,11 +2,15 @@ package jsonfilelog
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -14,6 +18,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
 	"github.com/docker/docker/pkg/filenotify"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonlog"
@@ -55,7 +60,7 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	pth := l.writer.LogPath()
 	var files []io.ReadSeeker
 	for i := l.writer.MaxFiles(); i > 1; i-- {
-		f, err := os.Open(fmt.Sprintf("%s.%d", pth, i-1))
+		f, err := openRotatedFile(fmt.Sprintf("%s.%d", pth, i-1), config.Since)
 		if err != nil {
 			if !os.IsNotExist(err) {
 				logWatcher.Err <- err
@@ -63,6 +68,9 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 			}
 			continue
 		}
+		if f == nil {
+			continue
+		}
 		defer f.Close()
 
 		files = append(files, f)
@@ -78,7 +86,7 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 
 	if config.Tail != 0 {
 		tailer := ioutils.MultiReadSeeker(append(files, latestFile)...)
-		tailFile(tailer, logWatcher, config.Tail, config.Since)
+		tailFile(tailer, logWatcher, config)
 	}
 
 	// close all the rotated files
@@ -104,7 +112,7 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	l.mu.Unlock()
 
 	notifyRotate := l.writer.NotifyRotate()
-	followLogs(latestFile, logWatcher, notifyRotate, config.Since)
+	followLogs(latestFile, logWatcher, notifyRotate, config)
 
 	l.mu.Lock()
 	delete(l.readers, logWatcher)
@@ -113,28 +121,179 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	l.writer.NotifyRotateEvict(notifyRotate)
 }
 
-func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since time.Time) {
+// openRotatedFile opens the rotated log file, falling back to its compressed
+// version. Compressed files are only decompressed, to an unlinked temporary
+// file, once read from, so that tailing the most recent entries does not
+// decompress the whole history. Compressed files holding only entries older
+// than since are skipped, in which case a nil file is returned.
+func openRotatedFile(name string, since time.Time) (readSeekCloser, error) {
+	f, err := os.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	cf, err := os.Open(name + loggerutils.CompressedFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+	defer cf.Close()
+
+	zr, err := gzip.NewReader(cf)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	// the header records the time of the last write to the file, truncated
+	// to the second
+	if !since.IsZero() && !zr.Header.ModTime.IsZero() && zr.Header.ModTime.Add(time.Second).Before(since) {
+		return nil, nil
+	}
+
+	c := &compressedFile{name: cf.Name()}
+	if size, ok := loggerutils.DecompressedSize(zr.Header); ok {
+		c.size = size
+		return c, nil
+	}
+	// the size is unknown, decompress the file right away
+	if err := c.decompress(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type readSeekCloser interface {
+	io.ReadSeeker
+	io.Closer
+}
+
+// compressedFile reads a compressed log file, which is decompressed on the
+// first read. Seeking relies on the size recorded in the gzip header, and
+// does not require the file to be decompressed.
+type compressedFile struct {
+	name   string
+	size   int64
+	offset int64
+	f      *os.File
+}
+
+func (c *compressedFile) decompress() error {
+	cf, err := os.Open(c.name)
+	if err != nil {
+		return err
+	}
+	defer cf.Close()
+
+	zr, err := gzip.NewReader(cf)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(c.name), strings.TrimSuffix(filepath.Base(c.name), loggerutils.CompressedFileSuffix)+"-decompressed-")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(tmp.Name()); err != nil {
+		tmp.Close()
+		return err
+	}
+	size, err := io.Copy(tmp, zr)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	c.f = tmp
+	c.size = size
+	return nil
+}
+
+func (c *compressedFile) Read(p []byte) (int, error) {
+	if c.f == nil {
+		if c.offset >= c.size {
+			return 0, io.EOF
+		}
+		if err := c.decompress(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := c.f.ReadAt(p, c.offset)
+	c.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (c *compressedFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case os.SEEK_SET:
+	case os.SEEK_CUR:
+		offset += c.offset
+	case os.SEEK_END:
+		offset += c.size
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+	c.offset = offset
+	return offset, nil
+}
+
+func (c *compressedFile) Close() error {
+	if c.f == nil {
+		return nil
+	}
+	return c.f.Close()
+}
+
+func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, config logger.ReadConfig) {
 	var rdr io.Reader
 	rdr = f
-	if tail > 0 {
+	tail := config.Tail
+	// The last lines of the file can only be used when all the messages
+	// are wanted, otherwise the whole file has to be decoded to tail the
+	// selected messages.
+	if tail > 0 && config.Until.IsZero() && !config.Filtered() {
 		ls, err := tailfile.TailFile(f, tail)
 		if err != nil {
 			logWatcher.Err <- err
 			return
 		}
 		rdr = bytes.NewBuffer(bytes.Join(ls, []byte("\n")))
+		tail = -1
 	}
 	dec := json.NewDecoder(rdr)
 	l := &jsonlog.JSONLog{}
+	var tailed []*logger.Message
 	for {
 		msg, err := decodeLogLine(dec, l)
 		if err != nil {
 			if err != io.EOF {
 				logWatcher.Err <- err
+				return
 			}
-			return
+			break
 		}
-		if !since.IsZero() && msg.Timestamp.Before(since) {
+		if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+			continue
+		}
+		if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+			break
+		}
+		if !config.Selects(msg) {
+			continue
+		}
+		if tail > 0 {
+			// keep the last selected messages only
+			if len(tailed) == tail {
+				tailed = tailed[1:]
+			}
+			tailed = append(tailed, msg)
 			continue
 		}
 		select {
@@ -143,6 +302,13 @@ func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since ti
 		case logWatcher.Msg <- msg:
 		}
 	}
+	for _, msg := range tailed {
+		select {
+		case <-logWatcher.WatchClose():
+			return
+		case logWatcher.Msg <- msg:
+		}
+	}
 }
 
 func watchFile(name string) (filenotify.FileWatcher, error) {
@@ -165,7 +331,7 @@ func watchFile(name string) (filenotify.FileWatcher, error) {
 	return fileWatcher, nil
 }
 
-func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, since time.Time) {
+func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, config logger.ReadConfig) {
 	dec := json.NewDecoder(f)
 	l := &jsonlog.JSONLog{}
 
@@ -304,7 +470,13 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 		}
 
 		retries = 0 // reset retries since we've succeeded
-		if !since.IsZero() && msg.Timestamp.Before(since) {
+		if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+			continue
+		}
+		if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+			return
+		}
+		if !config.Selects(msg) {
 			continue
 		}
 		select {
@@ -316,7 +488,13 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 				if err != nil {
 					return
 				}
-				if !since.IsZero() && msg.Timestamp.Before(since) {
+				if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+					continue
+				}
+				if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+					return
+				}
+				if !config.Selects(msg) {
 					continue
 				}
 				logWatcher.Msg <- msg
@@ -0,0 +1,273 @@
+package local
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/plugins/logdriver"
+)
+
+const (
+	// Entries are framed by their size, both before and after the encoded
+	// entry, so that a file can be read forward as well as backward:
+	//
+	// [uint32 size][protobuf LogEntry][uint32 size]
+	encodeBinaryLen = 4
+
+	// An index record is written every indexInterval bytes of entries. The
+	// index of a file is stored next to it, with the indexSuffix suffix, and
+	// is a sequence of records made of the timestamp of an entry and of its
+	// offset in the file, both as big-endian int64:
+	//
+	// [int64 time nano][int64 offset]
+	indexInterval  = 64 * 1024
+	indexRecordLen = 16
+	indexSuffix    = ".idx"
+)
+
+var errCorruptEntry = errors.New("corrupt log entry")
+
+func segmentName(path string, i int) string {
+	if i == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+// logFile writes log entries to a set of rotated files, along with their
+// timestamp index. It is not safe for concurrent use.
+type logFile struct {
+	path        string
+	f           *os.File
+	idx         *os.File
+	capacity    int64 // maximum size of each file
+	maxFiles    int   // maximum number of files
+	size        int64 // current size of the latest file
+	lastIndexed int64 // offset of the last indexed entry of the latest file, -1 if none
+	gen         uint64
+	buf         []byte
+	rec         []byte
+}
+
+func openLogFile(path string, capacity int64, maxFiles int) (*logFile, error) {
+	lf := &logFile{
+		path:     path,
+		capacity: capacity,
+		maxFiles: maxFiles,
+		buf:      make([]byte, 1024),
+		rec:      make([]byte, indexRecordLen),
+	}
+	if err := lf.open(os.O_APPEND); err != nil {
+		return nil, err
+	}
+	return lf, nil
+}
+
+// open opens the latest file and its index, with the additional flag. The
+// index is opened for reading as well, to pick up its last record.
+func (lf *logFile) open(flag int) error {
+	f, err := os.OpenFile(lf.path, os.O_WRONLY|os.O_CREATE|flag, 0640)
+	if err != nil {
+		return err
+	}
+	idx, err := os.OpenFile(lf.path+indexSuffix, os.O_RDWR|os.O_CREATE|flag, 0640)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	size, err := f.Seek(0, os.SEEK_END)
+	if err != nil {
+		f.Close()
+		idx.Close()
+		return err
+	}
+	idxSize, err := idx.Seek(0, os.SEEK_END)
+	if err != nil {
+		f.Close()
+		idx.Close()
+		return err
+	}
+
+	lf.f = f
+	lf.idx = idx
+	lf.size = size
+	lf.lastIndexed = -1
+	if n := idxSize / indexRecordLen; n > 0 {
+		if _, err := idx.ReadAt(lf.rec, (n-1)*indexRecordLen); err == nil {
+			lf.lastIndexed = int64(binary.BigEndian.Uint64(lf.rec[8:]))
+		}
+	}
+	return nil
+}
+
+func (lf *logFile) write(e *logdriver.LogEntry) error {
+	if lf.capacity > 0 && lf.size >= lf.capacity {
+		if err := lf.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n := e.Size()
+	total := n + 2*encodeBinaryLen
+	if total > len(lf.buf) {
+		lf.buf = make([]byte, total)
+	}
+	binary.BigEndian.PutUint32(lf.buf, uint32(n))
+	if _, err := e.MarshalTo(lf.buf[encodeBinaryLen:]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(lf.buf[encodeBinaryLen+n:], uint32(n))
+
+	offset := lf.size
+	written, err := lf.f.Write(lf.buf[:total])
+	lf.size += int64(written)
+	if err != nil {
+		return err
+	}
+
+	if lf.lastIndexed < 0 || offset-lf.lastIndexed >= indexInterval {
+		binary.BigEndian.PutUint64(lf.rec, uint64(e.TimeNano))
+		binary.BigEndian.PutUint64(lf.rec[8:], uint64(offset))
+		if _, err := lf.idx.Write(lf.rec); err != nil {
+			return err
+		}
+		lf.lastIndexed = offset
+	}
+	return nil
+}
+
+func (lf *logFile) rotate() error {
+	if err := lf.close(); err != nil {
+		return err
+	}
+	for i := lf.maxFiles - 1; i > 0; i-- {
+		from, to := segmentName(lf.path, i-1), segmentName(lf.path, i)
+		if err := os.Rename(from, to); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Rename(from+indexSuffix, to+indexSuffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if lf.maxFiles == 1 {
+		// There is no file to rotate to, so the latest file starts over.
+		// It is removed rather than truncated in place, so that the
+		// readers which have it open can still read it to its end; where
+		// open files cannot be removed, it is truncated.
+		for _, name := range []string{lf.path, lf.path + indexSuffix} {
+			if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+				logrus.WithField("logger", Name).Debugf("error removing rotated log file %s: %v", name, err)
+			}
+		}
+	}
+	if err := lf.open(os.O_TRUNC); err != nil {
+		return err
+	}
+	lf.gen++
+	return nil
+}
+
+func (lf *logFile) close() error {
+	err := lf.f.Close()
+	if idxErr := lf.idx.Close(); err == nil {
+		err = idxErr
+	}
+	return err
+}
+
+// readEntryAt decodes the entry stored at offset in r, and returns the offset
+// of the next entry. io.EOF or io.ErrUnexpectedEOF are returned if the entry
+// has not been (fully) written yet.
+func readEntryAt(r io.ReaderAt, offset int64, e *logdriver.LogEntry, buf []byte) (int64, []byte, error) {
+	var lenBuf [encodeBinaryLen]byte
+	if _, err := r.ReadAt(lenBuf[:], offset); err != nil {
+		return offset, buf, err
+	}
+	size := int(binary.BigEndian.Uint32(lenBuf[:]))
+	total := size + encodeBinaryLen
+	if len(buf) < total {
+		buf = make([]byte, total)
+	}
+	if _, err := r.ReadAt(buf[:total], offset+encodeBinaryLen); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return offset, buf, err
+	}
+	if int(binary.BigEndian.Uint32(buf[size:total])) != size {
+		return offset, buf, errCorruptEntry
+	}
+	e.Reset()
+	if err := e.Unmarshal(buf[:size]); err != nil {
+		return offset, buf, err
+	}
+	return offset + int64(total) + encodeBinaryLen, buf, nil
+}
+
+// readEntryBefore decodes the entry ending at offset end in r, and returns
+// the offset at which it starts.
+func readEntryBefore(r io.ReaderAt, end int64, e *logdriver.LogEntry, buf []byte) (int64, []byte, error) {
+	var lenBuf [encodeBinaryLen]byte
+	if end < 2*encodeBinaryLen {
+		return end, buf, errCorruptEntry
+	}
+	if _, err := r.ReadAt(lenBuf[:], end-encodeBinaryLen); err != nil {
+		return end, buf, err
+	}
+	start := end - int64(binary.BigEndian.Uint32(lenBuf[:])) - 2*encodeBinaryLen
+	if start < 0 {
+		return end, buf, errCorruptEntry
+	}
+	next, buf, err := readEntryAt(r, start, e, buf)
+	if err != nil {
+		return end, buf, err
+	}
+	if next != end {
+		return end, buf, errCorruptEntry
+	}
+	return start, buf, nil
+}
+
+// indexOffset looks up the index for the offset of the last indexed entry
+// emitted before since, among the entries stored before limit. The first
+// entry of the file is returned when there is no such entry, or when the
+// index cannot be read.
+func indexOffset(idx *os.File, since int64, limit int64) int64 {
+	if idx == nil {
+		return 0
+	}
+	fi, err := idx.Stat()
+	if err != nil {
+		logrus.WithField("logger", Name).Debugf("error reading log index %s: %v", idx.Name(), err)
+		return 0
+	}
+
+	rec := make([]byte, indexRecordLen)
+	var readErr error
+	n := int(fi.Size() / indexRecordLen)
+	i := sort.Search(n, func(i int) bool {
+		if _, err := idx.ReadAt(rec, int64(i)*indexRecordLen); err != nil {
+			readErr = err
+			return true
+		}
+		ts := int64(binary.BigEndian.Uint64(rec))
+		offset := int64(binary.BigEndian.Uint64(rec[8:]))
+		return ts >= since || offset >= limit
+	})
+	if readErr != nil {
+		logrus.WithField("logger", Name).Debugf("error reading log index %s: %v", idx.Name(), readErr)
+		return 0
+	}
+	if i == 0 {
+		return 0
+	}
+	if _, err := idx.ReadAt(rec, int64(i-1)*indexRecordLen); err != nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(rec[8:]))
+}
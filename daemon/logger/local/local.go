@@ -0,0 +1,138 @@
+// Package local provides a logger implementation which stores the logs of
+// a container on the host server in a compact binary format, indexed by
+// timestamp so that they can be read back efficiently.
+package local
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/go-units"
+)
+
+const (
+	// Name is the name of the driver
+	Name = "local"
+
+	defaultMaxSize  = 20 * 1024 * 1024
+	defaultMaxFiles = 5
+)
+
+type localLogger struct {
+	mu      sync.Mutex
+	file    *logFile
+	entry   logdriver.LogEntry
+	readers map[*logger.LogWatcher]chan struct{} // stores the active log followers, with their write notification channel
+	closed  bool
+}
+
+func init() {
+	if err := logger.RegisterLogDriver(Name, New); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, ValidateLogOpt); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// New creates a new local logger which writes to the log path passed in
+// the logger info.
+func New(info logger.Info) (logger.Logger, error) {
+	if info.LogPath == "" {
+		return nil, fmt.Errorf("log path is required for the %s log driver", Name)
+	}
+	var capval int64 = defaultMaxSize
+	if capacity, ok := info.Config["max-size"]; ok {
+		var err error
+		capval, err = units.FromHumanSize(capacity)
+		if err != nil {
+			return nil, err
+		}
+		if capval <= 0 {
+			return nil, fmt.Errorf("max-size must be a positive number")
+		}
+	}
+	var maxFiles = defaultMaxFiles
+	if maxFileString, ok := info.Config["max-file"]; ok {
+		var err error
+		maxFiles, err = strconv.Atoi(maxFileString)
+		if err != nil {
+			return nil, err
+		}
+		if maxFiles < 1 {
+			return nil, fmt.Errorf("max-file cannot be less than 1")
+		}
+	}
+
+	file, err := openLogFile(info.LogPath, capval, maxFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &localLogger{
+		file:    file,
+		readers: make(map[*logger.LogWatcher]chan struct{}),
+	}, nil
+}
+
+// ValidateLogOpt looks for local specific log options max-file & max-size.
+func ValidateLogOpt(cfg map[string]string) error {
+	for key := range cfg {
+		switch key {
+		case "max-file":
+		case "max-size":
+		default:
+			return fmt.Errorf("unknown log opt '%s' for %s log driver", key, Name)
+		}
+	}
+	return nil
+}
+
+// Log encodes the message and appends it to the log file.
+func (l *localLogger) Log(msg *logger.Message) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entry.Source = msg.Source
+	l.entry.TimeNano = msg.Timestamp.UnixNano()
+	l.entry.Line = msg.Line
+	l.entry.Partial = msg.Partial
+	err := l.file.write(&l.entry)
+	l.entry.Line = nil
+	logger.PutMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, notify := range l.readers {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Name returns name of this logger.
+func (l *localLogger) Name() string {
+	return Name
+}
+
+// Close closes the log file and signals all readers to stop.
+func (l *localLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	err := l.file.close()
+	for r := range l.readers {
+		r.Close()
+		delete(l.readers, r)
+	}
+	return err
+}
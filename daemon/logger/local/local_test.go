@@ -0,0 +1,341 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+)
+
+func newTestLogger(t *testing.T, config map[string]string) (logger.Logger, string, func()) {
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(tmp, "container.log")
+	l, err := New(logger.Info{
+		ContainerID: "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657",
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		os.RemoveAll(tmp)
+		t.Fatal(err)
+	}
+	return l, filename, func() {
+		l.Close()
+		os.RemoveAll(tmp)
+	}
+}
+
+func logLines(t *testing.T, l logger.Logger, start, count int, ts time.Time) {
+	for i := start; i < start+count; i++ {
+		msg := &logger.Message{
+			Line:      []byte("line" + strconv.Itoa(i)),
+			Source:    "stdout",
+			Timestamp: ts.Add(time.Duration(i) * time.Second),
+		}
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readLines(t *testing.T, l logger.Logger, config logger.ReadConfig) []string {
+	lw := l.(logger.LogReader).ReadLogs(config)
+	defer lw.Close()
+	var lines []string
+	for {
+		select {
+		case msg, ok := <-lw.Msg:
+			if !ok {
+				return lines
+			}
+			lines = append(lines, string(msg.Line))
+		case err := <-lw.Err:
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkLines(t *testing.T, lines []string, first, count int) {
+	if len(lines) != count {
+		t.Fatalf("Expected %d lines, got %d: %q", count, len(lines), lines)
+	}
+	for i, line := range lines {
+		if expected := "line" + strconv.Itoa(first+i) + "\n"; line != expected {
+			t.Fatalf("Expected line %d to be %q, got %q", i, expected, line)
+		}
+	}
+}
+
+func TestReadLogs(t *testing.T) {
+	l, filename, cleanup := newTestLogger(t, map[string]string{"max-size": "1k", "max-file": "3"})
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	logLines(t, l, 0, 100, ts)
+	if _, err := os.Stat(filename + ".2"); err != nil {
+		t.Fatalf("Expected the log file to have been rotated: %v", err)
+	}
+	if _, err := os.Stat(filename + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("Expected at most 3 log files, got %v", err)
+	}
+
+	all := readLines(t, l, logger.ReadConfig{Tail: -1})
+	if len(all) == 0 || len(all) == 100 {
+		t.Fatalf("Expected the oldest lines to have been dropped, got %d lines", len(all))
+	}
+	first := 100 - len(all)
+	checkLines(t, all, first, len(all))
+
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: 10}), 90, 10)
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: -1, Since: ts.Add(95 * time.Second)}), 95, 5)
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: 10, Since: ts.Add(95 * time.Second)}), 95, 5)
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: 1000}), first, len(all))
+	if lines := readLines(t, l, logger.ReadConfig{Tail: 0}); len(lines) != 0 {
+		t.Fatalf("Expected no lines, got %q", lines)
+	}
+}
+
+func TestReadLogsFiltered(t *testing.T) {
+	l, _, cleanup := newTestLogger(t, nil)
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	for i := 0; i < 20; i++ {
+		source := "stdout"
+		if i%2 == 1 {
+			source = "stderr"
+		}
+		msg := &logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: source, Timestamp: ts.Add(time.Duration(i) * time.Second)}
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := readLines(t, l, logger.ReadConfig{Tail: 2, Sources: []string{"stderr"}})
+	if len(lines) != 2 || lines[0] != "line17\n" || lines[1] != "line19\n" {
+		t.Fatalf("Expected the last 2 stderr lines, got %q", lines)
+	}
+	lines = readLines(t, l, logger.ReadConfig{Tail: -1, Match: regexp.MustCompile("^line1[24]$")})
+	if len(lines) != 2 || lines[0] != "line12\n" || lines[1] != "line14\n" {
+		t.Fatalf("Expected the matching lines, got %q", lines)
+	}
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: 2, Until: ts.Add(5 * time.Second)}), 4, 2)
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: -1, Since: ts.Add(3 * time.Second), Until: ts.Add(5 * time.Second)}), 3, 3)
+	// following stops once until is reached
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: -1, Follow: true, Until: ts.Add(5 * time.Second)}), 0, 6)
+}
+
+func TestReadLogsIndex(t *testing.T) {
+	l, _, cleanup := newTestLogger(t, map[string]string{"max-size": "100m", "max-file": "1"})
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	count := 3 * indexInterval / 16
+	logLines(t, l, 0, count, ts)
+
+	lf := l.(*localLogger).file
+	idx, err := os.Open(lf.path + indexSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	fi, err := idx.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records := fi.Size() / indexRecordLen; records < 3 {
+		t.Fatalf("Expected at least 3 index records, got %d", records)
+	}
+
+	since := ts.Add(time.Duration(count-5) * time.Second)
+	offset := indexOffset(idx, since.UnixNano(), lf.size)
+	if offset == 0 || offset >= lf.size {
+		t.Fatalf("Expected the index to skip older entries, got offset %d", offset)
+	}
+	checkLines(t, readLines(t, l, logger.ReadConfig{Tail: -1, Since: since}), count-5, 5)
+}
+
+func TestFollowLogsAcrossRotation(t *testing.T) {
+	l, _, cleanup := newTestLogger(t, map[string]string{"max-size": "1k", "max-file": "2"})
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	logLines(t, l, 0, 5, ts)
+
+	lw := l.(logger.LogReader).ReadLogs(logger.ReadConfig{Tail: -1, Follow: true})
+	done := make(chan []string)
+	go func() {
+		var lines []string
+		for msg := range lw.Msg {
+			lines = append(lines, string(msg.Line))
+		}
+		done <- lines
+	}()
+
+	// log enough lines to rotate the file several times, slowly enough for
+	// the follower to keep up with the rotations
+	for i := 5; i < 100; i++ {
+		logLines(t, l, i, 1, ts)
+		time.Sleep(time.Millisecond)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case lines := <-done:
+		checkLines(t, lines, 0, 100)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timeout waiting for the follower to stop")
+	}
+}
+
+func TestFollowLogsAcrossFastRotations(t *testing.T) {
+	l, _, cleanup := newTestLogger(t, map[string]string{"max-size": "1k", "max-file": "20"})
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	lw := l.(logger.LogReader).ReadLogs(logger.ReadConfig{Tail: -1, Follow: true})
+	done := make(chan []string)
+	go func() {
+		var lines []string
+		for msg := range lw.Msg {
+			lines = append(lines, string(msg.Line))
+		}
+		done <- lines
+	}()
+
+	// the file is rotated several times in a row, without giving the
+	// follower a chance to catch up
+	logLines(t, l, 0, 200, ts)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case lines := <-done:
+		checkLines(t, lines, 0, 200)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timeout waiting for the follower to stop")
+	}
+}
+
+func TestOpenNextGeneration(t *testing.T) {
+	l, _, cleanup := newTestLogger(t, map[string]string{"max-size": "1k", "max-file": "3"})
+	defer cleanup()
+	ll := l.(*localLogger)
+
+	ts := time.Unix(1000, 0)
+	logLines(t, l, 0, 200, ts)
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	current := ll.file.gen
+	if current < 4 {
+		t.Fatalf("Expected the file to be rotated at least 4 times, got %d", current)
+	}
+
+	for _, c := range []struct {
+		gen, expected uint64
+	}{
+		{current - 1, current},
+		{current - 2, current - 1},
+		// rotated away, the oldest kept file is opened
+		{0, current - 2},
+	} {
+		f, next, err := ll.openNextGeneration(c.gen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if next != c.expected {
+			t.Fatalf("Expected the generation after %d to be %d, got %d", c.gen, c.expected, next)
+		}
+		if expected := segmentName(ll.file.path, int(current-next)); f.Name() != expected {
+			t.Fatalf("Expected generation %d to be in %s, got %s", next, expected, f.Name())
+		}
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	if err := ValidateLogOpt(map[string]string{"max-size": "10m", "max-file": "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateLogOpt(map[string]string{"labels": "foo"}); err == nil {
+		t.Fatal("Expected an error for an unknown log opt")
+	}
+}
+
+func TestReopenKeepsIndex(t *testing.T) {
+	l, filename, cleanup := newTestLogger(t, map[string]string{"max-size": "100m", "max-file": "1"})
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	count := 2 * indexInterval / 16
+	logLines(t, l, 0, count, ts)
+	lastIndexed := l.(*localLogger).file.lastIndexed
+	if lastIndexed <= 0 {
+		t.Fatalf("Expected entries after the first one to be indexed, got offset %d", lastIndexed)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	lf, err := openLogFile(filename, 100*1024*1024, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lf.close()
+	if lf.lastIndexed != lastIndexed {
+		t.Fatalf("Expected the last indexed offset to be %d after reopening, got %d", lastIndexed, lf.lastIndexed)
+	}
+}
+
+func TestRotateSingleFileKeepsOpenFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be removed on windows")
+	}
+	l, filename, cleanup := newTestLogger(t, map[string]string{"max-size": "1k", "max-file": "1"})
+	defer cleanup()
+
+	ts := time.Unix(1000, 0)
+	logLines(t, l, 0, 10, ts)
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rotate the file, while it is open for reading
+	logLines(t, l, 10, 100, ts)
+	if l.(*localLogger).file.gen == 0 {
+		t.Fatal("Expected the file to be rotated")
+	}
+	opened, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened.Size() < fi.Size() {
+		t.Fatalf("Expected the open file to keep its %d bytes, got %d", fi.Size(), opened.Size())
+	}
+	var e logdriver.LogEntry
+	if _, _, err := readEntryAt(f, 0, &e, nil); err != nil {
+		t.Fatal(err)
+	}
+	if line := string(e.Line); line != "line0" {
+		t.Fatalf("Expected the open file to start with line0, got %q", line)
+	}
+}
@@ -0,0 +1,358 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+)
+
+var (
+	errWatcherClosed = errors.New("log watcher closed")
+	errUntilReached  = errors.New("until reached")
+)
+
+// segment is one of the log files, opened for reading along with its index.
+type segment struct {
+	f    *os.File
+	idx  *os.File
+	size int64
+}
+
+func closeSegments(segments []*segment) {
+	for _, s := range segments {
+		s.f.Close()
+		if s.idx != nil {
+			s.idx.Close()
+		}
+	}
+}
+
+// ReadLogs implements the logger's LogReader interface for the logs
+// created by this driver.
+func (l *localLogger) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	logWatcher := logger.NewLogWatcher()
+
+	go l.readLogs(logWatcher, config)
+	return logWatcher
+}
+
+func (l *localLogger) readLogs(logWatcher *logger.LogWatcher, config logger.ReadConfig) {
+	defer close(logWatcher.Msg)
+
+	// The files are opened while holding the lock, so that they are
+	// consistent with each other. Entries are then only read up to the size
+	// the files had at this point, so that writes are not blocked while
+	// reading.
+	l.mu.Lock()
+	segments, err := l.openSegments()
+	if err != nil {
+		l.mu.Unlock()
+		logWatcher.Err <- err
+		return
+	}
+	defer closeSegments(segments)
+	gen := l.file.gen
+	var notify chan struct{}
+	if config.Follow && !l.closed {
+		notify = make(chan struct{}, 1)
+		l.readers[logWatcher] = notify
+	}
+	l.mu.Unlock()
+
+	if notify != nil {
+		defer func() {
+			l.mu.Lock()
+			delete(l.readers, logWatcher)
+			l.mu.Unlock()
+		}()
+	}
+
+	if config.Tail > 0 {
+		err = tailSegments(segments, logWatcher, &config)
+	} else if config.Tail < 0 {
+		err = readSegments(segments, logWatcher, &config)
+	}
+	if err != nil {
+		if err != errWatcherClosed && err != errUntilReached {
+			logWatcher.Err <- err
+		}
+		return
+	}
+
+	if notify == nil {
+		return
+	}
+	latest := segments[len(segments)-1]
+	l.followLogs(latest.f, latest.size, gen, logWatcher, notify, &config)
+}
+
+// openSegments opens the log files from the oldest to the latest one. It
+// must be called with the lock held.
+func (l *localLogger) openSegments() ([]*segment, error) {
+	var segments []*segment
+	for i := l.file.maxFiles - 1; i >= 0; i-- {
+		name := segmentName(l.file.path, i)
+		f, err := os.Open(name)
+		if err != nil {
+			if os.IsNotExist(err) && i > 0 {
+				continue
+			}
+			closeSegments(segments)
+			return nil, err
+		}
+		fi, err := f.Stat()
+		if err != nil {
+			f.Close()
+			closeSegments(segments)
+			return nil, err
+		}
+		idx, err := os.Open(name + indexSuffix)
+		if err != nil {
+			logrus.WithField("logger", Name).Debugf("error opening log index: %v", err)
+			idx = nil
+		}
+		segments = append(segments, &segment{f: f, idx: idx, size: fi.Size()})
+	}
+	return segments, nil
+}
+
+// tailSegments sends the last tail selected entries of the segments, reading
+// them backward from the end of the latest segment.
+func tailSegments(segments []*segment, logWatcher *logger.LogWatcher, config *logger.ReadConfig) error {
+	var (
+		messages []*logger.Message
+		buf      []byte
+	)
+	for i := len(segments) - 1; i >= 0 && len(messages) < config.Tail; i-- {
+		s := segments[i]
+		end := s.size
+		for end > 0 && len(messages) < config.Tail {
+			var (
+				e   logdriver.LogEntry
+				err error
+			)
+			end, buf, err = readEntryBefore(s.f, end, &e, buf)
+			if err != nil {
+				return fmt.Errorf("error reading log file %s: %v", s.f.Name(), err)
+			}
+			if !config.Since.IsZero() && e.TimeNano < config.Since.UnixNano() {
+				// older entries are not wanted either
+				i = -1
+				break
+			}
+			if msg, _ := filterEntry(&e, config); msg != nil {
+				messages = append(messages, msg)
+			}
+		}
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if err := sendMessage(logWatcher, messages[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSegments sends all the selected entries of the segments, using the
+// index of the segments to skip the entries emitted before since.
+func readSegments(segments []*segment, logWatcher *logger.LogWatcher, config *logger.ReadConfig) error {
+	var (
+		e   logdriver.LogEntry
+		buf []byte
+	)
+	for _, s := range segments {
+		var offset int64
+		if !config.Since.IsZero() {
+			offset = indexOffset(s.idx, config.Since.UnixNano(), s.size)
+		}
+		for offset < s.size {
+			var err error
+			offset, buf, err = readEntryAt(s.f, offset, &e, buf)
+			if err != nil {
+				return fmt.Errorf("error reading log file %s: %v", s.f.Name(), err)
+			}
+			msg, done := filterEntry(&e, config)
+			if done {
+				return errUntilReached
+			}
+			if msg == nil {
+				continue
+			}
+			if err := sendMessage(logWatcher, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// followLogs sends the entries written to the latest log file from offset,
+// following the files generation after generation across rotations, until
+// either the watcher or the logger is closed.
+func (l *localLogger) followLogs(f *os.File, offset int64, gen uint64, logWatcher *logger.LogWatcher, notify <-chan struct{}, config *logger.ReadConfig) {
+	var (
+		e       logdriver.LogEntry
+		buf     []byte
+		err     error
+		drained bool
+	)
+	opened := f
+	defer func() {
+		// the first file is closed along with the other segments
+		if f != opened {
+			f.Close()
+		}
+	}()
+
+	for {
+		offset, buf, err = readEntryAt(f, offset, &e, buf)
+		if err == nil {
+			msg, done := filterEntry(&e, config)
+			if done {
+				return
+			}
+			if msg == nil {
+				continue
+			}
+			if err := sendMessage(logWatcher, msg); err != nil {
+				if l.isClosed() {
+					logWatcher.Msg <- msg
+					drainLogs(f, offset, logWatcher, config)
+				}
+				return
+			}
+			continue
+		}
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			logWatcher.Err <- fmt.Errorf("error reading log file %s: %v", f.Name(), err)
+			return
+		}
+
+		l.mu.Lock()
+		rotated := l.file.gen != gen
+		l.mu.Unlock()
+		if rotated {
+			// The file is complete once rotated, so read it to its end one
+			// last time before following the new latest file.
+			if !drained {
+				drained = true
+				continue
+			}
+			l.mu.Lock()
+			nf, next, err := l.openNextGeneration(gen)
+			l.mu.Unlock()
+			if err != nil {
+				logWatcher.Err <- err
+				return
+			}
+			if f != opened {
+				f.Close()
+			}
+			f, offset, gen, drained = nf, 0, next, false
+			continue
+		}
+
+		select {
+		case <-notify:
+		case <-logWatcher.WatchClose():
+			if l.isClosed() {
+				drainLogs(f, offset, logWatcher, config)
+			}
+			return
+		}
+	}
+}
+
+// openNextGeneration opens the log file which followed the file of
+// generation gen, along with its generation. The logger may have rotated
+// several times since, in which case the file has been renamed accordingly.
+// If it has been rotated away already, the oldest kept file is opened
+// instead. It must be called with the lock held.
+func (l *localLogger) openNextGeneration(gen uint64) (*os.File, uint64, error) {
+	next := gen + 1
+	if oldest := l.file.gen - uint64(l.file.maxFiles-1); l.file.gen >= uint64(l.file.maxFiles-1) && next < oldest {
+		logrus.WithField("logger", Name).Warnf("skipping %d log files rotated before they could be followed", oldest-next)
+		next = oldest
+	}
+	f, err := os.Open(segmentName(l.file.path, int(l.file.gen-next)))
+	if err != nil {
+		return nil, 0, err
+	}
+	return f, next, nil
+}
+
+func (l *localLogger) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+// drainLogs sends the entries remaining in the file from offset, once the
+// logger has been closed. The reader is still waiting for them, as opposed to
+// the watcher having been closed by the reader.
+func drainLogs(f *os.File, offset int64, logWatcher *logger.LogWatcher, config *logger.ReadConfig) {
+	var (
+		e   logdriver.LogEntry
+		buf []byte
+		err error
+	)
+	for {
+		offset, buf, err = readEntryAt(f, offset, &e, buf)
+		if err != nil {
+			return
+		}
+		msg, done := filterEntry(&e, config)
+		if done {
+			return
+		}
+		if msg != nil {
+			logWatcher.Msg <- msg
+		}
+	}
+}
+
+// filterEntry converts the entry to a message, returning a nil message if
+// the entry is not selected by the configuration. done is true if the entry
+// has been emitted after the until bound of the configuration.
+func filterEntry(e *logdriver.LogEntry, config *logger.ReadConfig) (msg *logger.Message, done bool) {
+	if !config.Until.IsZero() && e.TimeNano > config.Until.UnixNano() {
+		return nil, true
+	}
+	if !config.Since.IsZero() && e.TimeNano < config.Since.UnixNano() {
+		return nil, false
+	}
+	msg = entryToMessage(e)
+	if !config.Selects(msg) {
+		return nil, false
+	}
+	return msg, false
+}
+
+func sendMessage(logWatcher *logger.LogWatcher, msg *logger.Message) error {
+	select {
+	case <-logWatcher.WatchClose():
+		return errWatcherClosed
+	case logWatcher.Msg <- msg:
+		return nil
+	}
+}
+
+func entryToMessage(e *logdriver.LogEntry) *logger.Message {
+	line := make([]byte, len(e.Line), len(e.Line)+1)
+	copy(line, e.Line)
+	if !e.Partial {
+		line = append(line, '\n')
+	}
+	return &logger.Message{
+		Source:    e.Source,
+		Timestamp: time.Unix(0, e.TimeNano),
+		Line:      line,
+		Partial:   e.Partial,
+	}
+}
@@ -8,7 +8,9 @@
 package logger
 
 import (
+	"bytes"
 	"errors"
+	"regexp"
 	"sync"
 	"time"
 
@@ -83,8 +85,38 @@ type Logger interface {
 // ReadConfig is the configuration passed into ReadLogs.
 type ReadConfig struct {
 	Since  time.Time
+	Until  time.Time
 	Tail   int
 	Follow bool
+	// Sources restricts the messages read to the ones emitted on these
+	// sources (e.g. "stdout"). Messages from all sources are read if empty.
+	Sources []string
+	// Match restricts the messages read to the ones whose line matches.
+	Match *regexp.Regexp `json:"-"`
+}
+
+// Filtered returns whether messages are selected by their source or line,
+// in which case Tail applies to the selected messages.
+func (c *ReadConfig) Filtered() bool {
+	return len(c.Sources) > 0 || c.Match != nil
+}
+
+// Selects returns whether the message is selected by the Sources and Match
+// options of the configuration.
+func (c *ReadConfig) Selects(msg *Message) bool {
+	if len(c.Sources) > 0 {
+		selected := false
+		for _, s := range c.Sources {
+			if s == msg.Source {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			return false
+		}
+	}
+	return c.Match == nil || c.Match.Match(bytes.TrimSuffix(msg.Line, []byte("\n")))
 }
 
 // LogReader is the interface for reading log messages for loggers that support reading.
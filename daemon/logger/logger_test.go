@@ -1,5 +1,10 @@
 package logger
 
+import (
+	"regexp"
+	"testing"
+)
+
 func (m *Message) copy() *Message {
 	msg := &Message{
 		Source:    m.Source,
@@ -17,3 +22,28 @@ func (m *Message) copy() *Message {
 	msg.Line = append(make([]byte, 0, len(m.Line)), m.Line...)
 	return msg
 }
+
+func TestReadConfigSelects(t *testing.T) {
+	stdout := &Message{Source: "stdout", Line: []byte("level=info msg=started\n")}
+	stderr := &Message{Source: "stderr", Line: []byte("level=error msg=failed\n")}
+
+	config := ReadConfig{}
+	if config.Filtered() || !config.Selects(stdout) || !config.Selects(stderr) {
+		t.Fatal("Expected all the messages to be selected")
+	}
+
+	config = ReadConfig{Sources: []string{"stderr"}}
+	if !config.Filtered() || config.Selects(stdout) || !config.Selects(stderr) {
+		t.Fatal("Expected only the stderr messages to be selected")
+	}
+
+	config = ReadConfig{Match: regexp.MustCompile("failed$")}
+	if !config.Filtered() || config.Selects(stdout) || !config.Selects(stderr) {
+		t.Fatal("Expected only the matching messages to be selected")
+	}
+
+	config = ReadConfig{Sources: []string{"stdout"}, Match: regexp.MustCompile("failed")}
+	if config.Selects(stdout) || config.Selects(stderr) {
+		t.Fatal("Expected no message to be selected")
+	}
+}
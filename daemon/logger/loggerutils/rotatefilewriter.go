@@ -1,25 +1,46 @@
 package loggerutils
 
 import (
+	"compress/gzip"
+	"encoding/binary"
+	"io"
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/pubsub"
 )
 
+// CompressedFileSuffix is the suffix of the rotated files which have been
+// compressed with gzip.
+const CompressedFileSuffix = ".gz"
+
+// sizeSubfieldID identifies the subfield of the gzip extra field which holds
+// the size of the file before compression, as a little endian uint64.
+var sizeSubfieldID = [2]byte{'D', 'S'}
+
 // RotateFileWriter is Logger implementation for default Docker logging.
 type RotateFileWriter struct {
 	f            *os.File // store for closing
 	mu           sync.Mutex
-	capacity     int64 //maximum size of each file
-	currentSize  int64 // current size of the latest file
-	maxFiles     int   //maximum number of files
+	capacity     int64         //maximum size of each file
+	currentSize  int64         // current size of the latest file
+	maxFiles     int           //maximum number of files
+	compress     bool          // whether rotated files are compressed
+	interval     time.Duration // rotate the latest file when a write falls into a new interval
+	lastWrite    time.Time     // time of the last write to the latest file
+	compressWait sync.WaitGroup
 	notifyRotate *pubsub.Publisher
 }
 
-//NewRotateFileWriter creates new RotateFileWriter
-func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateFileWriter, error) {
+// NewRotateFileWriter creates new RotateFileWriter. If compress is true,
+// rotated files are compressed with gzip. If interval is not zero, the
+// latest file is also rotated when written to in a different interval than
+// the previous write, intervals being aligned on the zero time (e.g. 24h
+// rotates the file at midnight UTC).
+func NewRotateFileWriter(logPath string, capacity int64, maxFiles int, compress bool, interval time.Duration) (*RotateFileWriter, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
 	if err != nil {
 		return nil, err
@@ -30,11 +51,23 @@ func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateF
 		return nil, err
 	}
 
+	var lastWrite time.Time
+	if size > 0 {
+		fi, err := log.Stat()
+		if err != nil {
+			return nil, err
+		}
+		lastWrite = fi.ModTime()
+	}
+
 	return &RotateFileWriter{
 		f:            log,
 		capacity:     capacity,
 		currentSize:  size,
 		maxFiles:     maxFiles,
+		compress:     compress,
+		interval:     interval,
+		lastWrite:    lastWrite,
 		notifyRotate: pubsub.NewPublisher(0, 1),
 	}, nil
 }
@@ -42,7 +75,8 @@ func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateF
 //WriteLog write log message to File
 func (w *RotateFileWriter) Write(message []byte) (int, error) {
 	w.mu.Lock()
-	if err := w.checkCapacityAndRotate(); err != nil {
+	now := time.Now()
+	if err := w.checkCapacityAndRotate(now); err != nil {
 		w.mu.Unlock()
 		return -1, err
 	}
@@ -50,31 +84,52 @@ func (w *RotateFileWriter) Write(message []byte) (int, error) {
 	n, err := w.f.Write(message)
 	if err == nil {
 		w.currentSize += int64(n)
+		w.lastWrite = now
 	}
 	w.mu.Unlock()
 	return n, err
 }
 
-func (w *RotateFileWriter) checkCapacityAndRotate() error {
-	if w.capacity == -1 {
+func (w *RotateFileWriter) needsRotate(now time.Time) bool {
+	if w.capacity != -1 && w.currentSize >= w.capacity {
+		return true
+	}
+	if w.interval > 0 && w.currentSize > 0 && !w.lastWrite.IsZero() {
+		return !now.Truncate(w.interval).Equal(w.lastWrite.Truncate(w.interval))
+	}
+	return false
+}
+
+func (w *RotateFileWriter) checkCapacityAndRotate(now time.Time) error {
+	if !w.needsRotate(now) {
 		return nil
 	}
 
-	if w.currentSize >= w.capacity {
-		name := w.f.Name()
-		if err := w.f.Close(); err != nil {
-			return err
-		}
-		if err := rotate(name, w.maxFiles); err != nil {
-			return err
-		}
-		file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 06400)
-		if err != nil {
-			return err
-		}
-		w.f = file
-		w.currentSize = 0
-		w.notifyRotate.Publish(struct{}{})
+	name := w.f.Name()
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	// files must not be shifted while the previous one is being compressed
+	w.compressWait.Wait()
+	if err := rotate(name, w.maxFiles); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 06400)
+	if err != nil {
+		return err
+	}
+	w.f = file
+	w.currentSize = 0
+	w.notifyRotate.Publish(struct{}{})
+
+	if w.compress && w.maxFiles > 1 {
+		w.compressWait.Add(1)
+		go func(lastWrite time.Time) {
+			defer w.compressWait.Done()
+			if err := compressFile(name+".1", lastWrite); err != nil {
+				logrus.Errorf("Error compressing rotated log file %s: %v", name+".1", err)
+			}
+		}(w.lastWrite)
 	}
 
 	return nil
@@ -84,12 +139,22 @@ func rotate(name string, maxFiles int) error {
 	if maxFiles < 2 {
 		return nil
 	}
+	// the oldest file may have been kept uncompressed or compressed, drop both
+	oldest := name + "." + strconv.Itoa(maxFiles-1)
+	for _, p := range []string{oldest, oldest + CompressedFileSuffix} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	for i := maxFiles - 1; i > 1; i-- {
 		toPath := name + "." + strconv.Itoa(i)
 		fromPath := name + "." + strconv.Itoa(i-1)
 		if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
 			return err
 		}
+		if err := os.Rename(fromPath+CompressedFileSuffix, toPath+CompressedFileSuffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
 
 	if err := os.Rename(name, name+".1"); err != nil && !os.IsNotExist(err) {
@@ -98,6 +163,82 @@ func rotate(name string, maxFiles int) error {
 	return nil
 }
 
+// compressFile compresses the file with gzip, and replaces it with the
+// compressed file. The time of the last write to the file is recorded in the
+// gzip header, so that readers can skip the file without decompressing it.
+// The compressed file is renamed into place before the original file is
+// removed, so that readers always find one of them.
+func compressFile(name string, lastWrite time.Time) error {
+	f, err := os.Open(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := name + CompressedFileSuffix + ".tmp"
+	out, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			out.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	zw := gzip.NewWriter(out)
+	zw.ModTime = lastWrite
+	zw.Extra = sizeExtra(fi.Size())
+	if _, err = io.Copy(zw, f); err != nil {
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, name+CompressedFileSuffix); err != nil {
+		return err
+	}
+	return os.Remove(name)
+}
+
+// sizeExtra returns a gzip extra field recording the size of the file.
+func sizeExtra(size int64) []byte {
+	extra := make([]byte, 4+8)
+	copy(extra, sizeSubfieldID[:])
+	binary.LittleEndian.PutUint16(extra[2:], 8)
+	binary.LittleEndian.PutUint64(extra[4:], uint64(size))
+	return extra
+}
+
+// DecompressedSize returns the size of a file compressed on rotation, as
+// recorded in the extra field of its gzip header, so that readers can seek
+// through the file without decompressing it.
+func DecompressedSize(h gzip.Header) (int64, bool) {
+	extra := h.Extra
+	for len(extra) >= 4 {
+		n := int(binary.LittleEndian.Uint16(extra[2:]))
+		if len(extra) < 4+n {
+			break
+		}
+		if extra[0] == sizeSubfieldID[0] && extra[1] == sizeSubfieldID[1] && n == 8 {
+			return int64(binary.LittleEndian.Uint64(extra[4:])), true
+		}
+		extra = extra[4+n:]
+	}
+	return 0, false
+}
+
 // LogPath returns the location the given writer logs to.
 func (w *RotateFileWriter) LogPath() string {
 	return w.f.Name()
@@ -120,5 +261,6 @@ func (w *RotateFileWriter) NotifyRotateEvict(sub chan interface{}) {
 
 // Close closes underlying file and signals all readers to stop.
 func (w *RotateFileWriter) Close() error {
+	w.compressWait.Wait()
 	return w.f.Close()
 }
@@ -0,0 +1,111 @@
+package loggerutils
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotateFileWriterInterval(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "rotatefilewriter-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	name := filepath.Join(tmp, "container.log")
+
+	w, err := NewRotateFileWriter(name, -1, 3, false, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("second\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("Log file must not be rotated within the same interval, got %v", err)
+	}
+
+	// pretend the previous write happened in the previous interval
+	w.lastWrite = w.lastWrite.Add(-time.Hour)
+	if _, err := w.Write([]byte("third\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := ioutil.ReadFile(name + ".1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "first\nsecond\n"; string(rotated) != expected {
+		t.Fatalf("Wrong rotated content: %q, expected %q", rotated, expected)
+	}
+	latest, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "third\n"; string(latest) != expected {
+		t.Fatalf("Wrong log content: %q, expected %q", latest, expected)
+	}
+}
+
+func TestRotateFileWriterCompress(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "rotatefilewriter-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	name := filepath.Join(tmp, "container.log")
+
+	w, err := NewRotateFileWriter(name, 10, 3, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"line0-----\n", "line1-----\n", "line2-----\n", "line3-----\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// waits for the pending compression
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range map[string]string{"1": "line2-----\n", "2": "line1-----\n"} {
+		if _, err := os.Stat(name + "." + i); !os.IsNotExist(err) {
+			t.Fatalf("Rotated file %s must have been replaced by its compressed version, got %v", i, err)
+		}
+		f, err := os.Open(name + "." + i + CompressedFileSuffix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(zr)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expected {
+			t.Fatalf("Wrong content of rotated file %s: %q, expected %q", i, content, expected)
+		}
+		if zr.Header.ModTime.IsZero() {
+			t.Fatalf("Compressed file %s must record the time of the last write", i)
+		}
+		if size, ok := DecompressedSize(zr.Header); !ok || size != int64(len(expected)) {
+			t.Fatalf("Compressed file %s must record its size %d, got %d", i, len(expected), size)
+		}
+	}
+	if _, err := os.Stat(name + ".3" + CompressedFileSuffix); !os.IsNotExist(err) {
+		t.Fatalf("At most 3 files must be kept, got %v", err)
+	}
+}
@@ -128,6 +128,7 @@ type messageRing struct {
 	maxBytes  int64 // max buffer size size
 	queue     []*Message
 	closed    bool
+	dropped   int64 // number of messages dropped by Push
 }
 
 func newRing(maxBytes int64) *messageRing {
@@ -167,6 +168,42 @@ func (r *messageRing) Enqueue(m *Message) error {
 	return nil
 }
 
+// Push adds a message to the buffer, dropping the oldest messages to make
+// room for it rather than the message itself. It is used by buffers which
+// keep the last messages instead of queueing them.
+func (r *messageRing) Push(m *Message) error {
+	mSize := int64(len(m.Line))
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return errClosed
+	}
+	for len(r.queue) > 0 && mSize+r.sizeBytes > r.maxBytes {
+		r.sizeBytes -= int64(len(r.queue[0].Line))
+		r.queue[0] = nil
+		r.queue = r.queue[1:]
+		r.dropped++
+	}
+
+	r.queue = append(r.queue, m)
+	r.sizeBytes += mSize
+	r.wait.Signal()
+	r.mu.Unlock()
+	return nil
+}
+
+// Snapshot returns the messages in the buffer, oldest first, along with the
+// number of messages dropped by Push before them.
+func (r *messageRing) Snapshot() ([]*Message, int64) {
+	r.mu.Lock()
+	ls := make([]*Message, len(r.queue))
+	copy(ls, r.queue)
+	dropped := r.dropped
+	r.mu.Unlock()
+	return ls, dropped
+}
+
 // Dequeue pulls a message off the queue
 // If there are no messages, it waits for one.
 // If the buffer is closed, it will return immediately.
@@ -2,12 +2,15 @@ package daemon
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"strconv"
 	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/Sirupsen/logrus"
+	apierrors "github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	containertypes "github.com/docker/docker/api/types/container"
@@ -70,10 +73,45 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		since = time.Unix(s, n)
 	}
 
+	var until time.Time
+	if config.Until != "" && config.Until != "0" {
+		s, n, err := timetypes.ParseTimestamps(config.Until, 0)
+		if err != nil {
+			return nil, err
+		}
+		until = time.Unix(s, n)
+		if !until.After(time.Now()) {
+			// nothing to wait for
+			follow = false
+		}
+	}
+
+	var match *regexp.Regexp
+	if config.Grep != "" {
+		match, err = regexp.Compile(config.Grep)
+		if err != nil {
+			return nil, apierrors.NewBadRequestError(fmt.Errorf("invalid grep expression: %v", err))
+		}
+	}
+
+	// the sources are selected by the reader, so that tail applies to the
+	// selected messages
+	var sources []string
+	if config.ShowStdout != config.ShowStderr {
+		if config.ShowStdout {
+			sources = []string{"stdout"}
+		} else {
+			sources = []string{"stderr"}
+		}
+	}
+
 	readConfig := logger.ReadConfig{
-		Since:  since,
-		Tail:   tailLines,
-		Follow: follow,
+		Since:   since,
+		Until:   until,
+		Tail:    tailLines,
+		Follow:  follow,
+		Sources: sources,
+		Match:   match,
 	}
 
 	logs := logReader.ReadLogs(readConfig)
@@ -106,9 +144,20 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		// that we're doing with logs (other than context cancel i guess).
 		defer close(messageChan)
 
+		// stop following the logs once until is reached
+		var untilC <-chan time.Time
+		if follow && !until.IsZero() {
+			untilTimer := time.NewTimer(until.Sub(time.Now()))
+			defer untilTimer.Stop()
+			untilC = untilTimer.C
+		}
+
 		lg.Debug("begin logs")
 		for {
 			select {
+			case <-untilC:
+				lg.Debug("logs: end stream, until is reached")
+				return
 			// i do not believe as the system is currently designed any error
 			// is possible, but we should be prepared to handle it anyway. if
 			// we do get an error, copy only the error field to a new object so
@@ -0,0 +1,353 @@
+package daemon
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultMetricsContainerDimensions are the dimensions of the container
+// metrics when none are configured, which make each container a distinct
+// series.
+var defaultMetricsContainerDimensions = []string{"id", "name"}
+
+// metricsDimension is a label of the container metrics, computed from the
+// container.
+type metricsDimension struct {
+	name  string
+	value func(c *container.Container) string
+}
+
+func parseMetricsDimension(d string) (metricsDimension, error) {
+	if _, err := config.ValidateMetricsContainerDimension(d); err != nil {
+		return metricsDimension{}, err
+	}
+	name := config.MetricsContainerDimensionLabel(d)
+	switch d {
+	case "id":
+		return metricsDimension{name, func(c *container.Container) string { return c.ID }}, nil
+	case "name":
+		return metricsDimension{name, func(c *container.Container) string { return strings.TrimPrefix(c.Name, "/") }}, nil
+	case "image":
+		return metricsDimension{name, func(c *container.Container) string { return c.Config.Image }}, nil
+	}
+	key := strings.TrimPrefix(d, "label=")
+	return metricsDimension{name, func(c *container.Container) string { return c.Config.Labels[key] }}, nil
+}
+
+// containerMetrics is a prometheus collector exporting the resource usage,
+// restarts, health status and OOM events of the running containers. The
+// resource usage is the last one published by the stats collector, to which
+// the running containers are subscribed once the metrics are scraped, until
+// they stop. The values of the containers sharing the same dimensions are
+// summed up.
+type containerMetrics struct {
+	daemon     *Daemon
+	dimensions []metricsDimension
+
+	cpuUsage     *prometheus.Desc
+	memoryUsage  *prometheus.Desc
+	memoryLimit  *prometheus.Desc
+	blkioRead    *prometheus.Desc
+	blkioWrite   *prometheus.Desc
+	netRxBytes   *prometheus.Desc
+	netRxPackets *prometheus.Desc
+	netRxErrors  *prometheus.Desc
+	netRxDropped *prometheus.Desc
+	netTxBytes   *prometheus.Desc
+	netTxPackets *prometheus.Desc
+	netTxErrors  *prometheus.Desc
+	netTxDropped *prometheus.Desc
+	restarts     *prometheus.Desc
+	healthStatus *prometheus.Desc
+	oomEvents    *prometheus.Desc
+
+	mu    sync.Mutex
+	ooms  map[string]uint64          // number of OOM events by container ID
+	stats map[string]*containerStats // subscriptions to the stats collector by container ID
+}
+
+// containerStats is the subscription of the metrics to the statistics of a
+// container.
+type containerStats struct {
+	c    *container.Container
+	ch   chan interface{}
+	last *types.StatsJSON // nil until the first statistics are published
+}
+
+func newContainerMetrics(daemon *Daemon, dimensions []string) (*containerMetrics, error) {
+	if len(dimensions) == 0 {
+		dimensions = defaultMetricsContainerDimensions
+	}
+	if err := config.ValidateMetricsContainerDimensions(dimensions); err != nil {
+		return nil, err
+	}
+	m := &containerMetrics{
+		daemon: daemon,
+		ooms:   make(map[string]uint64),
+		stats:  make(map[string]*containerStats),
+	}
+	for _, d := range dimensions {
+		dim, err := parseMetricsDimension(d)
+		if err != nil {
+			return nil, err
+		}
+		m.dimensions = append(m.dimensions, dim)
+	}
+
+	m.cpuUsage = m.newDesc("cpu_usage_seconds_total", "The total CPU time consumed by the container")
+	m.memoryUsage = m.newDesc("memory_usage_bytes", "The memory used by the container")
+	m.memoryLimit = m.newDesc("memory_limit_bytes", "The memory limit of the container")
+	m.blkioRead = m.newDesc("blkio_read_bytes_total", "The number of bytes read by the container from block devices")
+	m.blkioWrite = m.newDesc("blkio_write_bytes_total", "The number of bytes written by the container to block devices")
+	m.netRxBytes = m.newDesc("network_receive_bytes_total", "The number of bytes received by the container", "interface")
+	m.netRxPackets = m.newDesc("network_receive_packets_total", "The number of packets received by the container", "interface")
+	m.netRxErrors = m.newDesc("network_receive_errors_total", "The number of errors while receiving packets", "interface")
+	m.netRxDropped = m.newDesc("network_receive_dropped_total", "The number of incoming packets dropped", "interface")
+	m.netTxBytes = m.newDesc("network_transmit_bytes_total", "The number of bytes transmitted by the container", "interface")
+	m.netTxPackets = m.newDesc("network_transmit_packets_total", "The number of packets transmitted by the container", "interface")
+	m.netTxErrors = m.newDesc("network_transmit_errors_total", "The number of errors while transmitting packets", "interface")
+	m.netTxDropped = m.newDesc("network_transmit_dropped_total", "The number of outgoing packets dropped", "interface")
+	m.restarts = m.newDesc("restarts_total", "The number of times the container has been restarted by its restart policy")
+	m.healthStatus = m.newDesc("health_status", "The number of containers whose health status is the one of the status label", "status")
+	m.oomEvents = m.newDesc("oom_events_total", "The number of OOM events of the container since the daemon started")
+	return m, nil
+}
+
+func (m *containerMetrics) newDesc(name, help string, labels ...string) *prometheus.Desc {
+	names := make([]string, 0, len(m.dimensions)+len(labels))
+	for _, d := range m.dimensions {
+		names = append(names, d.name)
+	}
+	names = append(names, labels...)
+	return prometheus.NewDesc("engine_daemon_container_"+name, help, names, nil)
+}
+
+// oomKilled records an OOM event of the container.
+func (m *containerMetrics) oomKilled(c *container.Container) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.ooms[c.ID]++
+	m.mu.Unlock()
+}
+
+// containerStopped unsubscribes the container from the stats collector, if
+// it was subscribed.
+func (m *containerMetrics) containerStopped(c *container.Container) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	s, ok := m.stats[c.ID]
+	delete(m.stats, c.ID)
+	m.mu.Unlock()
+	if ok {
+		m.daemon.unsubscribeToContainerStats(s.c, s.ch)
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *containerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		m.cpuUsage, m.memoryUsage, m.memoryLimit, m.blkioRead, m.blkioWrite,
+		m.netRxBytes, m.netRxPackets, m.netRxErrors, m.netRxDropped,
+		m.netTxBytes, m.netTxPackets, m.netTxErrors, m.netTxDropped,
+		m.restarts, m.healthStatus, m.oomEvents,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *containerMetrics) Collect(ch chan<- prometheus.Metric) {
+	containers := m.daemon.List()
+
+	var stopped []*containerStats
+	m.mu.Lock()
+	ooms := make(map[string]uint64, len(m.ooms))
+	existing := make(map[string]bool, len(containers))
+	running := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		existing[c.ID] = true
+		if c.IsRunning() {
+			running[c.ID] = true
+		}
+	}
+	for id, n := range m.ooms {
+		if !existing[id] {
+			// the container has been removed
+			delete(m.ooms, id)
+			continue
+		}
+		ooms[id] = n
+	}
+	for id, s := range m.stats {
+		if !running[id] {
+			stopped = append(stopped, s)
+			delete(m.stats, id)
+		}
+	}
+	stats := make(map[string]*types.StatsJSON, len(running))
+	for _, c := range containers {
+		if !running[c.ID] {
+			continue
+		}
+		s, ok := m.stats[c.ID]
+		if !ok {
+			s = &containerStats{c: c, ch: m.daemon.subscribeToContainerStats(c)}
+			m.stats[c.ID] = s
+			go m.receiveStats(s)
+		}
+		stats[c.ID] = s.last
+	}
+	m.mu.Unlock()
+
+	for _, s := range stopped {
+		m.daemon.unsubscribeToContainerStats(s.c, s.ch)
+	}
+
+	a := newMetricsAggregator()
+	for _, c := range containers {
+		if !running[c.ID] {
+			continue
+		}
+		c.Lock()
+		values := make([]string, len(m.dimensions))
+		for i, d := range m.dimensions {
+			values[i] = d.value(c)
+		}
+		restarts := c.RestartCount
+		var health string
+		if c.State.Health != nil {
+			health = c.State.Health.Status
+		}
+		c.Unlock()
+
+		a.add(m.restarts, prometheus.CounterValue, float64(restarts), values...)
+		a.add(m.oomEvents, prometheus.CounterValue, float64(ooms[c.ID]), values...)
+		if health != "" {
+			for _, status := range []string{types.Starting, types.Healthy, types.Unhealthy} {
+				var v float64
+				if status == health {
+					v = 1
+				}
+				a.add(m.healthStatus, prometheus.GaugeValue, v, append(values[:len(values):len(values)], status)...)
+			}
+		}
+
+		// the statistics of a newly subscribed container are only exported
+		// once published by the collector
+		if stats[c.ID] != nil {
+			m.collectStats(a, stats[c.ID], values)
+		}
+	}
+	a.collect(ch)
+}
+
+// receiveStats records the statistics published for the container, until
+// the subscription is closed.
+func (m *containerMetrics) receiveStats(s *containerStats) {
+	for v := range s.ch {
+		stats, ok := v.(types.StatsJSON)
+		if !ok {
+			continue
+		}
+		m.mu.Lock()
+		if stats.Read.IsZero() {
+			// the container is not running
+			s.last = nil
+		} else {
+			s.last = &stats
+		}
+		m.mu.Unlock()
+	}
+}
+
+func (m *containerMetrics) collectStats(a *metricsAggregator, stats *types.StatsJSON, values []string) {
+	cpuUsage := float64(stats.CPUStats.CPUUsage.TotalUsage)
+	if runtime.GOOS == "windows" {
+		// in 100ns intervals
+		cpuUsage *= 100
+	}
+	a.add(m.cpuUsage, prometheus.CounterValue, cpuUsage/1e9, values...)
+
+	memoryUsage := stats.MemoryStats.Usage
+	if memoryUsage == 0 {
+		memoryUsage = stats.MemoryStats.PrivateWorkingSet
+	}
+	a.add(m.memoryUsage, prometheus.GaugeValue, float64(memoryUsage), values...)
+	if stats.MemoryStats.Limit != 0 {
+		a.add(m.memoryLimit, prometheus.GaugeValue, float64(stats.MemoryStats.Limit), values...)
+	}
+
+	read, write := stats.StorageStats.ReadSizeBytes, stats.StorageStats.WriteSizeBytes
+	for _, e := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+	a.add(m.blkioRead, prometheus.CounterValue, float64(read), values...)
+	a.add(m.blkioWrite, prometheus.CounterValue, float64(write), values...)
+
+	for ifName, n := range stats.Networks {
+		labels := append(values[:len(values):len(values)], ifName)
+		a.add(m.netRxBytes, prometheus.CounterValue, float64(n.RxBytes), labels...)
+		a.add(m.netRxPackets, prometheus.CounterValue, float64(n.RxPackets), labels...)
+		a.add(m.netRxErrors, prometheus.CounterValue, float64(n.RxErrors), labels...)
+		a.add(m.netRxDropped, prometheus.CounterValue, float64(n.RxDropped), labels...)
+		a.add(m.netTxBytes, prometheus.CounterValue, float64(n.TxBytes), labels...)
+		a.add(m.netTxPackets, prometheus.CounterValue, float64(n.TxPackets), labels...)
+		a.add(m.netTxErrors, prometheus.CounterValue, float64(n.TxErrors), labels...)
+		a.add(m.netTxDropped, prometheus.CounterValue, float64(n.TxDropped), labels...)
+	}
+}
+
+// metricsAggregator sums up the values of the metrics sharing the same
+// label values, which are exported once.
+type metricsAggregator struct {
+	keys    []aggregatedKey
+	metrics map[aggregatedKey]*aggregatedMetric
+}
+
+type aggregatedKey struct {
+	desc   *prometheus.Desc
+	labels string
+}
+
+type aggregatedMetric struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	value     float64
+	labels    []string
+}
+
+func newMetricsAggregator() *metricsAggregator {
+	return &metricsAggregator{metrics: make(map[aggregatedKey]*aggregatedMetric)}
+}
+
+func (a *metricsAggregator) add(desc *prometheus.Desc, valueType prometheus.ValueType, value float64, labels ...string) {
+	key := aggregatedKey{desc, strings.Join(labels, "\x00")}
+	if metric, ok := a.metrics[key]; ok {
+		metric.value += value
+		return
+	}
+	a.keys = append(a.keys, key)
+	a.metrics[key] = &aggregatedMetric{desc: desc, valueType: valueType, value: value, labels: labels}
+}
+
+func (a *metricsAggregator) collect(ch chan<- prometheus.Metric) {
+	for _, key := range a.keys {
+		metric := a.metrics[key]
+		ch <- prometheus.MustNewConstMetric(metric.desc, metric.valueType, metric.value, metric.labels...)
+	}
+}
@@ -0,0 +1,241 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/stats"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestContainerMetricsDimensions(t *testing.T) {
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:   "container_id",
+			Name: "/container_name",
+			Config: &containertypes.Config{
+				Image:  "image_name",
+				Labels: map[string]string{"com.example.team": "web"},
+			},
+		},
+	}
+
+	m, err := newContainerMetrics(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.dimensions) != 2 || m.dimensions[0].name != "container_id" || m.dimensions[1].name != "container_name" {
+		t.Fatalf("Expected the default dimensions, got %v", m.dimensions)
+	}
+
+	m, err = newContainerMetrics(nil, []string{"name", "image", "label=com.example.team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the configured dimensions are labels
+	expected := []struct{ name, value string }{
+		{"container_name", "container_name"},
+		{"image", "image_name"},
+		{"container_label_com_example_team", "web"},
+	}
+	if len(m.dimensions) != len(expected) {
+		t.Fatalf("Expected %d dimensions, got %d", len(expected), len(m.dimensions))
+	}
+	for i, e := range expected {
+		d := m.dimensions[i]
+		if d.name != e.name || d.value(c) != e.value {
+			t.Fatalf("Expected dimension %s=%s, got %s=%s", e.name, e.value, d.name, d.value(c))
+		}
+	}
+
+	for _, dimensions := range [][]string{
+		{"command"},
+		{"name", "name"},
+		{"label=com.example.team", "label=com_example_team"},
+	} {
+		if _, err := newContainerMetrics(nil, dimensions); err == nil {
+			t.Fatalf("Expected an error for the dimensions %v", dimensions)
+		}
+	}
+}
+
+func TestContainerMetricsCollectStats(t *testing.T) {
+	m, err := newContainerMetrics(nil, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := &types.StatsJSON{
+		Stats: types.Stats{
+			MemoryStats: types.MemoryStats{Usage: 4096, Limit: 8192},
+			BlkioStats: types.BlkioStats{
+				IoServiceBytesRecursive: []types.BlkioStatEntry{
+					{Op: "Read", Value: 10},
+					{Op: "Write", Value: 20},
+					{Op: "Read", Value: 5},
+				},
+			},
+		},
+		Networks: map[string]types.NetworkStats{
+			"eth0": {RxBytes: 100, TxBytes: 200},
+		},
+	}
+	stats.CPUStats.CPUUsage.TotalUsage = 3e9
+
+	ch := make(chan prometheus.Metric, 100)
+	a := newMetricsAggregator()
+	m.collectStats(a, stats, []string{"container_id"})
+	a.collect(ch)
+	close(ch)
+
+	type key struct {
+		desc  *prometheus.Desc
+		iface string
+	}
+	values := make(map[key]float64)
+	for metric := range ch {
+		var out dto.Metric
+		if err := metric.Write(&out); err != nil {
+			t.Fatal(err)
+		}
+		k := key{desc: metric.Desc()}
+		for _, l := range out.Label {
+			if l.GetName() == "interface" {
+				k.iface = l.GetValue()
+			}
+		}
+		switch {
+		case out.Counter != nil:
+			values[k] = out.Counter.GetValue()
+		case out.Gauge != nil:
+			values[k] = out.Gauge.GetValue()
+		}
+	}
+
+	for k, expected := range map[key]float64{
+		{desc: m.cpuUsage}:       3,
+		{desc: m.memoryUsage}:    4096,
+		{desc: m.memoryLimit}:    8192,
+		{desc: m.blkioRead}:      15,
+		{desc: m.blkioWrite}:     20,
+		{m.netRxBytes, "eth0"}:   100,
+		{m.netTxBytes, "eth0"}:   200,
+		{m.netRxDropped, "eth0"}: 0,
+	} {
+		if v, ok := values[k]; !ok || v != expected {
+			t.Fatalf("Expected %v to be %v, got %v", k.desc, expected, v)
+		}
+	}
+}
+
+func TestContainerMetricsReceiveStats(t *testing.T) {
+	m, err := newContainerMetrics(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &containerStats{ch: make(chan interface{})}
+	done := make(chan struct{})
+	go func() {
+		m.receiveStats(s)
+		close(done)
+	}()
+
+	stats := types.StatsJSON{Stats: types.Stats{Read: time.Now(), MemoryStats: types.MemoryStats{Usage: 4096}}}
+	s.ch <- stats
+	// published when the container is not running
+	s.ch <- types.StatsJSON{ID: "container_id"}
+	s.ch <- stats
+	close(s.ch)
+	<-done
+
+	if s.last == nil || s.last.MemoryStats.Usage != 4096 {
+		t.Fatalf("Expected the last published statistics to be recorded, got %v", s.last)
+	}
+}
+
+func TestMetricsAggregator(t *testing.T) {
+	m, err := newContainerMetrics(nil, []string{"image"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the containers of the same image are a single series
+	a := newMetricsAggregator()
+	a.add(m.restarts, prometheus.CounterValue, 1, "image1")
+	a.add(m.restarts, prometheus.CounterValue, 2, "image1")
+	a.add(m.restarts, prometheus.CounterValue, 4, "image2")
+	a.add(m.oomEvents, prometheus.CounterValue, 8, "image1")
+	ch := make(chan prometheus.Metric, 10)
+	a.collect(ch)
+	close(ch)
+
+	values := make(map[string]float64)
+	for metric := range ch {
+		var out dto.Metric
+		if err := metric.Write(&out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Counter == nil {
+			t.Fatalf("Expected a counter, got %v", out)
+		}
+		values[metric.Desc().String()+out.Label[0].GetValue()] = out.Counter.GetValue()
+	}
+	for k, expected := range map[string]float64{
+		m.restarts.String() + "image1":  3,
+		m.restarts.String() + "image2":  4,
+		m.oomEvents.String() + "image1": 8,
+	} {
+		if v, ok := values[k]; !ok || v != expected {
+			t.Fatalf("Expected %s to be %v, got %v", k, expected, v)
+		}
+	}
+	if len(values) != 3 {
+		t.Fatalf("Expected 3 series, got %v", values)
+	}
+}
+
+func TestContainerMetricsStopped(t *testing.T) {
+	var m *containerMetrics
+	c := &container.Container{CommonContainer: container.CommonContainer{ID: "container_id"}}
+	// no-op when the metrics are not set up
+	m.containerStopped(c)
+
+	m, err := newContainerMetrics(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// no-op when the container is not subscribed
+	m.containerStopped(c)
+
+	d := &Daemon{statsCollector: stats.NewCollector(nil, time.Hour)}
+	m.daemon = d
+	s := &containerStats{c: c, ch: d.subscribeToContainerStats(c)}
+	m.stats[c.ID] = s
+	m.containerStopped(c)
+	if _, ok := m.stats[c.ID]; ok {
+		t.Fatal("Expected the container to be unsubscribed")
+	}
+	if _, ok := <-s.ch; ok {
+		t.Fatal("Expected the subscription to be closed")
+	}
+}
+
+func TestContainerMetricsOOM(t *testing.T) {
+	var m *containerMetrics
+	c := &container.Container{CommonContainer: container.CommonContainer{ID: "container_id"}}
+	// no-op when the metrics are not set up
+	m.oomKilled(c)
+
+	m, err := newContainerMetrics(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.oomKilled(c)
+	m.oomKilled(c)
+	if n := m.ooms[c.ID]; n != 2 {
+		t.Fatalf("Expected 2 OOM events, got %d", n)
+	}
+}
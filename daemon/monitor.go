@@ -27,6 +27,7 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 			return errors.New("Received StateOOM from libcontainerd on Windows. This should never happen.")
 		}
 		daemon.updateHealthMonitor(c)
+		daemon.containerMetrics.oomKilled(c)
 		daemon.LogContainerEvent(c, "oom")
 	case libcontainerd.StateExit:
 		// if container's AutoRemove flag is set, remove it after clean up
@@ -46,21 +47,29 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		c.Reset(false)
 
 		restart, wait, err := c.RestartManager().ShouldRestart(e.ExitCode, c.HasBeenManuallyStopped, time.Since(c.StartedAt))
+		var enteredCrashLoop bool
 		if err == nil && restart {
 			c.RestartCount++
 			c.SetRestarting(platformConstructExitStatus(e))
+			enteredCrashLoop = c.SetCrashLooping(c.RestartManager().IsCrashLooping())
 		} else {
 			c.SetStopped(platformConstructExitStatus(e))
+			c.SetCrashLooping(false)
 			defer autoRemove()
 		}
 
 		// cancel healthcheck here, they will be automatically
 		// restarted if/when the container is started again
 		daemon.stopHealthchecks(c)
+		daemon.containerMetrics.containerStopped(c)
 		attributes := map[string]string{
 			"exitCode": strconv.Itoa(int(e.ExitCode)),
 		}
 		daemon.LogContainerEventWithAttributes(c, "die", attributes)
+		if enteredCrashLoop {
+			attributes["restartCount"] = strconv.Itoa(c.RestartCount)
+			daemon.LogContainerEventWithAttributes(c, "die-loop", attributes)
+		}
 		daemon.Cleanup(c)
 
 		if err == nil && restart {
@@ -128,6 +128,10 @@ func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPrune
 		if len(daemon.referenceStore.References(dgst)) == 0 && len(daemon.imageStore.Children(id)) != 0 {
 			continue
 		}
+		// Build cache is pruned with BuildCachePrune.
+		if danglingOnly && daemon.isBuildCache(id) {
+			continue
+		}
 		if !until.IsZero() && img.Created.After(until) {
 			continue
 		}
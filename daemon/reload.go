@@ -17,6 +17,7 @@ import (
 // - Daemon debug log level
 // - Daemon max concurrent downloads
 // - Daemon max concurrent uploads
+// - Daemon max download and upload bandwidth
 // - Daemon shutdown timeout (in seconds)
 // - Cluster discovery (reconfigure and restart)
 // - Daemon labels
@@ -40,6 +41,7 @@ func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	daemon.reloadPlatform(conf, attributes)
 	daemon.reloadDebug(conf, attributes)
 	daemon.reloadMaxConcurrentDowloadsAndUploads(conf, attributes)
+	daemon.reloadMaxBandwidth(conf, attributes)
 	daemon.reloadShutdownTimeout(conf, attributes)
 
 	if err := daemon.reloadClusterDiscovery(conf, attributes); err != nil {
@@ -54,6 +56,9 @@ func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	if err := daemon.reloadRegistryMirrors(conf, attributes); err != nil {
 		return err
 	}
+	if err := daemon.reloadRegistryHostMirrors(conf, attributes); err != nil {
+		return err
+	}
 	if err := daemon.reloadLiveRestore(conf, attributes); err != nil {
 		return err
 	}
@@ -107,6 +112,34 @@ func (daemon *Daemon) reloadMaxConcurrentDowloadsAndUploads(conf *config.Config,
 	attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
 }
 
+// reloadMaxBandwidth updates configuration with max download and upload
+// bandwidth options and updates the passed attributes
+func (daemon *Daemon) reloadMaxBandwidth(conf *config.Config, attributes map[string]string) {
+	// If no value is set the bandwidth is not limited. As for the max
+	// concurrent downloads and uploads, we always "reset".
+	daemon.configStore.MaxDownloadBandwidth = 0
+	if conf.IsValueSet("max-download-bandwidth") {
+		daemon.configStore.MaxDownloadBandwidth = conf.MaxDownloadBandwidth
+	}
+	logrus.Debugf("Reset Max Download Bandwidth: %d", daemon.configStore.MaxDownloadBandwidth)
+	if daemon.downloadManager != nil {
+		daemon.downloadManager.SetBandwidthLimit(daemon.configStore.MaxDownloadBandwidth.Value())
+	}
+
+	daemon.configStore.MaxUploadBandwidth = 0
+	if conf.IsValueSet("max-upload-bandwidth") {
+		daemon.configStore.MaxUploadBandwidth = conf.MaxUploadBandwidth
+	}
+	logrus.Debugf("Reset Max Upload Bandwidth: %d", daemon.configStore.MaxUploadBandwidth)
+	if daemon.uploadManager != nil {
+		daemon.uploadManager.SetBandwidthLimit(daemon.configStore.MaxUploadBandwidth.Value())
+	}
+
+	// prepare reload event attributes with updatable configurations
+	attributes["max-download-bandwidth"] = fmt.Sprintf("%d", daemon.configStore.MaxDownloadBandwidth)
+	attributes["max-upload-bandwidth"] = fmt.Sprintf("%d", daemon.configStore.MaxUploadBandwidth)
+}
+
 // reloadShutdownTimeout updates configuration with daemon shutdown timeout option
 // and updates the passed attributes
 func (daemon *Daemon) reloadShutdownTimeout(conf *config.Config, attributes map[string]string) {
@@ -267,6 +300,31 @@ func (daemon *Daemon) reloadRegistryMirrors(conf *config.Config, attributes map[
 	return nil
 }
 
+// reloadRegistryHostMirrors updates configuration with the mirrors of the
+// registries other than Docker Hub, and updates the passed attributes
+func (daemon *Daemon) reloadRegistryHostMirrors(conf *config.Config, attributes map[string]string) error {
+	// update corresponding configuration
+	if conf.IsValueSet("registry-host-mirrors") {
+		daemon.configStore.HostMirrors = conf.HostMirrors
+		if err := daemon.RegistryService.LoadHostMirrors(conf.HostMirrors); err != nil {
+			return err
+		}
+	}
+
+	// prepare reload event attributes with updatable configurations
+	if daemon.configStore.HostMirrors != nil {
+		mirrors, err := json.Marshal(daemon.configStore.HostMirrors)
+		if err != nil {
+			return err
+		}
+		attributes["registry-host-mirrors"] = string(mirrors)
+	} else {
+		attributes["registry-host-mirrors"] = "{}"
+	}
+
+	return nil
+}
+
 // reloadLiveRestore updates configuration with live retore option
 // and updates the passed attributes
 func (daemon *Daemon) reloadLiveRestore(conf *config.Config, attributes map[string]string) error {
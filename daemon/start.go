@@ -192,6 +192,7 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		return fmt.Errorf("%s", errDesc)
 	}
 
+	daemon.markImageUsed(container.ImageID)
 	containerActions.WithValues("start").UpdateSince(start)
 
 	return nil
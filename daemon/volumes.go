@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
+	volumestore "github.com/docker/docker/volume/store"
 )
 
 var (
@@ -40,6 +41,43 @@ func volumeToAPIType(v volume.Volume) *types.Volume {
 	return tv
 }
 
+// volumeUsage returns the usage data of a volume. The disk usage is cached by
+// the volume store, and only determined again if refresh is set. It is -1 for
+// volumes whose driver cannot determine it.
+func (daemon *Daemon) volumeUsage(v volume.Volume, refresh bool) *types.VolumeUsageData {
+	usageData := &types.VolumeUsageData{
+		Size:     -1,
+		Inodes:   -1,
+		RefCount: int64(len(daemon.volumes.Refs(v))),
+	}
+	usage, err := daemon.volumes.Usage(v, refresh)
+	if err != nil {
+		if !volumestore.IsNotSupported(err) {
+			logrus.Warnf("failed to determine usage of volume %v: %v", v.Name(), err)
+		}
+		return usageData
+	}
+	usageData.Size = usage.Size
+	usageData.Inodes = usage.Inodes
+	return usageData
+}
+
+// cachedVolumeUsage returns the usage data of a volume like volumeUsage, but
+// never determines the disk usage. It is -1 for volumes whose disk usage is
+// not cached yet, so that listing the volumes doesn't walk all of them.
+func (daemon *Daemon) cachedVolumeUsage(v volume.Volume) *types.VolumeUsageData {
+	usageData := &types.VolumeUsageData{
+		Size:     -1,
+		Inodes:   -1,
+		RefCount: int64(len(daemon.volumes.Refs(v))),
+	}
+	if usage, cached := daemon.volumes.CachedUsage(v); cached {
+		usageData.Size = usage.Size
+		usageData.Inodes = usage.Inodes
+	}
+	return usageData
+}
+
 // Len returns the number of mounts. Used in sorting.
 func (m mounts) Len() int {
 	return len(m)
@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"io"
+	"net/http"
+
+	apierrors "github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stringid"
+	volumestore "github.com/docker/docker/volume/store"
+)
+
+// VolumeClone creates a volume with the given name and labels, holding a
+// copy of the data of the volume with the name source.
+// This is called directly from the Engine API
+func (daemon *Daemon) VolumeClone(source, name string, labels map[string]string) (*types.Volume, error) {
+	if name == "" {
+		name = stringid.GenerateNonCryptoID()
+	}
+
+	v, err := daemon.volumes.Clone(source, name, labels)
+	if err != nil {
+		return nil, volumeArchiveError(err)
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "create", map[string]string{"driver": v.DriverName(), "source": source})
+	apiV := volumeToAPIType(v)
+	apiV.Mountpoint = v.Path()
+	return apiV, nil
+}
+
+// VolumeExport returns a tar archive of the data of the volume with the
+// given name. The volume cannot be removed until the archive is closed.
+// This is called directly from the Engine API
+func (daemon *Daemon) VolumeExport(name string) (io.ReadCloser, error) {
+	rc, err := daemon.volumes.Export(name)
+	if err != nil {
+		return nil, volumeArchiveError(err)
+	}
+	return rc, nil
+}
+
+// VolumeImport extracts the given tar archive into the volume with the
+// given name.
+// This is called directly from the Engine API
+func (daemon *Daemon) VolumeImport(name string, data io.Reader) error {
+	if err := daemon.volumes.Import(name, data); err != nil {
+		return volumeArchiveError(err)
+	}
+	return nil
+}
+
+func volumeArchiveError(err error) error {
+	switch {
+	case volumestore.IsNameConflict(err):
+		return apierrors.NewRequestConflictError(err)
+	case volumestore.IsNotSupported(err):
+		return apierrors.NewErrorWithStatusCode(err, http.StatusNotImplemented)
+	}
+	return err
+}
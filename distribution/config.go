@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	refstore "github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
@@ -47,6 +48,10 @@ type Config struct {
 	ReferenceStore refstore.Store
 	// RequireSchema2 ensures that only schema2 manifests are used.
 	RequireSchema2 bool
+	// MaxBandwidth is the max number of bytes per second transferred by
+	// the layer downloads or uploads of the operation, on top of the limit
+	// of the download or upload manager. It is not limited if zero.
+	MaxBandwidth int64
 }
 
 // ImagePullConfig stores pull configuration.
@@ -58,6 +63,10 @@ type ImagePullConfig struct {
 	// Schema2Types is the valid schema2 configuration types allowed
 	// by the pull operation.
 	Schema2Types []string
+	// DownloadDir is the directory the partial downloads of the layers
+	// are kept in, by digest, to resume them on a later pull. The partial
+	// downloads are discarded if it is empty.
+	DownloadDir string
 }
 
 // ImagePushConfig stores push configuration.
@@ -74,6 +83,10 @@ type ImagePushConfig struct {
 	TrustKey libtrust.PrivateKey
 	// UploadManager dispatches uploads.
 	UploadManager *xfer.LayerUploadManager
+	// LayerCompression is the compression of the pushed layers, either
+	// archive.Gzip or archive.Zstd. The layers are compressed with gzip
+	// if it is not set.
+	LayerCompression archive.Compression
 }
 
 // ImageConfigStore handles storing and getting image configurations
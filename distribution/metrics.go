@@ -0,0 +1,88 @@
+package distribution
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/docker/go-metrics"
+)
+
+var (
+	registryRequests      metrics.LabeledTimer
+	registryReceivedBytes metrics.LabeledCounter
+	registrySentBytes     metrics.LabeledCounter
+)
+
+func init() {
+	ns := metrics.NewNamespace("engine", "daemon", nil)
+	registryRequests = ns.NewLabeledTimer("registry_requests", "The number of seconds it takes a registry to respond to each request", "registry")
+	registryReceivedBytes = ns.NewLabeledCounter("registry_received_bytes", "The number of bytes received from each registry", "registry")
+	registrySentBytes = ns.NewLabeledCounter("registry_sent_bytes", "The number of bytes sent to each registry", "registry")
+	metrics.Register(ns)
+}
+
+// meteredTransport records the time it takes the registry to respond to
+// each request, up to the response headers.
+type meteredTransport struct {
+	base     http.RoundTripper
+	registry string
+}
+
+func (t *meteredTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		registryRequests.WithValues(t.registry).UpdateSince(start)
+	}
+	return resp, err
+}
+
+// CancelRequest cancels an in-flight request, if the base transport
+// supports it.
+func (t *meteredTransport) CancelRequest(req *http.Request) {
+	type canceler interface {
+		CancelRequest(*http.Request)
+	}
+	if cr, ok := t.base.(canceler); ok {
+		cr.CancelRequest(req)
+	}
+}
+
+// meteredDial returns a dial function counting the bytes sent and received
+// on the connections opened with dial.
+func meteredDial(dial func(network, addr string) (net.Conn, error), registry string) func(network, addr string) (net.Conn, error) {
+	return func(network, addr string) (net.Conn, error) {
+		conn, err := dial(network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &meteredConn{
+			Conn:     conn,
+			received: registryReceivedBytes.WithValues(registry),
+			sent:     registrySentBytes.WithValues(registry),
+		}, nil
+	}
+}
+
+type meteredConn struct {
+	net.Conn
+	received metrics.Counter
+	sent     metrics.Counter
+}
+
+func (c *meteredConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.received.Inc(float64(n))
+	}
+	return n, err
+}
+
+func (c *meteredConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	if n > 0 {
+		c.sent.Inc(float64(n))
+	}
+	return n, err
+}
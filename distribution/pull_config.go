@@ -0,0 +1,190 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/registry"
+	"golang.org/x/net/context"
+)
+
+// RemoteImage is an image of a registry whose config was pulled without its
+// layers, for the layers to be pulled when they are needed.
+type RemoteImage struct {
+	// Config is the image config.
+	Config []byte
+
+	descriptors     []xfer.DownloadDescriptor
+	downloadManager RootFSDownloadManager
+}
+
+// PullImageConfig pulls the config of the image referenced by ref from a v2
+// registry, without pulling its layers. Only the images with a schema2
+// manifest have a config, the v1 endpoints are skipped.
+func PullImageConfig(ctx context.Context, ref reference.Named, imagePullConfig *ImagePullConfig) (*RemoteImage, error) {
+	if reference.IsNameOnly(ref) {
+		return nil, fmt.Errorf("reference has neither a tag nor a digest: %s", reference.FamiliarString(ref))
+	}
+
+	// Resolve the Repository name from fqn to RepositoryInfo
+	repoInfo, err := imagePullConfig.RegistryService.ResolveRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// makes sure name is not `scratch`
+	if err := ValidateRepoName(repoInfo.Name); err != nil {
+		return nil, err
+	}
+
+	endpoints, err := imagePullConfig.RegistryService.LookupPullEndpoints(reference.Domain(repoInfo.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, endpoint := range endpoints {
+		if endpoint.Version != registry.APIVersion2 {
+			continue
+		}
+
+		logrus.Debugf("Trying to pull the config of %s from %s %s", reference.FamiliarString(ref), endpoint.URL, endpoint.Version)
+
+		p := &v2Puller{
+			V2MetadataService: metadata.NewV2MetadataService(imagePullConfig.MetadataStore),
+			endpoint:          endpoint,
+			config:            imagePullConfig,
+			repoInfo:          repoInfo,
+		}
+		remoteImage, err := p.pullConfig(ctx, ref)
+		if err == nil {
+			return remoteImage, nil
+		}
+		lastErr = err
+
+		// Was this pull cancelled? If so, don't try the next endpoint.
+		select {
+		case <-ctx.Done():
+			return nil, err
+		default:
+		}
+		logrus.Debugf("Error pulling the config of %s from %s: %v", reference.FamiliarString(ref), endpoint.URL, err)
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no v2 endpoint to pull the config of %s from", reference.FamiliarString(ref))
+	}
+	return nil, lastErr
+}
+
+func (p *v2Puller) pullConfig(ctx context.Context, ref reference.Named) (*RemoteImage, error) {
+	var err error
+	p.repo, p.confirmedV2, err = NewV2Repository(ctx, p.repoInfo, p.endpoint, p.config.MetaHeaders, p.config.AuthConfig, "pull")
+	if err != nil {
+		return nil, err
+	}
+
+	manSvc, err := p.repo.Manifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest distribution.Manifest
+	if tagged, isTagged := ref.(reference.NamedTagged); isTagged {
+		manifest, err = manSvc.Get(ctx, "", distribution.WithTag(tagged.Tag()))
+	} else if digested, isDigested := ref.(reference.Canonical); isDigested {
+		manifest, err = manSvc.Get(ctx, digested.Digest())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mfstList, ok := manifest.(*manifestlist.DeserializedManifestList); ok {
+		manifestDigest, err := platformManifestDigest(mfstList)
+		if err != nil {
+			return nil, err
+		}
+		if manifest, err = manSvc.Get(ctx, manifestDigest); err != nil {
+			return nil, err
+		}
+	}
+
+	mfst, ok := manifest.(*schema2.DeserializedManifest)
+	if !ok {
+		return nil, fmt.Errorf("the manifest of %s has no image config", reference.FamiliarString(ref))
+	}
+	if err := p.checkConfigMediaType(mfst); err != nil {
+		return nil, err
+	}
+
+	configJSON, err := p.pullSchema2Config(ctx, mfst.Target().Digest)
+	if err != nil {
+		return nil, ImageConfigPullError{Err: err}
+	}
+
+	configRootFS, err := p.config.ImageStore.RootFSFromConfig(configJSON)
+	if err != nil {
+		return nil, err
+	}
+	if configRootFS == nil {
+		return nil, errRootFSInvalid
+	}
+	if len(configRootFS.DiffIDs) != len(mfst.Layers) {
+		return nil, errRootFSMismatch
+	}
+
+	var descriptors []xfer.DownloadDescriptor
+	for _, d := range mfst.Layers {
+		descriptors = append(descriptors, &v2LayerDescriptor{
+			digest:            d.Digest,
+			repo:              p.repo,
+			repoInfo:          p.repoInfo,
+			V2MetadataService: p.V2MetadataService,
+			src:               d,
+			downloadDir:       p.config.DownloadDir,
+		})
+	}
+
+	return &RemoteImage{
+		Config:          configJSON,
+		descriptors:     descriptors,
+		downloadManager: p.config.DownloadManager,
+	}, nil
+}
+
+// PullLayers registers the layers of rootFS, the first layers of the image,
+// in the layer store. The layers of parent, the first layers of rootFS, must
+// already be present. The other layers are only downloaded if they are not
+// already present. The caller must call the returned release function once it
+// is done with the layers.
+func (ri *RemoteImage) PullLayers(ctx context.Context, parent, rootFS image.RootFS, progressOutput progress.Output) (func(), error) {
+	if len(parent.DiffIDs) > len(rootFS.DiffIDs) || len(rootFS.DiffIDs) > len(ri.descriptors) {
+		return nil, errRootFSMismatch
+	}
+
+	downloadedRootFS, release, err := ri.downloadManager.Download(ctx, parent, ri.descriptors[len(parent.DiffIDs):len(rootFS.DiffIDs)], progressOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	// The DiffIDs of the registered layers MUST match those of rootFS.
+	if len(downloadedRootFS.DiffIDs) != len(rootFS.DiffIDs) {
+		release()
+		return nil, errRootFSMismatch
+	}
+	for i := range downloadedRootFS.DiffIDs {
+		if downloadedRootFS.DiffIDs[i] != rootFS.DiffIDs[i] {
+			release()
+			return nil, errRootFSMismatch
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,78 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/net/context"
+)
+
+// fakeDownloadManager registers each layer with the digest of its descriptor
+// as DiffID, or with the digest of wrong if set.
+type fakeDownloadManager struct {
+	downloaded []xfer.DownloadDescriptor
+	released   int
+	wrong      digest.Digest
+}
+
+func (dm *fakeDownloadManager) Download(ctx context.Context, initialRootFS image.RootFS, layers []xfer.DownloadDescriptor, progressOutput progress.Output) (image.RootFS, func(), error) {
+	dm.downloaded = append(dm.downloaded, layers...)
+	rootFS := initialRootFS
+	rootFS.DiffIDs = append([]layer.DiffID(nil), initialRootFS.DiffIDs...)
+	for _, l := range layers {
+		d := l.(*v2LayerDescriptor).digest
+		if dm.wrong != "" {
+			d = dm.wrong
+		}
+		rootFS.Append(layer.DiffID(d))
+	}
+	return rootFS, func() { dm.released++ }, nil
+}
+
+func TestRemoteImagePullLayers(t *testing.T) {
+	digests := []digest.Digest{digest.FromString("a"), digest.FromString("b"), digest.FromString("c")}
+	dm := &fakeDownloadManager{}
+	ri := &RemoteImage{downloadManager: dm}
+	for _, d := range digests {
+		ri.descriptors = append(ri.descriptors, &v2LayerDescriptor{digest: d})
+	}
+
+	parent := *image.NewRootFS()
+	parent.Append(layer.DiffID(digests[0]))
+	rootFS := *image.NewRootFS()
+	rootFS.Append(layer.DiffID(digests[0]))
+	rootFS.Append(layer.DiffID(digests[1]))
+
+	// Only the layer on top of parent is downloaded, not the following ones.
+	release, err := ri.PullLayers(context.Background(), parent, rootFS, progress.DiscardOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dm.downloaded) != 1 || dm.downloaded[0] != ri.descriptors[1] {
+		t.Fatalf("expected only the second layer to be downloaded, got %v", dm.downloaded)
+	}
+	release()
+	if dm.released != 1 {
+		t.Fatal("expected the layers to be released")
+	}
+
+	// The layers are released when they don't match rootFS.
+	dm.wrong = digests[2]
+	if _, err := ri.PullLayers(context.Background(), parent, rootFS, progress.DiscardOutput()); err != errRootFSMismatch {
+		t.Fatalf("expected %v, got %v", errRootFSMismatch, err)
+	}
+	if dm.released != 2 {
+		t.Fatal("expected the mismatching layers to be released")
+	}
+
+	// rootFS can't have more layers than the image.
+	rootFS.Append(layer.DiffID(digests[2]))
+	rootFS.Append(layer.DiffID(digests[2]))
+	if _, err := ri.PullLayers(context.Background(), parent, rootFS, progress.DiscardOutput()); err != errRootFSMismatch {
+		t.Fatalf("expected %v, got %v", errRootFSMismatch, err)
+	}
+}
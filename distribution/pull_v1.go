@@ -325,7 +325,7 @@ func (ld *v1LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	reader := progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, layerReader), progressOutput, ld.layerSize, ld.ID(), "Downloading")
 	defer reader.Close()
 
-	_, err = io.Copy(ld.tmpFile, reader)
+	_, err = io.Copy(ld.tmpFile, xfer.LimitReader(ctx, reader))
 	if err != nil {
 		ld.Close()
 		return nil, 0, err
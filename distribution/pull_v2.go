@@ -8,7 +8,9 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution"
@@ -137,6 +139,7 @@ type v2LayerDescriptor struct {
 	tmpFile           *os.File
 	verifier          digest.Verifier
 	src               distribution.Descriptor
+	downloadDir       string
 }
 
 func (ld *v2LayerDescriptor) Key() string {
@@ -160,7 +163,7 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 	)
 
 	if ld.tmpFile == nil {
-		ld.tmpFile, err = createDownloadFile()
+		ld.tmpFile, offset, err = ld.openDownloadFile()
 		if err != nil {
 			return nil, 0, xfer.DoNotRetry{Err: err}
 		}
@@ -174,7 +177,8 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 			if err := os.Remove(ld.tmpFile.Name()); err != nil {
 				logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
 			}
-			ld.tmpFile, err = createDownloadFile()
+			ld.verifier = nil
+			ld.tmpFile, offset, err = ld.openDownloadFile()
 			if err != nil {
 				return nil, 0, xfer.DoNotRetry{Err: err}
 			}
@@ -231,15 +235,19 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 		ld.verifier = ld.digest.Verifier()
 	}
 
-	_, err = io.Copy(tmpFile, io.TeeReader(reader, ld.verifier))
-	if err != nil {
-		if err == transport.ErrWrongCodeForByteRange {
-			if err := ld.truncateDownloadFile(); err != nil {
-				return nil, 0, xfer.DoNotRetry{Err: err}
+	// A partial download of a previous pull may already hold the whole
+	// blob, in which case there is nothing left to download.
+	if size == 0 || offset < size {
+		_, err = io.Copy(tmpFile, io.TeeReader(xfer.LimitReader(ctx, reader), ld.verifier))
+		if err != nil {
+			if err == transport.ErrWrongCodeForByteRange {
+				if err := ld.truncateDownloadFile(); err != nil {
+					return nil, 0, xfer.DoNotRetry{Err: err}
+				}
+				return nil, 0, err
 			}
-			return nil, 0, err
+			return nil, 0, retryOnError(err)
 		}
-		return nil, 0, retryOnError(err)
 	}
 
 	progress.Update(progressOutput, ld.ID(), "Verifying Checksum")
@@ -257,6 +265,10 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 
 			return nil, 0, err
 		}
+		// Do not keep the corrupted download for the next pull.
+		if err := ld.truncateDownloadFile(); err != nil {
+			logrus.Debugf("error truncating corrupted download of %q: %v", ld.digest, err)
+		}
 		return nil, 0, xfer.DoNotRetry{Err: err}
 	}
 
@@ -291,6 +303,14 @@ func (ld *v2LayerDescriptor) Download(ctx context.Context, progressOutput progre
 
 func (ld *v2LayerDescriptor) Close() {
 	if ld.tmpFile != nil {
+		if ld.downloadDir != "" {
+			// keep the partial download to resume it on the next pull
+			if fi, err := ld.tmpFile.Stat(); err == nil && fi.Size() > 0 {
+				logrus.Debugf("Keeping partial download of %s in %s", ld.digest, ld.tmpFile.Name())
+				ld.tmpFile.Close()
+				return
+			}
+		}
 		ld.tmpFile.Close()
 		if err := os.RemoveAll(ld.tmpFile.Name()); err != nil {
 			logrus.Errorf("Failed to remove temp file: %s", ld.tmpFile.Name())
@@ -298,6 +318,64 @@ func (ld *v2LayerDescriptor) Close() {
 	}
 }
 
+// openDownloadFile opens the file the blob is downloaded to, and returns the
+// number of bytes already downloaded. If the downloads are kept in a
+// directory, the partial download of a previous pull is resumed, hashing the
+// bytes already downloaded to verify the digest of the complete blob.
+func (ld *v2LayerDescriptor) openDownloadFile() (*os.File, int64, error) {
+	if ld.downloadDir == "" {
+		f, err := createDownloadFile()
+		return f, 0, err
+	}
+
+	dir := filepath.Join(ld.downloadDir, ld.digest.Algorithm().String())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, 0, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, ld.digest.Hex()), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, 0, err
+	}
+	ld.tmpFile = f
+
+	verifier := ld.digest.Verifier()
+	offset, err := io.Copy(verifier, f)
+	if err != nil {
+		logrus.Debugf("error reading partial download of %q: %v", ld.digest, err)
+		if err := ld.truncateDownloadFile(); err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		return f, 0, nil
+	}
+	if offset != 0 {
+		logrus.Debugf("attempting to resume download of %q from %d bytes of a previous pull", ld.digest, offset)
+	}
+	ld.verifier = verifier
+	return f, offset, nil
+}
+
+// RemoveStaleDownloads removes the partial downloads of the layers which were
+// not resumed for longer than maxAge from the download directory of the
+// pulls.
+func RemoveStaleDownloads(dir string, maxAge time.Duration) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() && time.Since(info.ModTime()) > maxAge {
+			logrus.Debugf("Removing stale partial download %s", path)
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ld *v2LayerDescriptor) truncateDownloadFile() error {
 	// Need a new hash context since we will be redoing the download
 	ld.verifier = nil
@@ -317,7 +395,11 @@ func (ld *v2LayerDescriptor) truncateDownloadFile() error {
 
 func (ld *v2LayerDescriptor) Registered(diffID layer.DiffID) {
 	// Cache mapping from this layer's DiffID to the blobsum
-	ld.V2MetadataService.Add(diffID, metadata.V2Metadata{Digest: ld.digest, SourceRepository: ld.repoInfo.Name.Name()})
+	meta := metadata.V2Metadata{Digest: ld.digest, SourceRepository: ld.repoInfo.Name.Name()}
+	if ld.src.MediaType == MediaTypeZstdLayer {
+		meta.MediaType = MediaTypeZstdLayer
+	}
+	ld.V2MetadataService.Add(diffID, meta)
 }
 
 func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdated bool, err error) {
@@ -351,19 +433,8 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdat
 	}
 
 	if m, ok := manifest.(*schema2.DeserializedManifest); ok {
-		var allowedMediatype bool
-		for _, t := range p.config.Schema2Types {
-			if m.Manifest.Config.MediaType == t {
-				allowedMediatype = true
-				break
-			}
-		}
-		if !allowedMediatype {
-			configClass := mediaTypeClasses[m.Manifest.Config.MediaType]
-			if configClass == "" {
-				configClass = "unknown"
-			}
-			return false, fmt.Errorf("Encountered remote %q(%s) when fetching", m.Manifest.Config.MediaType, configClass)
+		if err := p.checkConfigMediaType(m); err != nil {
+			return false, err
 		}
 	}
 
@@ -430,6 +501,21 @@ func (p *v2Puller) pullV2Tag(ctx context.Context, ref reference.Named) (tagUpdat
 	return true, nil
 }
 
+// checkConfigMediaType returns an error if the media type of the config of
+// the manifest is not one of the schema2 types allowed by the pull.
+func (p *v2Puller) checkConfigMediaType(m *schema2.DeserializedManifest) error {
+	for _, t := range p.config.Schema2Types {
+		if m.Manifest.Config.MediaType == t {
+			return nil
+		}
+	}
+	configClass := mediaTypeClasses[m.Manifest.Config.MediaType]
+	if configClass == "" {
+		configClass = "unknown"
+	}
+	return fmt.Errorf("Encountered remote %q(%s) when fetching", m.Manifest.Config.MediaType, configClass)
+}
+
 func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Named, unverifiedManifest *schema1.SignedManifest) (id digest.Digest, manifestDigest digest.Digest, err error) {
 	var verifiedManifest *schema1.Manifest
 	verifiedManifest, err = verifySchema1Manifest(unverifiedManifest, ref)
@@ -477,6 +563,7 @@ func (p *v2Puller) pullSchema1(ctx context.Context, ref reference.Named, unverif
 			repoInfo:          p.repoInfo,
 			repo:              p.repo,
 			V2MetadataService: p.V2MetadataService,
+			downloadDir:       p.config.DownloadDir,
 		}
 
 		descriptors = append(descriptors, layerDescriptor)
@@ -527,6 +614,7 @@ func (p *v2Puller) pullSchema2(ctx context.Context, ref reference.Named, mfst *s
 			repoInfo:          p.repoInfo,
 			V2MetadataService: p.V2MetadataService,
 			src:               d,
+			downloadDir:       p.config.DownloadDir,
 		}
 
 		descriptors = append(descriptors, layerDescriptor)
@@ -673,22 +761,9 @@ func (p *v2Puller) pullManifestList(ctx context.Context, ref reference.Named, mf
 	}
 
 	logrus.Debugf("%s resolved to a manifestList object with %d entries; looking for a os/arch match", ref, len(mfstList.Manifests))
-	var manifestDigest digest.Digest
-	for _, manifestDescriptor := range mfstList.Manifests {
-		// TODO(aaronl): The manifest list spec supports optional
-		// "features" and "variant" fields. These are not yet used.
-		// Once they are, their values should be interpreted here.
-		if manifestDescriptor.Platform.Architecture == runtime.GOARCH && manifestDescriptor.Platform.OS == runtime.GOOS {
-			manifestDigest = manifestDescriptor.Digest
-			logrus.Debugf("found match for %s/%s with media type %s, digest %s", runtime.GOOS, runtime.GOARCH, manifestDescriptor.MediaType, manifestDigest.String())
-			break
-		}
-	}
-
-	if manifestDigest == "" {
-		errMsg := fmt.Sprintf("no matching manifest for %s/%s in the manifest list entries", runtime.GOOS, runtime.GOARCH)
-		logrus.Debugf(errMsg)
-		return "", "", errors.New(errMsg)
+	manifestDigest, err := platformManifestDigest(mfstList)
+	if err != nil {
+		return "", "", err
 	}
 
 	manSvc, err := p.repo.Manifests(ctx)
@@ -724,6 +799,24 @@ func (p *v2Puller) pullManifestList(ctx context.Context, ref reference.Named, mf
 	return id, manifestListDigest, err
 }
 
+// platformManifestDigest returns the digest of the manifest of a manifest
+// list matching the platform of the daemon.
+func platformManifestDigest(mfstList *manifestlist.DeserializedManifestList) (digest.Digest, error) {
+	for _, manifestDescriptor := range mfstList.Manifests {
+		// TODO(aaronl): The manifest list spec supports optional
+		// "features" and "variant" fields. These are not yet used.
+		// Once they are, their values should be interpreted here.
+		if manifestDescriptor.Platform.Architecture == runtime.GOARCH && manifestDescriptor.Platform.OS == runtime.GOOS {
+			logrus.Debugf("found match for %s/%s with media type %s, digest %s", runtime.GOOS, runtime.GOARCH, manifestDescriptor.MediaType, manifestDescriptor.Digest.String())
+			return manifestDescriptor.Digest, nil
+		}
+	}
+
+	errMsg := fmt.Sprintf("no matching manifest for %s/%s in the manifest list entries", runtime.GOOS, runtime.GOARCH)
+	logrus.Debugf(errMsg)
+	return "", errors.New(errMsg)
+}
+
 func (p *v2Puller) pullSchema2Config(ctx context.Context, dgst digest.Digest) (configJSON []byte, err error) {
 	blobs := p.repo.Blobs(ctx)
 	configJSON, err = blobs.Get(ctx, dgst)
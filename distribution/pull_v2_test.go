@@ -1,15 +1,22 @@
 package distribution
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/pkg/progress"
 	"github.com/opencontainers/go-digest"
 )
 
@@ -181,3 +188,165 @@ func TestValidateManifest(t *testing.T) {
 		t.Fatal("expected validateManifest to fail with digest error")
 	}
 }
+
+type blobRepository struct {
+	distribution.Repository
+	blobs *blobStore
+}
+
+func (r *blobRepository) Blobs(ctx context.Context) distribution.BlobStore {
+	return r.blobs
+}
+
+// blobStore serves a single blob, recording the offset the blob was first
+// read from.
+type blobStore struct {
+	distribution.BlobStore
+	data       []byte
+	readOffset int64
+}
+
+func (s *blobStore) Open(ctx context.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error) {
+	return &blobReader{r: bytes.NewReader(s.data), store: s}, nil
+}
+
+type blobReader struct {
+	r     *bytes.Reader
+	store *blobStore
+	read  bool
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	if !r.read {
+		r.read = true
+		r.store.readOffset = r.r.Size() - int64(r.r.Len())
+	}
+	return r.r.Read(p)
+}
+
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	return r.r.Seek(offset, whence)
+}
+
+func (r *blobReader) Close() error {
+	return nil
+}
+
+func TestDownloadResumesPartialDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pull-download-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 256)
+	dgst := digest.FromBytes(blob)
+	partialPath := filepath.Join(dir, dgst.Algorithm().String(), dgst.Hex())
+	if err := os.MkdirAll(filepath.Dir(partialPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(partialPath, blob[:1000], 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &blobStore{data: blob, readOffset: -1}
+	ld := &v2LayerDescriptor{
+		digest:      dgst,
+		repo:        &blobRepository{blobs: store},
+		downloadDir: dir,
+	}
+	rc, _, err := ld.Download(context.Background(), progress.DiscardOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(rc)
+	rc.Close()
+	ld.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, blob) {
+		t.Fatal("downloaded blob does not match")
+	}
+	if store.readOffset != 1000 {
+		t.Fatalf("expected the download to resume from 1000 bytes, got %d", store.readOffset)
+	}
+	if _, err := os.Stat(partialPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the completed download to be removed, got %v", err)
+	}
+}
+
+func TestDownloadDiscardsCorruptedPartialDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pull-download-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 256)
+	dgst := digest.FromBytes(blob)
+	partialPath := filepath.Join(dir, dgst.Algorithm().String(), dgst.Hex())
+	if err := os.MkdirAll(filepath.Dir(partialPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(partialPath, bytes.Repeat([]byte("x"), 1000), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ld := &v2LayerDescriptor{
+		digest:      dgst,
+		repo:        &blobRepository{blobs: &blobStore{data: blob}},
+		downloadDir: dir,
+	}
+	// The resumed download fails verification and is retried from the
+	// start.
+	if _, _, err := ld.Download(context.Background(), progress.DiscardOutput()); err == nil {
+		t.Fatal("expected the resumed download to fail verification")
+	}
+	rc, _, err := ld.Download(context.Background(), progress.DiscardOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, blob) {
+		t.Fatal("downloaded blob does not match")
+	}
+}
+
+func TestRemoveStaleDownloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pull-download-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := RemoveStaleDownloads(filepath.Join(dir, "missing"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := filepath.Join(dir, "stale")
+	recent := filepath.Join(dir, "recent")
+	for _, p := range []string{stale, recent} {
+		if err := ioutil.WriteFile(p, []byte("partial"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveStaleDownloads(dir, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected the stale download to be removed, got %v", err)
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Fatal(err)
+	}
+}
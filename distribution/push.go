@@ -9,6 +9,8 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/registry"
 	"golang.org/x/net/context"
@@ -58,6 +60,8 @@ func NewPusher(ref reference.Named, endpoint registry.APIEndpoint, repoInfo *reg
 func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushConfig) error {
 	// FIXME: Allow to interrupt current push when new push of same image is done.
 
+	ctx = xfer.WithBandwidthLimit(ctx, imagePushConfig.MaxBandwidth)
+
 	// Resolve the Repository name from fqn to RepositoryInfo
 	repoInfo, err := imagePushConfig.RegistryService.ResolveRepository(ref)
 	if err != nil {
@@ -146,7 +150,8 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 }
 
 // compress returns an io.ReadCloser which will supply a compressed version of
-// the provided Reader. The caller must close the ReadCloser after reading the
+// the provided Reader, compressed with zstd if compression is archive.Zstd
+// and with gzip otherwise. The caller must close the ReadCloser after reading the
 // compressed data.
 //
 // Note that this function returns a reader instead of taking a writer as an
@@ -158,18 +163,28 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 // is finished. This allows the caller to make sure the goroutine finishes
 // before it releases any resources connected with the reader that was
 // passed in.
-func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
+func compress(in io.Reader, compression archive.Compression) (io.ReadCloser, chan struct{}) {
 	compressionDone := make(chan struct{})
 
 	pipeReader, pipeWriter := io.Pipe()
 	// Use a bufio.Writer to avoid excessive chunking in HTTP request.
 	bufWriter := bufio.NewWriterSize(pipeWriter, compressionBufSize)
-	compressor := gzip.NewWriter(bufWriter)
 
 	go func() {
-		_, err := io.Copy(compressor, in)
+		var (
+			compressor io.WriteCloser
+			err        error
+		)
+		if compression == archive.Zstd {
+			compressor, err = archive.CompressStream(bufWriter, archive.Zstd)
+		} else {
+			compressor = gzip.NewWriter(bufWriter)
+		}
 		if err == nil {
-			err = compressor.Close()
+			_, err = io.Copy(compressor, in)
+			if closeErr := compressor.Close(); err == nil {
+				err = closeErr
+			}
 		}
 		if err == nil {
 			err = bufWriter.Flush()
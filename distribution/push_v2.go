@@ -21,6 +21,7 @@ import (
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/stringid"
@@ -143,6 +144,7 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 		ref:               p.ref,
 		repo:              p.repo,
 		pushState:         &p.pushState,
+		compression:       p.config.LayerCompression,
 	}
 
 	// Loop bounds condition is to avoid pushing the base layer on Windows.
@@ -244,10 +246,54 @@ type v2PushDescriptor struct {
 	remoteDescriptor  distribution.Descriptor
 	// a set of digests whose presence has been checked in a target repository
 	checkedDigests map[digest.Digest]struct{}
+	// compression of the pushed layer, gzip unless archive.Zstd
+	compression archive.Compression
 }
 
 func (pd *v2PushDescriptor) Key() string {
-	return "v2push:" + pd.ref.Name() + " " + pd.layer.DiffID().String()
+	key := "v2push:" + pd.ref.Name() + " " + pd.layer.DiffID().String()
+	if pd.compression == archive.Zstd {
+		key += " zstd"
+	}
+	return key
+}
+
+// mediaType returns the media type of the pushed layer.
+func (pd *v2PushDescriptor) mediaType() string {
+	if pd.compression == archive.Zstd {
+		return MediaTypeZstdLayer
+	}
+	return schema2.MediaTypeLayer
+}
+
+// newV2Metadata returns the metadata of a blob of the layer pushed to the
+// target repository.
+func (pd *v2PushDescriptor) newV2Metadata(dgst digest.Digest) metadata.V2Metadata {
+	meta := metadata.V2Metadata{
+		Digest:           dgst,
+		SourceRepository: pd.repoInfo.Name(),
+	}
+	if pd.compression == archive.Zstd {
+		meta.MediaType = MediaTypeZstdLayer
+	}
+	return meta
+}
+
+// filterV2Metadata returns the metadata of the blobs compressed like the
+// pushed layer, which can be reused instead of pushing the layer.
+func (pd *v2PushDescriptor) filterV2Metadata(v2Metadata []metadata.V2Metadata) []metadata.V2Metadata {
+	mediaType := pd.mediaType()
+	var filtered []metadata.V2Metadata
+	for _, meta := range v2Metadata {
+		m := meta.MediaType
+		if m == "" {
+			m = schema2.MediaTypeLayer
+		}
+		if m == mediaType {
+			filtered = append(filtered, meta)
+		}
+	}
+	return filtered
 }
 
 func (pd *v2PushDescriptor) ID() string {
@@ -282,6 +328,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 
 	// Do we have any metadata associated with this layer's DiffID?
 	v2Metadata, err := pd.v2MetadataService.GetMetadata(diffID)
+	v2Metadata = pd.filterV2Metadata(v2Metadata)
 	if err == nil {
 		// check for blob existence in the target repository
 		descriptor, exists, err := pd.layerAlreadyExists(ctx, progressOutput, diffID, true, 1, v2Metadata)
@@ -335,7 +382,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 		case distribution.ErrBlobMounted:
 			progress.Updatef(progressOutput, pd.ID(), "Mounted from %s", err.From.Name())
 
-			err.Descriptor.MediaType = schema2.MediaTypeLayer
+			err.Descriptor.MediaType = pd.mediaType()
 
 			pd.pushState.Lock()
 			pd.pushState.confirmedV2 = true
@@ -343,10 +390,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 			pd.pushState.Unlock()
 
 			// Cache mapping from this layer's DiffID to the blobsum
-			if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
-				Digest:           err.Descriptor.Digest,
-				SourceRepository: pd.repoInfo.Name(),
-			}); err != nil {
+			if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, pd.newV2Metadata(err.Descriptor.Digest)); err != nil {
 				return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 			}
 			return err.Descriptor, nil
@@ -419,22 +463,25 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 
 	reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, contentReader), progressOutput, size, pd.ID(), "Pushing")
 
+	mediaType := pd.mediaType()
 	switch m := pd.layer.MediaType(); m {
 	case schema2.MediaTypeUncompressedLayer:
-		compressedReader, compressionDone := compress(reader)
+		compressedReader, compressionDone := compress(reader, pd.compression)
 		defer func(closer io.Closer) {
 			closer.Close()
 			<-compressionDone
 		}(reader)
 		reader = compressedReader
 	case schema2.MediaTypeLayer:
+		// the layer is already compressed with gzip
+		mediaType = schema2.MediaTypeLayer
 	default:
 		reader.Close()
 		return distribution.Descriptor{}, fmt.Errorf("unsupported layer media type %s", m)
 	}
 
 	digester := digest.Canonical.Digester()
-	tee := io.TeeReader(reader, digester.Hash())
+	tee := io.TeeReader(xfer.LimitReader(ctx, reader), digester.Hash())
 
 	nn, err := layerUpload.ReadFrom(tee)
 	reader.Close()
@@ -451,16 +498,17 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	progress.Update(progressOutput, pd.ID(), "Pushed")
 
 	// Cache mapping from this layer's DiffID to the blobsum
-	if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
-		Digest:           pushDigest,
-		SourceRepository: pd.repoInfo.Name(),
-	}); err != nil {
+	meta := pd.newV2Metadata(pushDigest)
+	if mediaType == schema2.MediaTypeLayer {
+		meta.MediaType = ""
+	}
+	if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, meta); err != nil {
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 	}
 
 	desc := distribution.Descriptor{
 		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
+		MediaType: mediaType,
 		Size:      nn,
 	}
 
@@ -526,14 +574,11 @@ attempts:
 		case nil:
 			if m, ok := digestToMetadata[desc.Digest]; !ok || m.SourceRepository != pd.repoInfo.Name() || !metadata.CheckV2MetadataHMAC(m, pd.hmacKey) {
 				// cache mapping from this layer's DiffID to the blobsum
-				if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
-					Digest:           desc.Digest,
-					SourceRepository: pd.repoInfo.Name(),
-				}); err != nil {
+				if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, pd.newV2Metadata(desc.Digest)); err != nil {
 					return distribution.Descriptor{}, false, xfer.DoNotRetry{Err: err}
 				}
 			}
-			desc.MediaType = schema2.MediaTypeLayer
+			desc.MediaType = pd.mediaType()
 			exists = true
 			break attempts
 		case distribution.ErrBlobUnknown:
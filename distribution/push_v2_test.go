@@ -11,6 +11,7 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/opencontainers/go-digest"
 )
@@ -581,3 +582,24 @@ func (s *progressSink) WriteProgress(p progress.Progress) error {
 	s.t.Logf("progress update: %#+v", p)
 	return nil
 }
+
+func TestFilterV2MetadataByMediaType(t *testing.T) {
+	v2Metadata := []metadata.V2Metadata{
+		{Digest: digest.Digest("apple"), SourceRepository: "docker.io/library/busybox"},
+		{Digest: digest.Digest("orange"), SourceRepository: "docker.io/library/busybox", MediaType: MediaTypeZstdLayer},
+		{Digest: digest.Digest("pear"), SourceRepository: "docker.io/library/busybox", MediaType: schema2.MediaTypeLayer},
+	}
+
+	pd := &v2PushDescriptor{}
+	if filtered := pd.filterV2Metadata(v2Metadata); !reflect.DeepEqual(filtered, []metadata.V2Metadata{v2Metadata[0], v2Metadata[2]}) {
+		t.Errorf("unexpected gzip metadata: %#+v", filtered)
+	}
+
+	pd.compression = archive.Zstd
+	if filtered := pd.filterV2Metadata(v2Metadata); !reflect.DeepEqual(filtered, []metadata.V2Metadata{v2Metadata[1]}) {
+		t.Errorf("unexpected zstd metadata: %#+v", filtered)
+	}
+	if pd.mediaType() != MediaTypeZstdLayer {
+		t.Errorf("unexpected media type %s", pd.mediaType())
+	}
+}
@@ -19,6 +19,10 @@ import (
 	"golang.org/x/net/context"
 )
 
+// MediaTypeZstdLayer is the media type of the layers compressed with zstd in
+// schema2 manifests, as defined by the OCI image specification.
+const MediaTypeZstdLayer = "application/vnd.oci.image.layer.v1.tar+zstd"
+
 // ImageTypes represents the schema2 config types for images
 var ImageTypes = []string{
 	schema2.MediaTypeImageConfig,
@@ -81,9 +85,11 @@ func NewV2Repository(ctx context.Context, repoInfo *registry.RepositoryInfo, end
 	if err == nil {
 		base.Dial = proxyDialer.Dial
 	}
+	base.Dial = meteredDial(base.Dial, endpoint.URL.Host)
+	metered := &meteredTransport{base: base, registry: endpoint.URL.Host}
 
 	modifiers := registry.DockerHeaders(dockerversion.DockerUserAgent(ctx), metaHeaders)
-	authTransport := transport.NewTransport(base, modifiers...)
+	authTransport := transport.NewTransport(metered, modifiers...)
 
 	challengeManager, foundVersion, err := registry.PingV2Registry(endpoint.URL, authTransport)
 	if err != nil {
@@ -120,7 +126,7 @@ func NewV2Repository(ctx context.Context, repoInfo *registry.RepositoryInfo, end
 		basicHandler := auth.NewBasicHandler(creds)
 		modifiers = append(modifiers, auth.NewAuthorizer(challengeManager, tokenHandler, basicHandler))
 	}
-	tr := transport.NewTransport(base, modifiers...)
+	tr := transport.NewTransport(metered, modifiers...)
 
 	repoNameRef, err := reference.WithName(repoName)
 	if err != nil {
@@ -0,0 +1,84 @@
+package xfer
+
+import (
+	"io"
+
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+// bandwidthBurst is the largest number of bytes a limited reader reads at
+// once, and the number of bytes it can read at once after being idle.
+const bandwidthBurst = 32 * 1024
+
+type bandwidthLimitersKey struct{}
+
+// newBandwidthLimiter returns a limiter allowing limit bytes per second, or
+// an unlimited one if limit is not positive.
+func newBandwidthLimiter(limit int64) *rate.Limiter {
+	return rate.NewLimiter(bandwidthLimit(limit), bandwidthBurst)
+}
+
+func bandwidthLimit(limit int64) rate.Limit {
+	if limit <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(limit)
+}
+
+// WithBandwidthLimit returns a context limiting the layers transferred by the
+// requests using it to limit bytes per second, in addition to the bandwidth
+// limit of the manager. The context is returned unchanged if limit is not
+// positive.
+func WithBandwidthLimit(ctx context.Context, limit int64) context.Context {
+	if limit <= 0 {
+		return ctx
+	}
+	return withBandwidthLimiters(ctx, newBandwidthLimiter(limit))
+}
+
+func withBandwidthLimiters(ctx context.Context, limiters ...*rate.Limiter) context.Context {
+	if len(limiters) == 0 {
+		return ctx
+	}
+	limiters = append(bandwidthLimiters(ctx), limiters...)
+	return context.WithValue(ctx, bandwidthLimitersKey{}, limiters)
+}
+
+func bandwidthLimiters(ctx context.Context) []*rate.Limiter {
+	limiters, _ := ctx.Value(bandwidthLimitersKey{}).([]*rate.Limiter)
+	return limiters
+}
+
+// LimitReader returns a reader reading from r no faster than the bandwidth
+// limits of ctx allow. The descriptors use it to read the layers they
+// download or upload from the context they are given. Reading fails if ctx
+// is cancelled while waiting.
+func LimitReader(ctx context.Context, r io.Reader) io.Reader {
+	limiters := bandwidthLimiters(ctx)
+	if len(limiters) == 0 {
+		return r
+	}
+	return &limitedReader{ctx: ctx, r: r, limiters: limiters}
+}
+
+type limitedReader struct {
+	ctx      context.Context
+	r        io.Reader
+	limiters []*rate.Limiter
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if len(p) > bandwidthBurst {
+		p = p[:bandwidthBurst]
+	}
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		for _, limiter := range lr.limiters {
+			if werr := limiter.WaitN(lr.ctx, n); werr != nil {
+				return n, werr
+			}
+		}
+	}
+	return n, err
+}
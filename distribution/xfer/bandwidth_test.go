@@ -0,0 +1,78 @@
+package xfer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestLimitReader(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 3*bandwidthBurst)
+
+	// Without a limit the reader is returned as is.
+	r := bytes.NewReader(data)
+	if LimitReader(context.Background(), r) != r {
+		t.Fatal("expected the reader not to be limited")
+	}
+
+	// The first burst is read at once, the two others at the limit.
+	ctx := WithBandwidthLimit(context.Background(), 4*bandwidthBurst)
+	start := time.Now()
+	read, err := ioutil.ReadAll(LimitReader(ctx, bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(read, data) {
+		t.Fatal("unexpected data read")
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected the read to take at least 400ms, took %v", elapsed)
+	}
+}
+
+func TestLimitReaderCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(WithBandwidthLimit(context.Background(), 1))
+	r := LimitReader(ctx, bytes.NewReader(bytes.Repeat([]byte("a"), 3*bandwidthBurst)))
+
+	done := make(chan error)
+	go func() {
+		_, err := ioutil.ReadAll(r)
+		done <- err
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected the read to fail")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the read was not cancelled")
+	}
+}
+
+func TestSetBandwidthLimit(t *testing.T) {
+	ldm := NewLayerDownloadManager(nil, 1)
+	ctx := withBandwidthLimiters(context.Background(), ldm.limiter)
+
+	ldm.SetBandwidthLimit(4 * bandwidthBurst)
+	start := time.Now()
+	if _, err := ioutil.ReadAll(LimitReader(ctx, bytes.NewReader(make([]byte, 3*bandwidthBurst)))); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected the read to take at least 400ms, took %v", elapsed)
+	}
+
+	ldm.SetBandwidthLimit(0)
+	start = time.Now()
+	if _, err := ioutil.ReadAll(LimitReader(ctx, bytes.NewReader(make([]byte, 3*bandwidthBurst)))); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("expected the read not to be limited, took %v", elapsed)
+	}
+}
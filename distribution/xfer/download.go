@@ -14,6 +14,7 @@ import (
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
 )
 
 const maxDownloadAttempts = 5
@@ -25,6 +26,7 @@ type LayerDownloadManager struct {
 	layerStore   layer.Store
 	tm           TransferManager
 	waitDuration time.Duration
+	limiter      *rate.Limiter
 }
 
 // SetConcurrency sets the max concurrent downloads for each pull
@@ -32,12 +34,19 @@ func (ldm *LayerDownloadManager) SetConcurrency(concurrency int) {
 	ldm.tm.SetConcurrency(concurrency)
 }
 
+// SetBandwidthLimit sets the max number of bytes per second downloaded by
+// all the pulls. Downloads are not limited if it is not positive.
+func (ldm *LayerDownloadManager) SetBandwidthLimit(limit int64) {
+	ldm.limiter.SetLimit(bandwidthLimit(limit))
+}
+
 // NewLayerDownloadManager returns a new LayerDownloadManager.
 func NewLayerDownloadManager(layerStore layer.Store, concurrencyLimit int, options ...func(*LayerDownloadManager)) *LayerDownloadManager {
 	manager := LayerDownloadManager{
 		layerStore:   layerStore,
 		tm:           NewTransferManager(concurrencyLimit),
 		waitDuration: time.Second,
+		limiter:      newBandwidthLimiter(0),
 	}
 	for _, option := range options {
 		option(&manager)
@@ -93,7 +102,11 @@ type DownloadDescriptorWithRegistered interface {
 // the layer store, and the key is not used by an in-progress download, the
 // Download method is called to get the layer tar data. Layers are then
 // registered in the appropriate order.  The caller must call the returned
-// release function once it is done with the returned RootFS object.
+// release function once it is done with the returned RootFS object. The
+// downloads started by the call are limited to the bandwidth set on ctx with
+// WithBandwidthLimit, if any. The limit belongs to the download rather than
+// to the caller: a call sharing a download in progress shares the limit of
+// the call which started it, whatever the bandwidth set on its own ctx.
 func (ldm *LayerDownloadManager) Download(ctx context.Context, initialRootFS image.RootFS, layers []DownloadDescriptor, progressOutput progress.Output) (image.RootFS, func(), error) {
 	var (
 		topLayer       layer.Layer
@@ -152,10 +165,10 @@ func (ldm *LayerDownloadManager) Download(ctx context.Context, initialRootFS ima
 
 		var xferFunc DoFunc
 		if topDownload != nil {
-			xferFunc = ldm.makeDownloadFunc(descriptor, "", topDownload)
+			xferFunc = ldm.makeDownloadFunc(descriptor, "", topDownload, bandwidthLimiters(ctx))
 			defer topDownload.Transfer.Release(watcher)
 		} else {
-			xferFunc = ldm.makeDownloadFunc(descriptor, rootFS.ChainID(), nil)
+			xferFunc = ldm.makeDownloadFunc(descriptor, rootFS.ChainID(), nil, bandwidthLimiters(ctx))
 		}
 		topDownloadUncasted, watcher = ldm.tm.Transfer(transferKey, xferFunc, progressOutput)
 		topDownload = topDownloadUncasted.(*downloadTransfer)
@@ -211,8 +224,9 @@ func (ldm *LayerDownloadManager) Download(ctx context.Context, initialRootFS ima
 // registration. If parentDownload is non-nil, it waits for that download to
 // complete before the registration step, and registers the downloaded data
 // on top of parentDownload's resulting layer. Otherwise, it registers the
-// layer on top of the ChainID given by parentLayer.
-func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor, parentLayer layer.ChainID, parentDownload *downloadTransfer) DoFunc {
+// layer on top of the ChainID given by parentLayer. The download is limited
+// to the bandwidth of the manager, and of the given limiters.
+func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor, parentLayer layer.ChainID, parentDownload *downloadTransfer, limiters []*rate.Limiter) DoFunc {
 	return func(progressChan chan<- progress.Progress, start <-chan struct{}, inactive chan<- struct{}) Transfer {
 		d := &downloadTransfer{
 			Transfer:   NewTransfer(),
@@ -256,8 +270,9 @@ func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor,
 
 			defer descriptor.Close()
 
+			downloadCtx := withBandwidthLimiters(d.Transfer.Context(), append([]*rate.Limiter{ldm.limiter}, limiters...)...)
 			for {
-				downloadReader, size, err = descriptor.Download(d.Transfer.Context(), progressOutput)
+				downloadReader, size, err = descriptor.Download(downloadCtx, progressOutput)
 				if err == nil {
 					break
 				}
@@ -279,6 +294,7 @@ func (ldm *LayerDownloadManager) makeDownloadFunc(descriptor DownloadDescriptor,
 				}
 
 				logrus.Errorf("Download failed, retrying: %v", err)
+				transferRetries.WithValues("download").Inc()
 				delay := retries * 5
 				ticker := time.NewTicker(ldm.waitDuration)
 
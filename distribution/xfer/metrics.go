@@ -0,0 +1,11 @@
+package xfer
+
+import "github.com/docker/go-metrics"
+
+var transferRetries metrics.LabeledCounter
+
+func init() {
+	ns := metrics.NewNamespace("engine", "daemon", nil)
+	transferRetries = ns.NewLabeledCounter("layer_transfer_retries", "The number of retried layer downloads and uploads", "direction")
+	metrics.Register(ns)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/progress"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
 )
 
 const maxUploadAttempts = 5
@@ -18,6 +19,7 @@ const maxUploadAttempts = 5
 type LayerUploadManager struct {
 	tm           TransferManager
 	waitDuration time.Duration
+	limiter      *rate.Limiter
 }
 
 // SetConcurrency sets the max concurrent uploads for each push
@@ -25,11 +27,18 @@ func (lum *LayerUploadManager) SetConcurrency(concurrency int) {
 	lum.tm.SetConcurrency(concurrency)
 }
 
+// SetBandwidthLimit sets the max number of bytes per second uploaded by all
+// the pushes. Uploads are not limited if it is not positive.
+func (lum *LayerUploadManager) SetBandwidthLimit(limit int64) {
+	lum.limiter.SetLimit(bandwidthLimit(limit))
+}
+
 // NewLayerUploadManager returns a new LayerUploadManager.
 func NewLayerUploadManager(concurrencyLimit int, options ...func(*LayerUploadManager)) *LayerUploadManager {
 	manager := LayerUploadManager{
 		tm:           NewTransferManager(concurrencyLimit),
 		waitDuration: time.Second,
+		limiter:      newBandwidthLimiter(0),
 	}
 	for _, option := range options {
 		option(&manager)
@@ -63,7 +72,11 @@ type UploadDescriptor interface {
 
 // Upload is a blocking function which ensures the listed layers are present on
 // the remote registry. It uses the string returned by the Key method to
-// deduplicate uploads.
+// deduplicate uploads. The uploads started by the call are limited to the
+// bandwidth set on ctx with WithBandwidthLimit, if any. The limit belongs to
+// the upload rather than to the caller: a call sharing an upload in progress
+// shares the limit of the call which started it, whatever the bandwidth set
+// on its own ctx.
 func (lum *LayerUploadManager) Upload(ctx context.Context, layers []UploadDescriptor, progressOutput progress.Output) error {
 	var (
 		uploads          []*uploadTransfer
@@ -78,7 +91,7 @@ func (lum *LayerUploadManager) Upload(ctx context.Context, layers []UploadDescri
 			continue
 		}
 
-		xferFunc := lum.makeUploadFunc(descriptor)
+		xferFunc := lum.makeUploadFunc(descriptor, bandwidthLimiters(ctx))
 		upload, watcher := lum.tm.Transfer(descriptor.Key(), xferFunc, progressOutput)
 		defer upload.Release(watcher)
 		uploads = append(uploads, upload.(*uploadTransfer))
@@ -102,7 +115,7 @@ func (lum *LayerUploadManager) Upload(ctx context.Context, layers []UploadDescri
 	return nil
 }
 
-func (lum *LayerUploadManager) makeUploadFunc(descriptor UploadDescriptor) DoFunc {
+func (lum *LayerUploadManager) makeUploadFunc(descriptor UploadDescriptor, limiters []*rate.Limiter) DoFunc {
 	return func(progressChan chan<- progress.Progress, start <-chan struct{}, inactive chan<- struct{}) Transfer {
 		u := &uploadTransfer{
 			Transfer: NewTransfer(),
@@ -122,9 +135,10 @@ func (lum *LayerUploadManager) makeUploadFunc(descriptor UploadDescriptor) DoFun
 				<-start
 			}
 
+			uploadCtx := withBandwidthLimiters(u.Transfer.Context(), append([]*rate.Limiter{lum.limiter}, limiters...)...)
 			retries := 0
 			for {
-				remoteDescriptor, err := descriptor.Upload(u.Transfer.Context(), progressOutput)
+				remoteDescriptor, err := descriptor.Upload(uploadCtx, progressOutput)
 				if err == nil {
 					u.remoteDescriptor = remoteDescriptor
 					break
@@ -147,6 +161,7 @@ func (lum *LayerUploadManager) makeUploadFunc(descriptor UploadDescriptor) DoFun
 				}
 
 				logrus.Errorf("Upload failed, retrying: %v", err)
+				transferRetries.WithValues("upload").Inc()
 				delay := retries * 5
 				ticker := time.NewTicker(lum.waitDuration)
 
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/Sirupsen/logrus"
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
@@ -41,13 +42,58 @@ func New(store image.Store) *ImageCache {
 // ImageCache is cache based on history objects. Requires initial set of images.
 type ImageCache struct {
 	sources         []*image.Image
+	populated       []*image.Image // sources before any cache hit
+	fetchers        map[*image.Image]LayerFetcher
 	store           image.Store
 	localImageCache *LocalImageCache
 }
 
+// LayerFetcher fetches the layers of an image of the cache which is not
+// present locally.
+type LayerFetcher interface {
+	// FetchLayers registers the layers of rootFS, the first layers of the
+	// image, on top of the layers of parent which are present locally. The
+	// returned function releases the layers once an image holds them.
+	FetchLayers(parent, rootFS image.RootFS) (release func(), err error)
+}
+
 // Populate adds an image to the cache (to be queried later)
 func (ic *ImageCache) Populate(image *image.Image) {
 	ic.sources = append(ic.sources, image)
+	ic.populated = append(ic.populated, image)
+}
+
+// PopulateRemote adds an image which is not present locally to the cache,
+// only its config. The layers of the image are fetched with fetcher when a
+// cache hit reuses them.
+func (ic *ImageCache) PopulateRemote(img *image.Image, fetcher LayerFetcher) {
+	if ic.fetchers == nil {
+		ic.fetchers = make(map[*image.Image]LayerFetcher)
+	}
+	ic.sources = append(ic.sources, img)
+	ic.populated = append(ic.populated, img)
+	ic.fetchers[img] = fetcher
+}
+
+// Clone returns a copy of the cache populated with the same images, in the
+// state it had before any cache hit, so that the images are only looked up
+// once for several builds. The cache must not be populated anymore once
+// cloned.
+func (ic *ImageCache) Clone() *ImageCache {
+	var fetchers map[*image.Image]LayerFetcher
+	if ic.fetchers != nil {
+		fetchers = make(map[*image.Image]LayerFetcher, len(ic.fetchers))
+		for img, fetcher := range ic.fetchers {
+			fetchers[img] = fetcher
+		}
+	}
+	return &ImageCache{
+		sources:         append([]*image.Image(nil), ic.populated...),
+		populated:       ic.populated,
+		fetchers:        fetchers,
+		store:           ic.store,
+		localImageCache: ic.localImageCache,
+	}
 }
 
 // GetCache returns the image id found in the cache
@@ -79,6 +125,20 @@ func (ic *ImageCache) GetCache(parentID string, cfg *containertypes.Config) (str
 			continue
 		}
 
+		if _, remote := ic.fetchers[target]; remote {
+			imgID, err := ic.restoreRemoteImage(parent, target, cfg)
+			if err != nil {
+				// The image cannot be used as cache anymore, which is a
+				// cache miss rather than a build failure.
+				logrus.Warnf("failed to restore cached image from %q, dropping the remote cache source: %v", parentID, err)
+				ic.dropSource(target)
+				continue
+			}
+
+			ic.sources = []*image.Image{target} // avoid jumping to different target, tuned for safety atm
+			return imgID.String(), nil
+		}
+
 		if len(target.History)-1 == lenHistory { // last
 			if parent != nil {
 				if err := ic.store.SetParent(target.ID(), parent.ID()); err != nil {
@@ -100,7 +160,62 @@ func (ic *ImageCache) GetCache(parentID string, cfg *containertypes.Config) (str
 	return "", nil
 }
 
+// dropSource removes an image from the sources of the cache.
+func (ic *ImageCache) dropSource(img *image.Image) {
+	delete(ic.fetchers, img)
+	sources := make([]*image.Image, 0, len(ic.sources))
+	for _, s := range ic.sources {
+		if s != img {
+			sources = append(sources, s)
+		}
+	}
+	ic.sources = sources
+}
+
 func (ic *ImageCache) restoreCachedImage(parent, target *image.Image, cfg *containertypes.Config) (image.ID, error) {
+	config, err := restoredImageConfig(parent, target, cfg)
+	if err != nil {
+		return "", err
+	}
+	return ic.createCachedImage(parent, config)
+}
+
+// restoreRemoteImage restores the cached image from an image which is not
+// present locally, after fetching the layer it adds on top of parent. The
+// config of the image is restored as is for the last step of the image, for
+// the image to get the same ID as in the registry.
+func (ic *ImageCache) restoreRemoteImage(parent, target *image.Image, cfg *containertypes.Config) (image.ID, error) {
+	parentRootFS := *image.NewRootFS()
+	lenHistory := 0
+	if parent != nil {
+		parentRootFS = *parent.RootFS
+		lenHistory = len(parent.History)
+	}
+
+	config := target.RawJSON()
+	if lenHistory != len(target.History)-1 {
+		var err error
+		if config, err = restoredImageConfig(parent, target, cfg); err != nil {
+			return "", err
+		}
+	}
+
+	img, err := image.NewFromJSON(config)
+	if err != nil {
+		return "", err
+	}
+	release, err := ic.fetchers[target].FetchLayers(parentRootFS, *img.RootFS)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to fetch cached layers")
+	}
+	defer release()
+
+	return ic.createCachedImage(parent, config)
+}
+
+// restoredImageConfig returns the config of the image of the cache for the
+// step of target following parent.
+func restoredImageConfig(parent, target *image.Image, cfg *containertypes.Config) ([]byte, error) {
 	var history []image.History
 	rootFS := image.NewRootFS()
 	lenHistory := 0
@@ -129,17 +244,25 @@ func (ic *ImageCache) restoreCachedImage(parent, target *image.Image, cfg *conta
 		OSVersion:  target.OSVersion,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to marshal image config")
+		return nil, errors.Wrap(err, "failed to marshal image config")
 	}
+	return config, nil
+}
 
+// createCachedImage creates an image of the cache on top of parent.
+func (ic *ImageCache) createCachedImage(parent *image.Image, config []byte) (image.ID, error) {
 	imgID, err := ic.store.Create(config)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create cache image")
 	}
 
+	if err := ic.store.SetBuildCache(imgID, true); err != nil {
+		return "", errors.Wrap(err, "failed to mark cache image as build cache")
+	}
+
 	if parent != nil {
 		if err := ic.store.SetParent(imgID, parent.ID()); err != nil {
-			return "", errors.Wrapf(err, "failed to set parent for %v to %v", target.ID(), parent.ID())
+			return "", errors.Wrapf(err, "failed to set parent for %v to %v", imgID, parent.ID())
 		}
 	}
 	return imgID, nil
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLayerFetcher struct {
+	parents  []image.RootFS
+	rootFSs  []image.RootFS
+	released int
+	err      error
+}
+
+func (f *fakeLayerFetcher) FetchLayers(parent, rootFS image.RootFS) (func(), error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.parents = append(f.parents, parent)
+	f.rootFSs = append(f.rootFSs, rootFS)
+	return func() { f.released++ }, nil
+}
+
+func TestGetCacheRemote(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "image-cache-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	fs, err := image.NewFSStoreBackend(tmpdir)
+	require.NoError(t, err)
+	store, err := image.NewImageStore(fs, nil)
+	require.NoError(t, err)
+
+	remoteConfig := []byte(`{"architecture": "amd64", "os": "linux", "rootfs": {"type": "layers"}, "history": [` +
+		`{"created_by": "/bin/sh -c #(nop)  ENV A=1", "empty_layer": true},` +
+		`{"created_by": "/bin/sh -c #(nop)  ENV B=2", "empty_layer": true}]}`)
+	remote, err := image.NewFromJSON(remoteConfig)
+	require.NoError(t, err)
+
+	fetcher := &fakeLayerFetcher{}
+	ic := New(store)
+	ic.PopulateRemote(remote, fetcher)
+
+	// The first step is restored from the config of the remote image.
+	cfg := &containertypes.Config{Cmd: []string{"/bin/sh", "-c", "#(nop)  ENV A=1"}}
+	firstID, err := ic.GetCache("", cfg)
+	require.NoError(t, err)
+	require.NotEqual(t, "", firstID)
+
+	first, err := store.Get(image.ID(firstID))
+	require.NoError(t, err)
+	assert.Equal(t, remote.History[:1], first.History)
+	assert.Equal(t, cfg, first.Config)
+	assert.True(t, store.IsBuildCache(first.ID()))
+
+	// The last step gets the config, and so the ID, of the remote image.
+	cfg = &containertypes.Config{Cmd: []string{"/bin/sh", "-c", "#(nop)  ENV B=2"}}
+	lastID, err := ic.GetCache(firstID, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, image.IDFromDigest(digest.FromBytes(remoteConfig)).String(), lastID)
+
+	parent, err := store.GetParent(image.ID(lastID))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), parent)
+
+	assert.Equal(t, []image.RootFS{*image.NewRootFS(), *image.NewRootFS()}, fetcher.parents)
+	assert.Equal(t, []image.RootFS{*image.NewRootFS(), *image.NewRootFS()}, fetcher.rootFSs)
+	assert.Equal(t, 2, fetcher.released)
+
+	// A step which is not in the remote image misses the cache.
+	cfg = &containertypes.Config{Cmd: []string{"/bin/sh", "-c", "#(nop)  ENV C=3"}}
+	id, err := ic.GetCache(lastID, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "", id)
+}
+
+func TestGetCacheRemoteFetchError(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "image-cache-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	fs, err := image.NewFSStoreBackend(tmpdir)
+	require.NoError(t, err)
+	store, err := image.NewImageStore(fs, nil)
+	require.NoError(t, err)
+
+	remote, err := image.NewFromJSON([]byte(`{"architecture": "amd64", "os": "linux", "rootfs": {"type": "layers"}, "history": [` +
+		`{"created_by": "/bin/sh -c #(nop)  ENV A=1", "empty_layer": true},` +
+		`{"created_by": "/bin/sh -c #(nop)  ENV B=2", "empty_layer": true}]}`))
+	require.NoError(t, err)
+
+	root := New(store)
+	root.PopulateRemote(remote, &fakeLayerFetcher{err: errors.New("unauthorized")})
+	ic := root.Clone()
+
+	// A remote image which cannot be fetched misses the cache, and is not
+	// attempted again.
+	cfg := &containertypes.Config{Cmd: []string{"/bin/sh", "-c", "#(nop)  ENV A=1"}}
+	id, err := ic.GetCache("", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "", id)
+	assert.Empty(t, ic.sources)
+	assert.Empty(t, ic.fetchers)
+
+	// Other builds still use it.
+	assert.Equal(t, []*image.Image{remote}, root.Clone().sources)
+	assert.Len(t, root.fetchers, 1)
+}
+
+func TestGetLayerForHistoryIndex(t *testing.T) {
+	img := &image.Image{
+		RootFS: &image.RootFS{Type: "layers", DiffIDs: []layer.DiffID{"sha256:a", "sha256:b"}},
+		History: []image.History{
+			{CreatedBy: "ADD a"},
+			{CreatedBy: "ENV A=1", EmptyLayer: true},
+			{CreatedBy: "ADD b"},
+		},
+	}
+	assert.Equal(t, layer.DiffID("sha256:a"), getLayerForHistoryIndex(img, 0))
+	assert.Equal(t, layer.DiffID(""), getLayerForHistoryIndex(img, 1))
+	assert.Equal(t, layer.DiffID("sha256:b"), getLayerForHistoryIndex(img, 2))
+}
+
+func TestCloneResetsSources(t *testing.T) {
+	a := &image.Image{V1Image: image.V1Image{Comment: "a"}}
+	b := &image.Image{V1Image: image.V1Image{Comment: "b"}}
+	fetcher := &fakeLayerFetcher{}
+	ic := New(nil)
+	ic.Populate(a)
+	ic.PopulateRemote(b, fetcher)
+
+	// a cache hit narrows the sources down to the image it was found in
+	ic.sources = []*image.Image{b}
+
+	clone := ic.Clone()
+	assert.Equal(t, []*image.Image{a, b}, clone.sources)
+	assert.Equal(t, LayerFetcher(fetcher), clone.fetchers[b])
+	assert.Equal(t, []*image.Image{b}, ic.sources)
+
+	clone.sources = clone.sources[:1]
+	assert.Equal(t, []*image.Image{a, b}, ic.Clone().sources)
+}
@@ -131,6 +131,8 @@ type Exporter interface {
 	Load(io.ReadCloser, io.Writer, bool) error
 	// TODO: Load(net.Context, io.ReadCloser, <- chan StatusMessage) error
 	Save([]string, io.Writer) error
+	// SaveOCI saves the images as an OCI image layout
+	SaveOCI([]string, io.Writer) error
 }
 
 // NewFromJSON creates an Image configuration from json.
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/digestset"
@@ -23,6 +24,10 @@ type Store interface {
 	Children(id ID) []ID
 	Map() map[ID]*Image
 	Heads() map[ID]*Image
+	SetLastUsed(id ID, t time.Time) error
+	GetLastUsed(id ID) (time.Time, error)
+	SetBuildCache(id ID, buildCache bool) error
+	IsBuildCache(id ID) bool
 }
 
 // LayerGetReleaser is a minimal interface for getting and releasing images.
@@ -162,6 +167,11 @@ func (is *store) Create(config []byte) (ID, error) {
 		return "", err
 	}
 
+	// An image which was never used is as recent as its creation.
+	if err := is.SetLastUsed(imageID, time.Now()); err != nil {
+		logrus.Errorf("error setting last used time of %s: %v", imageID, err)
+	}
+
 	return imageID, nil
 }
 
@@ -250,6 +260,43 @@ func (is *store) GetParent(id ID) (ID, error) {
 	return ID(d), nil // todo: validate?
 }
 
+// SetLastUsed records the last time a container was created or started from
+// the image.
+func (is *store) SetLastUsed(id ID, t time.Time) error {
+	data, err := t.UTC().MarshalText()
+	if err != nil {
+		return err
+	}
+	return is.fs.SetMetadata(id.Digest(), "lastUsed", data)
+}
+
+// GetLastUsed returns the last time a container was created or started from
+// the image. It fails for the images created before the time was recorded.
+func (is *store) GetLastUsed(id ID) (time.Time, error) {
+	var t time.Time
+	data, err := is.fs.GetMetadata(id.Digest(), "lastUsed")
+	if err != nil {
+		return t, err
+	}
+	err = t.UnmarshalText(data)
+	return t, err
+}
+
+// SetBuildCache marks the image as build cache, an image committed by the
+// builder which is not the result of a build.
+func (is *store) SetBuildCache(id ID, buildCache bool) error {
+	if !buildCache {
+		return is.fs.DeleteMetadata(id.Digest(), "buildCache")
+	}
+	return is.fs.SetMetadata(id.Digest(), "buildCache", []byte("true"))
+}
+
+// IsBuildCache returns whether the image is marked as build cache.
+func (is *store) IsBuildCache(id ID) bool {
+	_, err := is.fs.GetMetadata(id.Digest(), "buildCache")
+	return err == nil
+}
+
 func (is *store) Children(id ID) []ID {
 	is.Lock()
 	defer is.Unlock()
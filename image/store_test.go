@@ -2,6 +2,7 @@ package image
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/testutil"
@@ -157,3 +158,42 @@ func (ls *mockLayerGetReleaser) Get(layer.ChainID) (layer.Layer, error) {
 func (ls *mockLayerGetReleaser) Release(layer.Layer) ([]layer.Metadata, error) {
 	return nil, nil
 }
+
+func TestLastUsed(t *testing.T) {
+	is, cleanup := defaultImageStore(t)
+	defer cleanup()
+
+	before := time.Now().Add(-time.Second)
+	id, err := is.Create([]byte(`{"comment": "abc", "rootfs": {"type": "layers"}}`))
+	assert.NoError(t, err)
+
+	lastUsed, err := is.GetLastUsed(id)
+	assert.NoError(t, err)
+	assert.True(t, lastUsed.After(before))
+
+	used := time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, is.SetLastUsed(id, used))
+	lastUsed, err = is.GetLastUsed(id)
+	assert.NoError(t, err)
+	assert.True(t, lastUsed.Equal(used))
+
+	_, err = is.Delete(id)
+	assert.NoError(t, err)
+	_, err = is.GetLastUsed(id)
+	assert.Error(t, err)
+}
+
+func TestBuildCache(t *testing.T) {
+	is, cleanup := defaultImageStore(t)
+	defer cleanup()
+
+	id, err := is.Create([]byte(`{"comment": "abc", "rootfs": {"type": "layers"}}`))
+	assert.NoError(t, err)
+	assert.False(t, is.IsBuildCache(id))
+
+	assert.NoError(t, is.SetBuildCache(id, true))
+	assert.True(t, is.IsBuildCache(id))
+
+	assert.NoError(t, is.SetBuildCache(id, false))
+	assert.False(t, is.IsBuildCache(id))
+}
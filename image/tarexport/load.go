@@ -8,9 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/image/v1"
@@ -52,6 +54,9 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	manifestFile, err := os.Open(manifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			if _, err := os.Stat(filepath.Join(tmpDir, ociLayoutFileName)); err == nil {
+				return l.ociLoad(tmpDir, outStream, progressOutput)
+			}
 			return l.legacyLoad(tmpDir, outStream, progressOutput)
 		}
 		return err
@@ -352,6 +357,180 @@ func (l *tarexporter) legacyLoadImage(oldID, sourceDir string, loadedMap map[str
 	return nil
 }
 
+// ociLoad loads the images of an OCI image layout, tagging them with the
+// references they were saved with.
+func (l *tarexporter) ociLoad(tmpDir string, outStream io.Writer, progressOutput progress.Output) error {
+	var layout ociLayout
+	if err := readJSONFile(tmpDir, ociLayoutFileName, &layout); err != nil {
+		return err
+	}
+	if layout.ImageLayoutVersion != ociImageLayoutVersion {
+		return fmt.Errorf("unsupported OCI image layout version %q", layout.ImageLayoutVersion)
+	}
+
+	var index ociIndex
+	if err := readJSONFile(tmpDir, ociIndexFileName, &index); err != nil {
+		return err
+	}
+
+	var imageIDsStr string
+	var imageRefCount int
+	loaded := make(map[image.ID]struct{})
+
+	for _, desc := range index.Manifests {
+		imgID, err := l.ociLoadImage(tmpDir, desc, progressOutput)
+		if err != nil {
+			return err
+		}
+		if _, ok := loaded[imgID]; !ok {
+			loaded[imgID] = struct{}{}
+			imageIDsStr += fmt.Sprintf("Loaded image ID: %s\n", imgID)
+			l.loggerImgEvent.LogImageEvent(imgID.String(), imgID.String(), "load")
+		}
+
+		ref, err := ociReference(desc.Annotations)
+		if err != nil {
+			outStream.Write([]byte(fmt.Sprintf("Not tagging image ID %s: %v\n", imgID, err)))
+		}
+		if ref != nil {
+			l.setLoadedTag(ref, imgID.Digest(), outStream)
+			outStream.Write([]byte(fmt.Sprintf("Loaded image: %s\n", reference.FamiliarString(ref))))
+			imageRefCount++
+		}
+	}
+
+	if imageRefCount == 0 {
+		outStream.Write([]byte(imageIDsStr))
+	}
+
+	return nil
+}
+
+// ociLoadImage loads the image of the manifest, or of the image index,
+// described by desc. The image matching the platform of the daemon is
+// loaded from an image index.
+func (l *tarexporter) ociLoadImage(tmpDir string, desc ociDescriptor, progressOutput progress.Output) (image.ID, error) {
+	if isOCIIndex(desc.MediaType) {
+		var index ociIndex
+		if err := readOCIBlobJSON(tmpDir, desc, &index); err != nil {
+			return "", err
+		}
+		for _, m := range index.Manifests {
+			if m.Platform == nil || (m.Platform.OS == runtime.GOOS && m.Platform.Architecture == runtime.GOARCH) {
+				return l.ociLoadImage(tmpDir, m, progressOutput)
+			}
+		}
+		return "", fmt.Errorf("no image for %s/%s in image index %s", runtime.GOOS, runtime.GOARCH, desc.Digest)
+	}
+	if !isOCIManifest(desc.MediaType) {
+		return "", fmt.Errorf("unsupported media type %q for %s", desc.MediaType, desc.Digest)
+	}
+
+	var m ociManifest
+	if err := readOCIBlobJSON(tmpDir, desc, &m); err != nil {
+		return "", err
+	}
+	if m.Config.MediaType != ociMediaTypeConfig && m.Config.MediaType != schema2.MediaTypeImageConfig {
+		return "", fmt.Errorf("unsupported media type %q for image config %s", m.Config.MediaType, m.Config.Digest)
+	}
+	config, err := readOCIBlob(tmpDir, m.Config)
+	if err != nil {
+		return "", err
+	}
+	img, err := image.NewFromJSON(config)
+	if err != nil {
+		return "", err
+	}
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+
+	if expected, actual := len(m.Layers), len(img.RootFS.DiffIDs); expected != actual {
+		return "", fmt.Errorf("invalid manifest, layers length mismatch: expected %d, got %d", expected, actual)
+	}
+
+	for i, diffID := range img.RootFS.DiffIDs {
+		layerDesc := m.Layers[i]
+		if !isOCILayer(layerDesc.MediaType) {
+			return "", fmt.Errorf("unsupported media type %q for layer %s", layerDesc.MediaType, layerDesc.Digest)
+		}
+		r := rootFS
+		r.Append(diffID)
+		newLayer, err := l.ls.Get(r.ChainID())
+		if err != nil {
+			layerPath, err := ociBlobPath(tmpDir, layerDesc.Digest)
+			if err != nil {
+				return "", err
+			}
+			var foreignSrc distribution.Descriptor
+			if len(layerDesc.URLs) > 0 {
+				foreignSrc = distribution.Descriptor{
+					MediaType: schema2.MediaTypeForeignLayer,
+					Digest:    layerDesc.Digest,
+					Size:      layerDesc.Size,
+					URLs:      layerDesc.URLs,
+				}
+			}
+			newLayer, err = l.loadLayer(layerPath, rootFS, diffID.String(), foreignSrc, progressOutput)
+			if err != nil {
+				return "", err
+			}
+		}
+		defer layer.ReleaseAndLog(l.ls, newLayer)
+		if expected, actual := diffID, newLayer.DiffID(); expected != actual {
+			return "", fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
+		}
+		rootFS.Append(diffID)
+	}
+
+	return l.is.Create(config)
+}
+
+// ociBlobPath returns the path of the blob with the given digest in the
+// image layout.
+func ociBlobPath(tmpDir string, dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+	return safePath(tmpDir, filepath.Join(ociBlobsDirName, dgst.Algorithm().String(), dgst.Hex()))
+}
+
+// readOCIBlob reads the blob described by desc, verifying its digest.
+func readOCIBlob(tmpDir string, desc ociDescriptor) ([]byte, error) {
+	blobPath, err := ociBlobPath(tmpDir, desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(blobPath)
+	if err != nil {
+		return nil, err
+	}
+	if actual := desc.Digest.Algorithm().FromBytes(data); actual != desc.Digest {
+		return nil, fmt.Errorf("invalid blob %s: got digest %s", desc.Digest, actual)
+	}
+	return data, nil
+}
+
+func readOCIBlobJSON(tmpDir string, desc ociDescriptor, v interface{}) error {
+	data, err := readOCIBlob(tmpDir, desc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func readJSONFile(tmpDir, name string, v interface{}) error {
+	p, err := safePath(tmpDir, name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
 func safePath(base, path string) (string, error) {
 	return symlink.FollowSymlinkInScope(filepath.Join(base, path), base)
 }
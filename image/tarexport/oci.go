@@ -0,0 +1,129 @@
+package tarexport
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+)
+
+// The file names, media types and annotations of the OCI image layout, as
+// defined by https://github.com/opencontainers/image-spec/blob/v1.0.0/image-layout.md
+const (
+	ociLayoutFileName     = "oci-layout"
+	ociIndexFileName      = "index.json"
+	ociBlobsDirName       = "blobs"
+	ociImageLayoutVersion = "1.0.0"
+
+	ociMediaTypeIndex                     = "application/vnd.oci.image.index.v1+json"
+	ociMediaTypeManifest                  = "application/vnd.oci.image.manifest.v1+json"
+	ociMediaTypeConfig                    = "application/vnd.oci.image.config.v1+json"
+	ociMediaTypeLayer                     = "application/vnd.oci.image.layer.v1.tar"
+	ociMediaTypeLayerGzip                 = "application/vnd.oci.image.layer.v1.tar+gzip"
+	ociMediaTypeLayerZstd                 = "application/vnd.oci.image.layer.v1.tar+zstd"
+	ociMediaTypeLayerNonDistributable     = "application/vnd.oci.image.layer.nondistributable.v1.tar"
+	ociMediaTypeLayerNonDistributableGzip = "application/vnd.oci.image.layer.nondistributable.v1.tar+gzip"
+	ociMediaTypeLayerNonDistributableZstd = "application/vnd.oci.image.layer.nondistributable.v1.tar+zstd"
+
+	ociAnnotationRefName          = "org.opencontainers.image.ref.name"
+	containerdAnnotationImageName = "io.containerd.image.name"
+)
+
+type ociLayout struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+type ociPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      digest.Digest     `json:"digest"`
+	Size        int64             `json:"size"`
+	URLs        []string          `json:"urls,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *ociPlatform      `json:"platform,omitempty"`
+}
+
+// ociIndex is the index.json of the layout, and the image indexes it refers
+// to.
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType,omitempty"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType,omitempty"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// isOCIIndex returns whether the media type is the one of an image index,
+// or of the equivalent Docker manifest list.
+func isOCIIndex(mediaType string) bool {
+	return mediaType == ociMediaTypeIndex || mediaType == manifestlist.MediaTypeManifestList
+}
+
+// isOCIManifest returns whether the media type is the one of an image
+// manifest, or of the equivalent Docker image manifest.
+func isOCIManifest(mediaType string) bool {
+	return mediaType == ociMediaTypeManifest || mediaType == schema2.MediaTypeManifest
+}
+
+// isOCILayer returns whether the media type is the one of a layer that can
+// be loaded from the layout.
+func isOCILayer(mediaType string) bool {
+	switch mediaType {
+	case ociMediaTypeLayer, ociMediaTypeLayerGzip, ociMediaTypeLayerZstd,
+		ociMediaTypeLayerNonDistributable, ociMediaTypeLayerNonDistributableGzip, ociMediaTypeLayerNonDistributableZstd,
+		schema2.MediaTypeLayer, schema2.MediaTypeUncompressedLayer, schema2.MediaTypeForeignLayer:
+		return true
+	}
+	return false
+}
+
+// ociTagRegexp matches the reference name annotations which only hold a tag.
+var ociTagRegexp = regexp.MustCompile(`^` + reference.TagRegexp.String() + `$`)
+
+// ociReference returns the reference an image of the index was saved with,
+// or nil if it has none. The full name is taken from the annotation set by
+// containerd and docker, or from the reference name annotation if it holds
+// a full name rather than only a tag. A tag alone is combined with the name
+// from the containerd annotation. An error is returned if the annotations
+// cannot be turned into a reference.
+func ociReference(annotations map[string]string) (reference.NamedTagged, error) {
+	imageName, refName := annotations[containerdAnnotationImageName], annotations[ociAnnotationRefName]
+	if imageName == "" && refName == "" {
+		return nil, nil
+	}
+	var tag string
+	if ociTagRegexp.MatchString(refName) {
+		tag, refName = refName, ""
+	}
+	for _, name := range []string{imageName, refName} {
+		if name == "" {
+			continue
+		}
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %q: %v", name, err)
+		}
+		if tagged, ok := named.(reference.NamedTagged); ok {
+			return tagged, nil
+		}
+		if tag != "" {
+			return reference.WithTag(reference.TrimNamed(named), tag)
+		}
+	}
+	if tag != "" {
+		return nil, fmt.Errorf("no image name for the tag %q", tag)
+	}
+	return nil, fmt.Errorf("no tag in the reference %q", imageName+refName)
+}
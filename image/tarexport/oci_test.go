@@ -0,0 +1,60 @@
+package tarexport
+
+import "testing"
+
+func TestOCIReference(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		expected    string
+		expectedErr string
+	}{
+		{annotations: nil},
+		{
+			annotations: map[string]string{ociAnnotationRefName: "latest"},
+			expectedErr: `no image name for the tag "latest"`,
+		},
+		{
+			annotations: map[string]string{containerdAnnotationImageName: "busybox"},
+			expectedErr: `no tag in the reference "busybox"`,
+		},
+		{
+			annotations: map[string]string{
+				containerdAnnotationImageName: "busybox",
+				ociAnnotationRefName:          "latest",
+			},
+			expected: "docker.io/library/busybox:latest",
+		},
+		{
+			annotations: map[string]string{ociAnnotationRefName: "busybox:1.26"},
+			expected:    "docker.io/library/busybox:1.26",
+		},
+		{
+			annotations: map[string]string{
+				containerdAnnotationImageName: "registry.example.com/foo/bar:v1",
+				ociAnnotationRefName:          "v1",
+			},
+			expected: "registry.example.com/foo/bar:v1",
+		},
+	} {
+		ref, err := ociReference(tc.annotations)
+		if tc.expectedErr != "" {
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Errorf("%v: expected error %q, got %v", tc.annotations, tc.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", tc.annotations, err)
+			continue
+		}
+		if tc.expected == "" {
+			if ref != nil {
+				t.Errorf("%v: expected no reference, got %s", tc.annotations, ref)
+			}
+			continue
+		}
+		if ref == nil || ref.String() != tc.expected {
+			t.Errorf("%v: expected %s, got %v", tc.annotations, tc.expected, ref)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/docker/distribution"
@@ -31,6 +32,7 @@ type saveSession struct {
 	images      map[image.ID]*imageDescriptor
 	savedLayers map[string]struct{}
 	diffIDPaths map[layer.DiffID]string // cache every diffID blob to avoid duplicates
+	ociBlobs    map[digest.Digest]int64 // size of every blob of an OCI image layout
 }
 
 func (l *tarexporter) Save(names []string, outStream io.Writer) error {
@@ -42,6 +44,16 @@ func (l *tarexporter) Save(names []string, outStream io.Writer) error {
 	return (&saveSession{tarexporter: l, images: images}).save(outStream)
 }
 
+// SaveOCI saves the images as an OCI image layout.
+func (l *tarexporter) SaveOCI(names []string, outStream io.Writer) error {
+	images, err := l.parseNames(names)
+	if err != nil {
+		return err
+	}
+
+	return (&saveSession{tarexporter: l, images: images}).saveOCI(outStream)
+}
+
 func (l *tarexporter) parseNames(names []string) (map[image.ID]*imageDescriptor, error) {
 	imgDescr := make(map[image.ID]*imageDescriptor)
 
@@ -360,3 +372,178 @@ func (s *saveSession) saveLayer(id layer.ChainID, legacyImg image.V1Image, creat
 	}
 	return src, nil
 }
+
+func (s *saveSession) saveOCI(outStream io.Writer) error {
+	s.ociBlobs = make(map[digest.Digest]int64)
+
+	tempDir, err := ioutil.TempDir("", "docker-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	s.outDir = tempDir
+	if err := os.MkdirAll(filepath.Join(tempDir, ociBlobsDirName, string(digest.Canonical)), 0755); err != nil {
+		return err
+	}
+
+	// sort the images for the index to be reproducible
+	ids := make([]string, 0, len(s.images))
+	for id := range s.images {
+		ids = append(ids, id.String())
+	}
+	sort.Strings(ids)
+
+	index := ociIndex{SchemaVersion: 2}
+	for _, idStr := range ids {
+		id := image.ID(idStr)
+		desc, err := s.saveOCIImage(id)
+		if err != nil {
+			return err
+		}
+
+		refs := s.images[id].refs
+		if len(refs) == 0 {
+			index.Manifests = append(index.Manifests, desc)
+		}
+		for _, ref := range refs {
+			d := desc
+			d.Annotations = map[string]string{
+				containerdAnnotationImageName: ref.String(),
+				ociAnnotationRefName:          ref.Tag(),
+			}
+			index.Manifests = append(index.Manifests, d)
+		}
+
+		s.tarexporter.loggerImgEvent.LogImageEvent(id.String(), id.String(), "save")
+	}
+
+	if err := writeJSONFile(filepath.Join(tempDir, ociLayoutFileName), ociLayout{ImageLayoutVersion: ociImageLayoutVersion}); err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(tempDir, ociIndexFileName), index); err != nil {
+		return err
+	}
+
+	fs, err := archive.Tar(tempDir, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	_, err = io.Copy(outStream, fs)
+	return err
+}
+
+// saveOCIImage writes the config, the layers and the manifest of the image
+// to the blobs of the layout, and returns the descriptor of the manifest.
+func (s *saveSession) saveOCIImage(id image.ID) (ociDescriptor, error) {
+	img, err := s.is.Get(id)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+
+	if len(img.RootFS.DiffIDs) == 0 {
+		return ociDescriptor{}, fmt.Errorf("empty export - not implemented")
+	}
+
+	config, err := s.writeOCIBlob(ociMediaTypeConfig, img.RawJSON())
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociMediaTypeManifest,
+		Config:        config,
+	}
+
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+	for _, diffID := range img.RootFS.DiffIDs {
+		rootFS.Append(diffID)
+		desc, err := s.saveOCILayer(rootFS.ChainID())
+		if err != nil {
+			return ociDescriptor{}, err
+		}
+		manifest.Layers = append(manifest.Layers, desc)
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	desc, err := s.writeOCIBlob(ociMediaTypeManifest, data)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	if img.OS != "" && img.Architecture != "" {
+		desc.Platform = &ociPlatform{Architecture: img.Architecture, OS: img.OS}
+	}
+	return desc, nil
+}
+
+// saveOCILayer writes the uncompressed layer to the blobs of the layout. The
+// digest of the blob is the DiffID of the layer.
+func (s *saveSession) saveOCILayer(id layer.ChainID) (ociDescriptor, error) {
+	l, err := s.ls.Get(id)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer layer.ReleaseAndLog(s.ls, l)
+
+	desc := ociDescriptor{
+		MediaType: ociMediaTypeLayer,
+		Digest:    digest.Digest(l.DiffID()),
+	}
+	if size, exists := s.ociBlobs[desc.Digest]; exists {
+		desc.Size = size
+		return desc, nil
+	}
+
+	// Use system.CreateSequential rather than os.Create. This ensures sequential
+	// file access on Windows to avoid eating into MM standby list.
+	// On Linux, this equates to a regular os.Create.
+	blobFile, err := system.CreateSequential(filepath.Join(s.outDir, ociBlobsDirName, desc.Digest.Algorithm().String(), desc.Digest.Hex()))
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer blobFile.Close()
+
+	arch, err := l.TarStream()
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer arch.Close()
+
+	if desc.Size, err = io.Copy(blobFile, arch); err != nil {
+		return ociDescriptor{}, err
+	}
+	s.ociBlobs[desc.Digest] = desc.Size
+	return desc, nil
+}
+
+// writeOCIBlob writes the data to the blobs of the layout, and returns its
+// descriptor.
+func (s *saveSession) writeOCIBlob(mediaType string, data []byte) (ociDescriptor, error) {
+	desc := ociDescriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	if _, exists := s.ociBlobs[desc.Digest]; exists {
+		return desc, nil
+	}
+	if err := ioutil.WriteFile(filepath.Join(s.outDir, ociBlobsDirName, desc.Digest.Algorithm().String(), desc.Digest.Hex()), data, 0644); err != nil {
+		return ociDescriptor{}, err
+	}
+	s.ociBlobs[desc.Digest] = desc.Size
+	return desc, nil
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -174,6 +174,33 @@ func (s *DockerSuite) TestSaveAndLoadRepoFlags(c *check.C) {
 	c.Assert(before, checker.Equals, after, check.Commentf("inspect is not the same after a save / load"))
 }
 
+func (s *DockerSuite) TestSaveAndLoadOCILayout(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	repoName := "foobar-save-load-oci-test"
+	dockerCmd(c, "tag", "busybox:latest", fmt.Sprintf("%v:latest", repoName))
+
+	before, _ := dockerCmd(c, "inspect", "--format", "{{.Id}}", repoName)
+
+	tmpDir, err := ioutil.TempDir("", "save-load-oci-test")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmpDir)
+	archivePath := filepath.Join(tmpDir, "oci.tar")
+
+	dockerCmd(c, "save", "--format", "oci", "-o", archivePath, repoName)
+
+	out, _, err := testutil.RunCommandPipelineWithOutput(
+		exec.Command("tar", "tf", archivePath),
+		exec.Command("grep", "-E", "^(oci-layout|index.json)$"))
+	c.Assert(err, checker.IsNil, check.Commentf("failed to find the OCI image layout files: %s, %v", out, err))
+
+	deleteImages(repoName)
+	out, _ = dockerCmd(c, "load", "-i", archivePath)
+	c.Assert(out, checker.Contains, fmt.Sprintf("Loaded image: %s:latest", repoName))
+
+	after, _ := dockerCmd(c, "inspect", "--format", "{{.Id}}", repoName)
+	c.Assert(after, checker.Equals, before, check.Commentf("image ID is not the same after a save / load of an OCI image layout"))
+}
+
 func (s *DockerSuite) TestSaveWithNoExistImage(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 
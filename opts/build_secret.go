@@ -0,0 +1,82 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BuildSecretOpt is a Value type for parsing the secrets of a build
+type BuildSecretOpt struct {
+	values map[string]string
+}
+
+// Set a new build secret value
+func (o *BuildSecretOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	var id, src string
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		value := parts[1]
+		switch key {
+		case "id":
+			if value != filepath.Base(value) || value == "." || value == ".." {
+				return fmt.Errorf("id must not be a path")
+			}
+			id = value
+		case "source", "src":
+			src = value
+		default:
+			return fmt.Errorf("invalid field in secret request: %s", key)
+		}
+	}
+
+	if id == "" {
+		return fmt.Errorf("id is required")
+	}
+	if src == "" {
+		return fmt.Errorf("source is required")
+	}
+	if _, exists := o.values[id]; exists {
+		return fmt.Errorf("duplicate secret id %s", id)
+	}
+
+	if o.values == nil {
+		o.values = make(map[string]string)
+	}
+	o.values[id] = src
+	return nil
+}
+
+// Type returns the type of this option
+func (o *BuildSecretOpt) Type() string {
+	return "secret"
+}
+
+// String returns a string repr of this option
+func (o *BuildSecretOpt) String() string {
+	secrets := []string{}
+	for id, src := range o.values {
+		secrets = append(secrets, fmt.Sprintf("%s -> %s", src, id))
+	}
+	sort.Strings(secrets)
+	return strings.Join(secrets, ", ")
+}
+
+// Value returns the paths of the secret files, by secret id
+func (o *BuildSecretOpt) Value() map[string]string {
+	return o.values
+}
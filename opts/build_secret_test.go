@@ -0,0 +1,34 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSecretOptions(t *testing.T) {
+	var opt BuildSecretOpt
+
+	assert.NoError(t, opt.Set("id=foo,src=/path/to/foo"))
+	assert.NoError(t, opt.Set("id=bar,source=bar.txt"))
+	assert.Equal(t, map[string]string{"foo": "/path/to/foo", "bar": "bar.txt"}, opt.Value())
+	assert.Equal(t, "/path/to/foo -> foo, bar.txt -> bar", opt.String())
+}
+
+func TestBuildSecretOptionsInvalid(t *testing.T) {
+	for _, testCase := range []string{
+		"foo",
+		"id=foo",
+		"src=foo",
+		"id=a/b,src=foo",
+		"id=..,src=foo",
+		"id=foo,src=foo,target=bar",
+	} {
+		var opt BuildSecretOpt
+		assert.Error(t, opt.Set(testCase), testCase)
+	}
+
+	var opt BuildSecretOpt
+	assert.NoError(t, opt.Set("id=foo,src=foo"))
+	assert.Error(t, opt.Set("id=foo,src=bar"))
+}
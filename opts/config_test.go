@@ -0,0 +1,106 @@
+package opts
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigOptionsSimple(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "app-config"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "app-config", req.ConfigName)
+	assert.Equal(t, "app-config", req.File.Name)
+	assert.Equal(t, "0", req.File.UID)
+	assert.Equal(t, "0", req.File.GID)
+}
+
+func TestConfigOptionsSourceTarget(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "source=foo,target=testing"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "foo", req.ConfigName)
+	assert.Equal(t, "testing", req.File.Name)
+}
+
+func TestConfigOptionsShorthand(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "src=foo,target=testing"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "foo", req.ConfigName)
+}
+
+func TestConfigOptionsCustomUidGid(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "source=foo,target=testing,uid=1000,gid=1001"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "foo", req.ConfigName)
+	assert.Equal(t, "testing", req.File.Name)
+	assert.Equal(t, "1000", req.File.UID)
+	assert.Equal(t, "1001", req.File.GID)
+}
+
+func TestConfigOptionsCustomMode(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "source=foo,target=testing,uid=1000,gid=1001,mode=0444"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "foo", req.ConfigName)
+	assert.Equal(t, "testing", req.File.Name)
+	assert.Equal(t, "1000", req.File.UID)
+	assert.Equal(t, "1001", req.File.GID)
+	assert.Equal(t, os.FileMode(0444), req.File.Mode)
+}
+
+func TestConfigOptionsTargetPath(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "source=foo,target=/etc/nginx/nginx.conf"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "foo", req.ConfigName)
+	assert.Equal(t, "/etc/nginx/nginx.conf", req.File.Name)
+}
+
+func TestConfigOptionsDefaultTarget(t *testing.T) {
+	var opt ConfigOpt
+
+	testCase := "source=foo,mode=0400"
+	assert.NoError(t, opt.Set(testCase))
+
+	reqs := opt.Value()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "foo", req.ConfigName)
+	assert.Equal(t, "foo", req.File.Name)
+}
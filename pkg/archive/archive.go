@@ -95,6 +95,8 @@ const (
 	Gzip
 	// Xz is xz compression algorithm.
 	Xz
+	// Zstd is zstd compression algorithm.
+	Zstd
 )
 
 const (
@@ -140,6 +142,7 @@ func DetectCompression(source []byte) Compression {
 		Bzip2: {0x42, 0x5A, 0x68},
 		Gzip:  {0x1F, 0x8B, 0x08},
 		Xz:    {0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00},
+		Zstd:  {0x28, 0xB5, 0x2F, 0xFD},
 	} {
 		if len(source) < len(m) {
 			logrus.Debug("Len too short")
@@ -158,6 +161,50 @@ func xzDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
 	return cmdStream(exec.Command(args[0], args[1:]...), archive)
 }
 
+// CheckZstd returns an error if zstd streams can neither be compressed nor
+// decompressed, which is done with the zstd command.
+func CheckZstd() error {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		return errors.New("zstd compression requires the zstd command, which is not installed or not in the PATH")
+	}
+	return nil
+}
+
+func zstdDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
+	if err := CheckZstd(); err != nil {
+		return nil, nil, err
+	}
+	args := []string{"zstd", "-d", "-c", "-q"}
+
+	return cmdStream(exec.Command(args[0], args[1:]...), archive)
+}
+
+// zstdCompress returns a writer compressing to dest with the zstd command.
+// The writer must be closed for the command to complete.
+func zstdCompress(dest io.Writer) (io.WriteCloser, error) {
+	if err := CheckZstd(); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("zstd", "-c", "-q")
+	cmd.Stdout = dest
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return ioutils.NewWriteCloserWrapper(stdin, func() error {
+		stdin.Close()
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("%s: %s", err, errBuf.String())
+		}
+		return nil
+	}), nil
+}
+
 // DecompressStream decompresses the archive and returns a ReaderCloser with the decompressed archive.
 func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 	p := pools.BufioReader32KPool
@@ -199,6 +246,16 @@ func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 			<-chdone
 			return readBufWrapper.Close()
 		}), nil
+	case Zstd:
+		zstdReader, chdone, err := zstdDecompress(buf)
+		if err != nil {
+			return nil, err
+		}
+		readBufWrapper := p.NewReadCloserWrapper(buf, zstdReader)
+		return ioutils.NewReadCloserWrapper(readBufWrapper, func() error {
+			<-chdone
+			return readBufWrapper.Close()
+		}), nil
 	default:
 		return nil, fmt.Errorf("Unsupported compression format %s", (&compression).Extension())
 	}
@@ -216,6 +273,13 @@ func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, er
 		gzWriter := gzip.NewWriter(dest)
 		writeBufWrapper := p.NewWriteCloserWrapper(buf, gzWriter)
 		return writeBufWrapper, nil
+	case Zstd:
+		zstdWriter, err := zstdCompress(dest)
+		if err != nil {
+			return nil, err
+		}
+		writeBufWrapper := p.NewWriteCloserWrapper(buf, zstdWriter)
+		return writeBufWrapper, nil
 	case Bzip2, Xz:
 		// archive/bzip2 does not support writing, and there is no xz support at all
 		// However, this is not a problem as docker only currently generates gzipped tars
@@ -323,10 +387,30 @@ func (compression *Compression) Extension() string {
 		return "tar.gz"
 	case Xz:
 		return "tar.xz"
+	case Zstd:
+		return "tar.zst"
 	}
 	return ""
 }
 
+// ParseCompression returns the compression algorithm with the given name:
+// "none", "gzip", "bzip2", "xz" or "zstd".
+func ParseCompression(name string) (Compression, error) {
+	switch strings.ToLower(name) {
+	case "none", "uncompressed":
+		return Uncompressed, nil
+	case "gzip":
+		return Gzip, nil
+	case "bzip2":
+		return Bzip2, nil
+	case "xz":
+		return Xz, nil
+	case "zstd":
+		return Zstd, nil
+	}
+	return Uncompressed, fmt.Errorf("invalid compression %q", name)
+}
+
 // FileInfoHeader creates a populated Header from fi.
 // Compared to archive pkg this function fills in more information.
 func FileInfoHeader(path, name string, fi os.FileInfo) (*tar.Header, error) {
@@ -965,7 +1049,7 @@ loop:
 // Untar reads a stream of bytes from `archive`, parses it as a tar archive,
 // and unpacks it into the directory at `dest`.
 // The archive may be compressed with one of the following algorithms:
-//  identity (uncompressed), gzip, bzip2, xz.
+//  identity (uncompressed), gzip, bzip2, xz, zstd.
 // FIXME: specify behavior when target path exists vs. doesn't exist.
 func Untar(tarArchive io.Reader, dest string, options *TarOptions) error {
 	return untarHandler(tarArchive, dest, options, true)
@@ -145,6 +145,74 @@ func TestDecompressStreamXz(t *testing.T) {
 	testDecompressStream(t, "xz", "xz -f")
 }
 
+func TestDecompressStreamZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd not installed")
+	}
+	testDecompressStream(t, "zst", "zstd -f -q")
+}
+
+func TestCompressStreamZstd(t *testing.T) {
+	if _, err := exec.LookPath("zstd"); err != nil {
+		t.Skip("zstd not installed")
+	}
+	var compressed bytes.Buffer
+	w, err := CompressStream(&compressed, Zstd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("zstd compressed data "), 1024)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if c := DetectCompression(compressed.Bytes()); c != Zstd {
+		t.Fatalf("Expected the stream to be detected as zstd, got %s", c.Extension())
+	}
+	if compressed.Len() >= len(data) {
+		t.Fatalf("Expected the stream to be compressed, got %d bytes out of %d", compressed.Len(), len(data))
+	}
+
+	r, err := DecompressStream(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decompressed, data) {
+		t.Fatal("The decompressed data differs from the original data")
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	for name, expected := range map[string]Compression{
+		"none":  Uncompressed,
+		"gzip":  Gzip,
+		"bzip2": Bzip2,
+		"xz":    Xz,
+		"zstd":  Zstd,
+		"ZSTD":  Zstd,
+	} {
+		c, err := ParseCompression(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != expected {
+			t.Fatalf("Expected %s to be parsed as %s, got %s", name, expected.Extension(), c.Extension())
+		}
+	}
+	if _, err := ParseCompression("lz4"); err == nil {
+		t.Fatal("Expected an error for an unknown compression")
+	}
+}
+
 func TestCompressStreamXzUnsupported(t *testing.T) {
 	dest, err := os.Create(tmp + "dest")
 	if err != nil {
@@ -221,6 +289,26 @@ func TestExtensionXz(t *testing.T) {
 	}
 }
 
+func TestCompressStreamZstdNotInstalled(t *testing.T) {
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", "")
+
+	if _, err := CompressStream(ioutil.Discard, Zstd); err == nil || !strings.Contains(err.Error(), "requires the zstd command") {
+		t.Fatalf("Expected an error about the missing zstd command, got %v", err)
+	}
+	if _, err := DecompressStream(bytes.NewReader([]byte{0x28, 0xB5, 0x2F, 0xFD, 0, 0, 0, 0, 0, 0})); err == nil || !strings.Contains(err.Error(), "requires the zstd command") {
+		t.Fatalf("Expected an error about the missing zstd command, got %v", err)
+	}
+}
+
+func TestExtensionZstd(t *testing.T) {
+	compression := Zstd
+	output := compression.Extension()
+	if output != "tar.zst" {
+		t.Fatalf("The extension of a zstd archive should be 'tar.zst'")
+	}
+}
+
 func TestCmdStreamLargeStderr(t *testing.T) {
 	cmd := exec.Command("sh", "-c", "dd if=/dev/zero bs=1k count=1000 of=/dev/stderr; echo hello")
 	out, _, err := cmdStream(cmd, nil)
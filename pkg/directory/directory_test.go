@@ -190,3 +190,33 @@ func TestSizeNonExistingDirectory(t *testing.T) {
 		t.Fatalf("error is expected")
 	}
 }
+
+// Test usage of a directory with 1 file and 1 non-empty directory
+func TestUsageFileAndNestedDirectoryNonempty(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "TestUsageFileAndNestedDirectoryNonempty")
+	if err != nil {
+		t.Fatalf("failed to create directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0755); err != nil {
+		t.Fatalf("failed to create nested directory: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "file"), []byte("docker"), 0644); err != nil {
+		t.Fatalf("failed to create file: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "nested", "file"), []byte("docker"), 0644); err != nil {
+		t.Fatalf("failed to create file in nested directory: %s", err)
+	}
+
+	size, inodes, err := Usage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 12 {
+		t.Fatalf("directory with 6-byte file and nested directory with 6-byte file has size: %d", size)
+	}
+	if inodes != 4 {
+		t.Fatalf("directory with 1 file and nested directory with 1 file has %d inodes", inodes)
+	}
+}
@@ -10,27 +10,28 @@ import (
 
 // Size walks a directory tree and returns its total size in bytes.
 func Size(dir string) (size int64, err error) {
+	size, _, err = Usage(dir)
+	return
+}
+
+// Usage walks a directory tree and returns its total size in bytes, and the
+// number of inodes it uses, including the inode of the directory itself.
+func Usage(dir string) (size, inodes int64, err error) {
 	data := make(map[uint64]struct{})
 	err = filepath.Walk(dir, func(d string, fileInfo os.FileInfo, err error) error {
 		if err != nil {
-			// if dir does not exist, Size() returns the error.
-			// if dir/x disappeared while walking, Size() ignores dir/x.
+			// if dir does not exist, Usage() returns the error.
+			// if dir/x disappeared while walking, Usage() ignores dir/x.
 			if os.IsNotExist(err) && d != dir {
 				return nil
 			}
 			return err
 		}
 
-		// Ignore directory sizes
 		if fileInfo == nil {
 			return nil
 		}
 
-		s := fileInfo.Size()
-		if fileInfo.IsDir() || s == 0 {
-			return nil
-		}
-
 		// Check inode to handle hard links correctly
 		inode := fileInfo.Sys().(*syscall.Stat_t).Ino
 		// inode is not a uint64 on all platforms. Cast it to avoid issues.
@@ -39,8 +40,14 @@ func Size(dir string) (size int64, err error) {
 		}
 		// inode is not a uint64 on all platforms. Cast it to avoid issues.
 		data[uint64(inode)] = struct{}{}
+		inodes++
+
+		// Ignore directory sizes
+		if fileInfo.IsDir() {
+			return nil
+		}
 
-		size += s
+		size += fileInfo.Size()
 
 		return nil
 	})
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -21,6 +22,10 @@ type ServiceOptions struct {
 	Mirrors            []string `json:"registry-mirrors,omitempty"`
 	InsecureRegistries []string `json:"insecure-registries,omitempty"`
 
+	// HostMirrors are the mirrors of the registries other than Docker Hub,
+	// by registry host, in order of preference.
+	HostMirrors map[string][]string `json:"registry-host-mirrors,omitempty"`
+
 	// V2Only controls access to legacy registries.  If it is set to true via the
 	// command line flag the daemon will not attempt to contact v1 legacy registries
 	V2Only bool `json:"disable-legacy-registry,omitempty"`
@@ -29,7 +34,8 @@ type ServiceOptions struct {
 // serviceConfig holds daemon configuration for the registry service.
 type serviceConfig struct {
 	registrytypes.ServiceConfig
-	V2Only bool
+	V2Only      bool
+	HostMirrors map[string][]string
 }
 
 var (
@@ -78,6 +84,7 @@ func (options *ServiceOptions) InstallCliFlags(flags *pflag.FlagSet) {
 	insecureRegistries := opts.NewNamedListOptsRef("insecure-registries", &options.InsecureRegistries, ValidateIndexName)
 
 	flags.Var(mirrors, "registry-mirror", "Preferred Docker registry mirror")
+	flags.Var(&hostMirrorsOpt{values: &options.HostMirrors}, "registry-host-mirror", "Preferred mirror of a registry (host=mirror)")
 	flags.Var(insecureRegistries, "insecure-registry", "Enable insecure registry communication")
 
 	options.installCliPlatformFlags(flags)
@@ -97,6 +104,7 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 
 	config.LoadMirrors(options.Mirrors)
 	config.LoadInsecureRegistries(options.InsecureRegistries)
+	config.LoadHostMirrors(options.HostMirrors)
 
 	return config
 }
@@ -131,6 +139,18 @@ func (config *serviceConfig) LoadMirrors(mirrors []string) error {
 	return nil
 }
 
+// LoadHostMirrors loads the mirrors of the registries other than Docker Hub
+// to config, after removing duplicates. Returns an error if hostMirrors
+// contains an invalid registry host or mirror.
+func (config *serviceConfig) LoadHostMirrors(hostMirrors map[string][]string) error {
+	validated, err := ValidateHostMirrors(hostMirrors)
+	if err != nil {
+		return err
+	}
+	config.HostMirrors = validated
+	return nil
+}
+
 // LoadInsecureRegistries loads insecure registries to config
 func (config *serviceConfig) LoadInsecureRegistries(registries []string) error {
 	// Localhost is by default considered as an insecure registry
@@ -280,6 +300,103 @@ func ValidateMirror(val string) (string, error) {
 	return strings.TrimSuffix(val, "/") + "/", nil
 }
 
+// ValidateHostMirrors validates the mirrors of the registries other than
+// Docker Hub, and returns them by normalized registry host without duplicates.
+func ValidateHostMirrors(hostMirrors map[string][]string) (map[string][]string, error) {
+	validated := make(map[string][]string, len(hostMirrors))
+	for host, mirrors := range hostMirrors {
+		h, err := validateMirroredHost(host)
+		if err != nil {
+			return nil, err
+		}
+		for _, mirror := range mirrors {
+			m, err := ValidateMirror(mirror)
+			if err != nil {
+				return nil, err
+			}
+			validated[h] = appendMirror(validated[h], m)
+		}
+	}
+	return validated, nil
+}
+
+// validateMirroredHost validates the host of a registry other than Docker
+// Hub that mirrors are configured for.
+func validateMirroredHost(val string) (string, error) {
+	host, err := ValidateIndexName(val)
+	if err != nil {
+		return "", err
+	}
+	if host == IndexName || host == DefaultV2Registry.Host {
+		return "", fmt.Errorf("invalid mirrored registry %s: use registry-mirrors to configure the mirrors of Docker Hub", val)
+	}
+	if err := validateNoScheme(host); err != nil {
+		return "", fmt.Errorf("invalid mirrored registry %s: should not contain '://'", val)
+	}
+	if err := validateHostPort(host); err != nil {
+		return "", fmt.Errorf("invalid mirrored registry %s: %v", val, err)
+	}
+	return host, nil
+}
+
+func appendMirror(mirrors []string, mirror string) []string {
+	for _, m := range mirrors {
+		if m == mirror {
+			return mirrors
+		}
+	}
+	return append(mirrors, mirror)
+}
+
+// hostMirrorsOpt is a Value type for parsing the mirrors of registries, in
+// the host=mirror format.
+type hostMirrorsOpt struct {
+	values *map[string][]string
+}
+
+// Set adds a mirror of a registry
+func (o *hostMirrorsOpt) Set(val string) error {
+	parts := strings.SplitN(val, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid registry mirror %q: must be host=mirror", val)
+	}
+	host, err := validateMirroredHost(parts[0])
+	if err != nil {
+		return err
+	}
+	mirror, err := ValidateMirror(parts[1])
+	if err != nil {
+		return err
+	}
+	if *o.values == nil {
+		*o.values = make(map[string][]string)
+	}
+	(*o.values)[host] = appendMirror((*o.values)[host], mirror)
+	return nil
+}
+
+// String returns the mirrors of the registries, in the host=mirror format
+func (o *hostMirrorsOpt) String() string {
+	var mirrors []string
+	for host, hostMirrors := range *o.values {
+		for _, m := range hostMirrors {
+			mirrors = append(mirrors, host+"="+m)
+		}
+	}
+	sort.Strings(mirrors)
+	return fmt.Sprintf("%v", mirrors)
+}
+
+// Type returns the type of this option
+func (o *hostMirrorsOpt) Type() string {
+	return "list"
+}
+
+// Name returns the name of the option in the configuration file
+func (o *hostMirrorsOpt) Name() string {
+	return "registry-host-mirrors"
+}
+
 // ValidateIndexName validates an index name.
 func ValidateIndexName(val string) (string, error) {
 	// TODO: upstream this to check to reference package
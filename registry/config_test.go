@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -139,3 +140,55 @@ func TestLoadInsecureRegistries(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateHostMirrors(t *testing.T) {
+	mirrors, err := ValidateHostMirrors(map[string][]string{
+		"gcr.io":         {"https://mirror-1.com", "https://mirror-1.com/", "http://mirror-2.com"},
+		"localhost:5000": {"http://localhost:5001"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"https://mirror-1.com/", "http://mirror-2.com/"}; !reflect.DeepEqual(mirrors["gcr.io"], expected) {
+		t.Fatalf("expected the mirrors %v, got %v", expected, mirrors["gcr.io"])
+	}
+
+	for _, invalid := range []map[string][]string{
+		{"docker.io": {"https://mirror-1.com"}},
+		{"index.docker.io": {"https://mirror-1.com"}},
+		{"https://gcr.io": {"https://mirror-1.com"}},
+		{"-gcr.io": {"https://mirror-1.com"}},
+		{"gcr.io": {"ftp://mirror-1.com"}},
+		{"gcr.io": {"https://mirror-1.com/v2/"}},
+	} {
+		if _, err := ValidateHostMirrors(invalid); err == nil {
+			t.Errorf("expected an error for %v", invalid)
+		}
+	}
+}
+
+func TestHostMirrorsOpt(t *testing.T) {
+	var values map[string][]string
+	opt := &hostMirrorsOpt{values: &values}
+	for _, val := range []string{"gcr.io=https://mirror-1.com", "gcr.io=https://mirror-2.com", "quay.io=https://mirror-1.com", "gcr.io=https://mirror-1.com/"} {
+		if err := opt.Set(val); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := map[string][]string{
+		"gcr.io":  {"https://mirror-1.com/", "https://mirror-2.com/"},
+		"quay.io": {"https://mirror-1.com/"},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+	if s := opt.String(); s != "[gcr.io=https://mirror-1.com/ gcr.io=https://mirror-2.com/ quay.io=https://mirror-1.com/]" {
+		t.Fatalf("unexpected string %s", s)
+	}
+
+	for _, invalid := range []string{"gcr.io", "docker.io=https://mirror-1.com", "gcr.io=mirror-1.com"} {
+		if err := opt.Set(invalid); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
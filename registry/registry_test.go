@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -686,6 +687,54 @@ func TestMirrorEndpointLookup(t *testing.T) {
 	}
 }
 
+func TestHostMirrorEndpointLookup(t *testing.T) {
+	config := makeServiceConfig(nil, nil)
+	if err := config.LoadHostMirrors(map[string][]string{
+		"gcr.io": {"https://gcr-1.mirror", "http://gcr-2.mirror/", "https://gcr-1.mirror/"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s := DefaultService{config: config}
+
+	pullAPIEndpoints, err := s.LookupPullEndpoints("gcr.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hosts []string
+	for _, endpoint := range pullAPIEndpoints {
+		if endpoint.Version != APIVersion2 {
+			continue
+		}
+		if endpoint.Mirror != (endpoint.URL.Host != "gcr.io") {
+			t.Fatalf("unexpected mirror flag for %s", endpoint.URL)
+		}
+		hosts = append(hosts, endpoint.URL.Host)
+	}
+	if expected := []string{"gcr-1.mirror", "gcr-2.mirror", "gcr.io"}; !reflect.DeepEqual(hosts, expected) {
+		t.Fatalf("expected the v2 pull endpoints %v, got %v", expected, hosts)
+	}
+
+	pushAPIEndpoints, err := s.LookupPushEndpoints("gcr.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, endpoint := range pushAPIEndpoints {
+		if endpoint.Mirror {
+			t.Fatalf("push endpoints should not contain the mirror %s", endpoint.URL)
+		}
+	}
+
+	pullAPIEndpoints, err = s.LookupPullEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, endpoint := range pullAPIEndpoints {
+		if endpoint.Mirror {
+			t.Fatalf("pull endpoints of quay.io should not contain the mirror %s", endpoint.URL)
+		}
+	}
+}
+
 func TestPushRegistryTag(t *testing.T) {
 	r := spawnTestRegistrySession(t)
 	repoRef, err := reference.ParseNormalizedNamed(REPO)
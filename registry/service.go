@@ -33,6 +33,7 @@ type Service interface {
 	TLSConfig(hostname string) (*tls.Config, error)
 	LoadMirrors([]string) error
 	LoadInsecureRegistries([]string) error
+	LoadHostMirrors(map[string][]string) error
 }
 
 // DefaultService is a registry service. It tracks configuration data such as a list
@@ -82,6 +83,15 @@ func (s *DefaultService) LoadMirrors(mirrors []string) error {
 	return s.config.LoadMirrors(mirrors)
 }
 
+// LoadHostMirrors loads the mirrors of the registries other than Docker Hub
+// for Service
+func (s *DefaultService) LoadHostMirrors(hostMirrors map[string][]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config.LoadHostMirrors(hostMirrors)
+}
+
 // LoadInsecureRegistries loads insecure registries for Service
 func (s *DefaultService) LoadInsecureRegistries(registries []string) error {
 	s.mu.Lock()
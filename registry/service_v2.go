@@ -11,26 +11,9 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 	tlsConfig := tlsconfig.ServerDefault()
 	if hostname == DefaultNamespace || hostname == IndexHostname {
 		// v2 mirrors
-		for _, mirror := range s.config.Mirrors {
-			if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
-				mirror = "https://" + mirror
-			}
-			mirrorURL, err := url.Parse(mirror)
-			if err != nil {
-				return nil, err
-			}
-			mirrorTLSConfig, err := s.tlsConfigForMirror(mirrorURL)
-			if err != nil {
-				return nil, err
-			}
-			endpoints = append(endpoints, APIEndpoint{
-				URL: mirrorURL,
-				// guess mirrors are v2
-				Version:      APIVersion2,
-				Mirror:       true,
-				TrimHostname: true,
-				TLSConfig:    mirrorTLSConfig,
-			})
+		endpoints, err = s.mirrorEndpoints(s.config.Mirrors)
+		if err != nil {
+			return nil, err
 		}
 		// v2 registry
 		endpoints = append(endpoints, APIEndpoint{
@@ -44,22 +27,26 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 		return endpoints, nil
 	}
 
+	// v2 mirrors of the registry, falling back to the registry itself
+	endpoints, err = s.mirrorEndpoints(s.config.HostMirrors[hostname])
+	if err != nil {
+		return nil, err
+	}
+
 	tlsConfig, err = s.tlsConfig(hostname)
 	if err != nil {
 		return nil, err
 	}
 
-	endpoints = []APIEndpoint{
-		{
-			URL: &url.URL{
-				Scheme: "https",
-				Host:   hostname,
-			},
-			Version:      APIVersion2,
-			TrimHostname: true,
-			TLSConfig:    tlsConfig,
+	endpoints = append(endpoints, APIEndpoint{
+		URL: &url.URL{
+			Scheme: "https",
+			Host:   hostname,
 		},
-	}
+		Version:      APIVersion2,
+		TrimHostname: true,
+		TLSConfig:    tlsConfig,
+	})
 
 	if tlsConfig.InsecureSkipVerify {
 		endpoints = append(endpoints, APIEndpoint{
@@ -76,3 +63,30 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 
 	return endpoints, nil
 }
+
+// mirrorEndpoints returns the endpoints of the mirrors, in order of
+// preference.
+func (s *DefaultService) mirrorEndpoints(mirrors []string) (endpoints []APIEndpoint, err error) {
+	for _, mirror := range mirrors {
+		if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
+			mirror = "https://" + mirror
+		}
+		mirrorURL, err := url.Parse(mirror)
+		if err != nil {
+			return nil, err
+		}
+		mirrorTLSConfig, err := s.tlsConfigForMirror(mirrorURL)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, APIEndpoint{
+			URL: mirrorURL,
+			// guess mirrors are v2
+			Version:      APIVersion2,
+			Mirror:       true,
+			TrimHostname: true,
+			TLSConfig:    mirrorTLSConfig,
+		})
+	}
+	return endpoints, nil
+}
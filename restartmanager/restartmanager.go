@@ -3,6 +3,7 @@ package restartmanager
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -10,9 +11,13 @@ import (
 )
 
 const (
-	backoffMultiplier = 2
-	defaultTimeout    = 100 * time.Millisecond
-	maxRestartTimeout = 1 * time.Minute
+	backoffMultiplier  = 2
+	defaultTimeout     = 100 * time.Millisecond
+	maxRestartTimeout  = 1 * time.Minute
+	defaultResetWindow = 10 * time.Second
+	// crashLoopExits is the number of consecutive exits before the reset
+	// window elapses after which a container is considered crash-looping.
+	crashLoopExits = 5
 )
 
 // ErrRestartCanceled is returned when the restart manager has been
@@ -23,6 +28,7 @@ var ErrRestartCanceled = errors.New("restart canceled")
 type RestartManager interface {
 	Cancel() error
 	ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error)
+	IsCrashLooping() bool
 }
 
 type restartManager struct {
@@ -31,6 +37,8 @@ type restartManager struct {
 	policy       container.RestartPolicy
 	restartCount int
 	timeout      time.Duration
+	fastExits    int
+	crashLooping bool
 	active       bool
 	cancel       chan struct{}
 	canceled     bool
@@ -66,19 +74,27 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if rm.active {
 		return false, nil, fmt.Errorf("invalid call on an active restart manager")
 	}
-	// if the container ran for more than 10s, regardless of status and policy reset the
-	// the timeout back to the default.
-	if executionDuration.Seconds() >= 10 {
+	// if the container ran for longer than the reset window, regardless of status and
+	// policy reset the timeout back to the initial delay.
+	if executionDuration >= rm.resetWindow() {
 		rm.timeout = 0
+		rm.fastExits = 0
+	} else {
+		rm.fastExits++
 	}
+	// the container kept exiting before the reset window elapsed: it is
+	// crash-looping, whatever the backoff settings are.
+	rm.crashLooping = rm.fastExits >= crashLoopExits
+
+	maxTimeout := rm.maxDelay()
 	switch {
 	case rm.timeout == 0:
-		rm.timeout = defaultTimeout
-	case rm.timeout < maxRestartTimeout:
-		rm.timeout *= backoffMultiplier
+		rm.timeout = rm.initialDelay()
+	case rm.timeout < maxTimeout:
+		rm.timeout = time.Duration(float64(rm.timeout) * rm.multiplier())
 	}
-	if rm.timeout > maxRestartTimeout {
-		rm.timeout = maxRestartTimeout
+	if rm.timeout >= maxTimeout {
+		rm.timeout = maxTimeout
 	}
 
 	var restart bool
@@ -101,6 +117,11 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 
 	rm.restartCount++
 
+	delay := rm.timeout
+	if jitter := rm.policy.Jitter; jitter > 0 {
+		delay += time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
 	unlockOnExit = false
 	rm.active = true
 	rm.Unlock()
@@ -111,7 +132,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 		case <-rm.cancel:
 			ch <- ErrRestartCanceled
 			close(ch)
-		case <-time.After(rm.timeout):
+		case <-time.After(delay):
 			rm.Lock()
 			close(ch)
 			rm.active = false
@@ -122,6 +143,46 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	return true, ch, nil
 }
 
+// IsCrashLooping returns whether the container has been restarting in a
+// tight loop, without ever running longer than the reset window.
+func (rm *restartManager) IsCrashLooping() bool {
+	rm.Lock()
+	defer rm.Unlock()
+	return rm.crashLooping
+}
+
+func (rm *restartManager) initialDelay() time.Duration {
+	if rm.policy.InitialDelay > 0 {
+		return rm.policy.InitialDelay
+	}
+	return defaultTimeout
+}
+
+func (rm *restartManager) maxDelay() time.Duration {
+	max := maxRestartTimeout
+	if rm.policy.MaxDelay > 0 {
+		max = rm.policy.MaxDelay
+	}
+	if initial := rm.initialDelay(); max < initial {
+		max = initial
+	}
+	return max
+}
+
+func (rm *restartManager) multiplier() float64 {
+	if rm.policy.Multiplier > 0 {
+		return rm.policy.Multiplier
+	}
+	return backoffMultiplier
+}
+
+func (rm *restartManager) resetWindow() time.Duration {
+	if rm.policy.ResetWindow > 0 {
+		return rm.policy.ResetWindow
+	}
+	return defaultResetWindow
+}
+
 func (rm *restartManager) Cancel() error {
 	rm.Do(func() {
 		rm.Lock()
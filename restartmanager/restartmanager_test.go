@@ -34,3 +34,75 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerCustomBackoff(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     3 * time.Second,
+		Multiplier:   1.5,
+	}
+	rm := New(policy, 0).(*restartManager)
+	expected := []time.Duration{1 * time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond, 3 * time.Second, 3 * time.Second}
+	for i, e := range expected {
+		rm.active = false
+		if _, _, err := rm.ShouldRestart(1, false, 0); err != nil {
+			t.Fatal(err)
+		}
+		if rm.timeout != e {
+			t.Fatalf("restart %d: expected a timeout of %s but has %s", i, e, rm.timeout)
+		}
+	}
+}
+
+func TestRestartManagerCrashLoop(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     4 * time.Second,
+		ResetWindow:  30 * time.Second,
+	}
+	rm := New(policy, 0).(*restartManager)
+	for i := 0; i < crashLoopExits; i++ {
+		if rm.IsCrashLooping() {
+			t.Fatalf("restart manager should not be crash-looping after %d exits", i)
+		}
+		rm.active = false
+		if _, _, err := rm.ShouldRestart(1, false, 20*time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !rm.IsCrashLooping() {
+		t.Fatalf("restart manager should be crash-looping after %d fast exits", crashLoopExits)
+	}
+
+	rm.active = false
+	if _, _, err := rm.ShouldRestart(1, false, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if rm.IsCrashLooping() {
+		t.Fatal("restart manager should not be crash-looping after a run longer than the reset window")
+	}
+	if rm.timeout != policy.InitialDelay {
+		t.Fatalf("restart manager should have a timeout of %s but has %s", policy.InitialDelay, rm.timeout)
+	}
+}
+
+func TestRestartManagerCrashLoopConstantDelay(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     1 * time.Second,
+		Multiplier:   1,
+	}
+	rm := New(policy, 0).(*restartManager)
+	for i := 0; i < crashLoopExits; i++ {
+		rm.active = false
+		if _, _, err := rm.ShouldRestart(1, false, time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !rm.IsCrashLooping() {
+		t.Fatal("restart manager should be crash-looping without a growing delay")
+	}
+}
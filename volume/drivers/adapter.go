@@ -2,6 +2,8 @@ package volumedrivers
 
 import (
 	"errors"
+	"io"
+	"net/url"
 	"path/filepath"
 	"strings"
 
@@ -92,6 +94,67 @@ func (a *volumeDriverAdapter) Scope() string {
 	return cap.Scope
 }
 
+func (a *volumeDriverAdapter) Clone(src volume.Volume, name string) (volume.Volume, map[string]string, error) {
+	if !a.getCapabilities().Clone {
+		return nil, nil, volume.ErrNotSupported
+	}
+	if err := a.proxy.Clone(name, src.Name()); err != nil {
+		return nil, nil, err
+	}
+	return &volumeAdapter{
+		proxy:        a.proxy,
+		name:         name,
+		driverName:   a.name,
+		baseHostPath: a.baseHostPath,
+	}, nil, nil
+}
+
+// streamClient is implemented by the plugin clients which can stream the
+// body of requests and responses, as required by the archive endpoints.
+type streamClient interface {
+	Stream(serviceMethod string, args interface{}) (io.ReadCloser, error)
+	SendFile(serviceMethod string, data io.Reader, ret interface{}) error
+}
+
+type volumeDriverExportRequest struct {
+	Name string
+}
+
+type volumeDriverImportResponse struct {
+	Err string
+}
+
+func (a *volumeDriverAdapter) archiveClient() (streamClient, error) {
+	c, ok := a.proxy.client.(streamClient)
+	if !ok || !a.getCapabilities().Archive {
+		return nil, volume.ErrNotSupported
+	}
+	return c, nil
+}
+
+func (a *volumeDriverAdapter) Export(v volume.Volume) (io.ReadCloser, error) {
+	c, err := a.archiveClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.Stream("VolumeDriver.Export", volumeDriverExportRequest{Name: v.Name()})
+}
+
+func (a *volumeDriverAdapter) Import(v volume.Volume, data io.Reader) error {
+	c, err := a.archiveClient()
+	if err != nil {
+		return err
+	}
+	var ret volumeDriverImportResponse
+	if err := c.SendFile("VolumeDriver.Import?name="+url.QueryEscape(v.Name()), data, &ret); err != nil {
+		return err
+	}
+	if ret.Err != "" {
+		return errors.New(ret.Err)
+	}
+	return nil
+}
+
 func (a *volumeDriverAdapter) getCapabilities() volume.Capability {
 	if a.capabilities != nil {
 		return *a.capabilities
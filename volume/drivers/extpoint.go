@@ -48,6 +48,8 @@ type volumeDriver interface {
 	Get(name string) (volume *proxyVolume, err error)
 	// Capabilities gets the list of capabilities of the driver
 	Capabilities() (capabilities volume.Capability, err error)
+	// Clone creates a volume with the given name and a copy of the data of the source volume
+	Clone(name, source string) (err error)
 }
 
 type driverExtpoint struct {
@@ -240,3 +240,31 @@ func (pp *volumeDriverProxy) Capabilities() (capabilities volume.Capability, err
 
 	return
 }
+
+type volumeDriverProxyCloneRequest struct {
+	Name   string
+	Source string
+}
+
+type volumeDriverProxyCloneResponse struct {
+	Err string
+}
+
+func (pp *volumeDriverProxy) Clone(name string, source string) (err error) {
+	var (
+		req volumeDriverProxyCloneRequest
+		ret volumeDriverProxyCloneResponse
+	)
+
+	req.Name = name
+	req.Source = source
+	if err = pp.Call("VolumeDriver.Clone", req, &ret); err != nil {
+		return
+	}
+
+	if ret.Err != "" {
+		err = errors.New(ret.Err)
+	}
+
+	return
+}
@@ -6,6 +6,7 @@ package local
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -17,8 +18,13 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/volume"
 )
 
@@ -55,7 +61,12 @@ type activeMount struct {
 // New instantiates a new Root instance with the provided scope. Scope
 // is the base path that the Root instance uses to store its
 // volumes. The base path is created here if it does not exist.
-func New(scope string, rootUID, rootGID int) (*Root, error) {
+// The uid and gid maps are the ranges remapped for user namespaces, if any.
+func New(scope string, uidMaps, gidMaps []idtools.IDMap) (*Root, error) {
+	rootUID, rootGID, err := idtools.GetRootUIDGID(uidMaps, gidMaps)
+	if err != nil {
+		return nil, err
+	}
 	rootDirectory := filepath.Join(scope, volumesPathName)
 
 	if err := idtools.MkdirAllAs(rootDirectory, 0700, rootUID, rootGID); err != nil {
@@ -68,6 +79,9 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 		volumes: make(map[string]*localVolume),
 		rootUID: rootUID,
 		rootGID: rootGID,
+		uidMaps: uidMaps,
+		gidMaps: gidMaps,
+		quota:   newQuotaCtl(rootDirectory),
 	}
 
 	dirs, err := ioutil.ReadDir(rootDirectory)
@@ -90,6 +104,7 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 			driverName: r.Name(),
 			name:       name,
 			path:       r.DataPath(name),
+			quota:      r.quota,
 		}
 		r.volumes[name] = v
 		optsFilePath := filepath.Join(rootDirectory, name, "opts.json")
@@ -127,6 +142,9 @@ type Root struct {
 	volumes map[string]*localVolume
 	rootUID int
 	rootGID int
+	uidMaps []idtools.IDMap
+	gidMaps []idtools.IDMap
+	quota   *quotaCtl
 }
 
 // List lists all the volumes
@@ -167,7 +185,17 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	}
 
 	path := r.DataPath(name)
-	if err := idtools.MkdirAllAs(path, 0755, r.rootUID, r.rootGID); err != nil {
+	v = &localVolume{
+		driverName: r.Name(),
+		name:       name,
+		path:       path,
+		quota:      r.quota,
+	}
+	if err := setOpts(v, opts); err != nil {
+		return nil, err
+	}
+
+	if err := idtools.MkdirAllAs(filepath.Dir(path), 0755, r.rootUID, r.rootGID); err != nil {
 		if os.IsExist(err) {
 			return nil, fmt.Errorf("volume already exists under %s", filepath.Dir(path))
 		}
@@ -181,16 +209,16 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		}
 	}()
 
-	v = &localVolume{
-		driverName: r.Name(),
-		name:       name,
-		path:       path,
+	// The quota is set before the data directory is created, so that the
+	// data directory inherits the quota project of the volume directory.
+	if err = v.setQuota(); err != nil {
+		return nil, err
+	}
+	if err = idtools.MkdirAllAs(path, 0755, r.rootUID, r.rootGID); err != nil {
+		return nil, errors.Wrapf(err, "error while creating volume path '%s'", path)
 	}
 
 	if len(opts) != 0 {
-		if err = setOpts(v, opts); err != nil {
-			return nil, err
-		}
 		var b []byte
 		b, err = json.Marshal(v.opts)
 		if err != nil {
@@ -264,6 +292,98 @@ func (r *Root) Scope() string {
 	return volume.LocalScope
 }
 
+// Clone creates a new volume with the given name and a copy of the data of
+// src. The new volume is always a plain local volume, even if src mounts a
+// device, but it keeps the size limit of src.
+func (r *Root) Clone(src volume.Volume, name string) (volume.Volume, map[string]string, error) {
+	lv, ok := src.(*localVolume)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown volume type %T", src)
+	}
+
+	r.m.Lock()
+	_, exists := r.volumes[name]
+	r.m.Unlock()
+	if exists {
+		return nil, nil, fmt.Errorf("volume %s already exists", name)
+	}
+
+	opts := lv.cloneOpts()
+	v, err := r.Create(name, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id := stringid.GenerateNonCryptoID()
+	path, err := lv.Mount(id)
+	if err != nil {
+		r.Remove(v)
+		return nil, nil, err
+	}
+	defer lv.Unmount(id)
+
+	if err := chrootarchive.CopyWithTar(path, v.Path()); err != nil {
+		r.Remove(v)
+		return nil, nil, errors.Wrapf(err, "error while copying data of volume %s", lv.name)
+	}
+	return v, opts, nil
+}
+
+// Export returns a tar archive of the data of the volume. The volume stays
+// mounted until the archive is closed.
+func (r *Root) Export(v volume.Volume) (io.ReadCloser, error) {
+	lv, ok := v.(*localVolume)
+	if !ok {
+		return nil, fmt.Errorf("unknown volume type %T", v)
+	}
+
+	id := stringid.GenerateNonCryptoID()
+	path, err := lv.Mount(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := archive.TarWithOptions(path, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		UIDMaps:     r.uidMaps,
+		GIDMaps:     r.gidMaps,
+	})
+	if err != nil {
+		lv.Unmount(id)
+		return nil, errors.Wrapf(err, "error while archiving volume %s", lv.name)
+	}
+	return ioutils.NewReadCloserWrapper(rc, func() error {
+		err := rc.Close()
+		lv.Unmount(id)
+		return err
+	}), nil
+}
+
+// Import extracts the given tar archive, which may be compressed, into the
+// volume.
+func (r *Root) Import(v volume.Volume, data io.Reader) error {
+	lv, ok := v.(*localVolume)
+	if !ok {
+		return fmt.Errorf("unknown volume type %T", v)
+	}
+
+	id := stringid.GenerateNonCryptoID()
+	path, err := lv.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer lv.Unmount(id)
+
+	options := &archive.TarOptions{
+		UIDMaps: r.uidMaps,
+		GIDMaps: r.gidMaps,
+	}
+	if err := chrootarchive.Untar(data, path, options); err != nil {
+		return errors.Wrapf(err, "error while extracting archive into volume %s", lv.name)
+	}
+	return nil
+}
+
 func (r *Root) validateName(name string) error {
 	if len(name) == 1 {
 		return validationError{fmt.Errorf("volume name is too short, names should be at least two alphanumeric characters")}
@@ -288,6 +408,8 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// quota limits the size of the volume, if requested in its options
+	quota *quotaCtl
 }
 
 // Name returns the name of the given Volume.
@@ -309,7 +431,7 @@ func (v *localVolume) Path() string {
 func (v *localVolume) Mount(id string) (string, error) {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		if !v.active.mounted {
 			if err := v.mount(); err != nil {
 				return "", err
@@ -325,7 +447,7 @@ func (v *localVolume) Mount(id string) (string, error) {
 func (v *localVolume) Unmount(id string) error {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		v.active.count--
 		if v.active.count == 0 {
 			if err := mount.Unmount(v.path); err != nil {
@@ -338,6 +460,22 @@ func (v *localVolume) Unmount(id string) error {
 	return nil
 }
 
+// Usage returns the disk usage of the volume. The usage of volumes with a
+// size option is read from their quota, instead of walking their files.
+func (v *localVolume) Usage() (volume.Usage, error) {
+	if _, err := os.Stat(v.path); err != nil {
+		return volume.Usage{}, err
+	}
+	if usage, ok := v.quotaUsage(); ok {
+		return usage, nil
+	}
+	size, inodes, err := directory.Usage(v.path)
+	if err != nil {
+		return volume.Usage{}, err
+	}
+	return volume.Usage{Size: size, Inodes: inodes}, nil
+}
+
 func validateOpts(opts map[string]string) error {
 	for opt := range opts {
 		if !validOpts[opt] {
@@ -347,10 +485,6 @@ func validateOpts(opts map[string]string) error {
 	return nil
 }
 
-func (v *localVolume) Status() map[string]interface{} {
-	return nil
-}
-
 // getAddress finds out address/hostname from options
 func getAddress(opts string) string {
 	optsList := strings.Split(opts, ",")
@@ -0,0 +1,61 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/docker/docker/pkg/idtools"
+)
+
+func TestImportRemapped(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("remapping the owner of the files requires root")
+	}
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	maps := []idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	r, err := New(rootDir, maps, maps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(src.Path(), "file")
+	if err := ioutil.WriteFile(file, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Lchown(file, 100001, 100001); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := r.Export(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	dst, err := r.Create("dst", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Import(dst, archive); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Lstat(filepath.Join(dst.Path(), "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := fi.Sys().(*syscall.Stat_t); st.Uid != 100001 || st.Gid != 100001 {
+		t.Fatalf("expected the imported file to be owned by 100001:100001, got %d:%d", st.Uid, st.Gid)
+	}
+}
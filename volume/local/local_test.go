@@ -6,12 +6,19 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/docker/docker/volume"
 )
 
+func init() {
+	reexec.Init()
+}
+
 func TestGetAddress(t *testing.T) {
 	cases := map[string]string{
 		"addr=11.11.11.1":   "11.11.11.1",
@@ -40,7 +47,7 @@ func TestRemove(t *testing.T) {
 	}
 	defer os.RemoveAll(rootDir)
 
-	r, err := New(rootDir, 0, 0)
+	r, err := New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -82,7 +89,7 @@ func TestInitializeWithVolumes(t *testing.T) {
 	}
 	defer os.RemoveAll(rootDir)
 
-	r, err := New(rootDir, 0, 0)
+	r, err := New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -92,7 +99,7 @@ func TestInitializeWithVolumes(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	r, err = New(rootDir, 0, 0)
+	r, err = New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -114,7 +121,7 @@ func TestCreate(t *testing.T) {
 	}
 	defer os.RemoveAll(rootDir)
 
-	r, err := New(rootDir, 0, 0)
+	r, err := New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -151,7 +158,7 @@ func TestCreate(t *testing.T) {
 		}
 	}
 
-	r, err = New(rootDir, 0, 0)
+	r, err = New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -189,7 +196,7 @@ func TestCreateWithOpts(t *testing.T) {
 	}
 	defer os.RemoveAll(rootDir)
 
-	r, err := New(rootDir, 0, 0)
+	r, err := New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -270,7 +277,7 @@ func TestCreateWithOpts(t *testing.T) {
 		t.Fatal("expected mount to still be active")
 	}
 
-	r, err = New(rootDir, 0, 0)
+	r, err = New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -292,7 +299,7 @@ func TestRealodNoOpts(t *testing.T) {
 	}
 	defer os.RemoveAll(rootDir)
 
-	r, err := New(rootDir, 0, 0)
+	r, err := New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -320,7 +327,7 @@ func TestRealodNoOpts(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	r, err = New(rootDir, 0, 0)
+	r, err = New(rootDir, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -342,3 +349,171 @@ func TestRealodNoOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestCloneExportImport(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(src.Path(), "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "dir", "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, _, err := r.Clone(src, "clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := r.Clone(src, "clone"); err == nil {
+		t.Fatal("Expected error cloning into an existing volume, got nil")
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "dir", "file"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(clone.Path(), "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("Expected the clone to hold a copy of the data, got %q", b)
+	}
+
+	archive, err := r.Export(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	dst, err := r.Create("dst", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Import(dst, archive); err != nil {
+		t.Fatal(err)
+	}
+	b, err = ioutil.ReadFile(filepath.Join(dst.Path(), "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "changed" {
+		t.Fatalf("Expected the imported volume to hold the exported data, got %q", b)
+	}
+}
+
+func TestCreateWithSize(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip()
+	}
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []map[string]string{
+		{"size": "invalid"},
+		{"size": "0"},
+		{"size": "10m", "device": "tmpfs", "type": "tmpfs"},
+	} {
+		if _, err := r.Create("test", opts); err == nil {
+			t.Fatalf("expected options %v to cause error", opts)
+		}
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "10m"})
+	if err != nil {
+		// the quota is not supported on the filesystem of the test directory
+		if _, err := os.Stat(filepath.Join(r.path, "test")); !os.IsNotExist(err) {
+			t.Fatalf("expected the volume directory to be removed, got %v", err)
+		}
+		if _, exists := r.volumes["test"]; exists {
+			t.Fatal("expected the volume to not be created")
+		}
+		t.Skipf("volume size quotas are not supported: %v", err)
+	}
+	v := vol.(*localVolume)
+
+	if v.needsMount() {
+		t.Fatal("expected a volume with a size to not be mounted")
+	}
+	if size := v.Status()["Size"]; size != uint64(10*1024*1024) {
+		t.Fatalf("expected a size of 10m in the volume status, got %v", size)
+	}
+
+	clone, opts, err := r.Clone(v, "clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := clone.(*localVolume).Status()["Size"]; size != uint64(10*1024*1024) {
+		t.Fatalf("expected the clone to keep the size of 10m, got %v", size)
+	}
+	if opts["size"] != strconv.Itoa(10*1024*1024) {
+		t.Fatalf("expected the clone to be created with the size of 10m, got %v", opts)
+	}
+
+	r, err = New(rootDir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, exists := r.volumes["test"]
+	if !exists {
+		t.Fatal("missing volume on restart")
+	}
+	if !reflect.DeepEqual(v.opts, v2.opts) {
+		t.Fatal("missing volume options on restart")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vol, err := r.Create("test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(vol.Path(), "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := vol.(volume.UsageVolume).Usage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Size != 4 || usage.Inodes != 2 {
+		t.Fatalf("expected a usage of 4 bytes and 2 inodes, got %v", usage)
+	}
+
+	if err := r.Remove(vol); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vol.(volume.UsageVolume).Usage(); err == nil {
+		t.Fatal("expected an error getting the usage of a removed volume")
+	}
+}
@@ -9,11 +9,15 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/docker/volume"
+	units "github.com/docker/go-units"
 )
 
 var (
@@ -23,6 +27,7 @@ var (
 		"type":   true, // specify the filesystem type for mount, e.g. nfs
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
+		"size":   true, // size limit of the volume, enforced with a quota
 	}
 )
 
@@ -30,6 +35,7 @@ type optsConfig struct {
 	MountType   string
 	MountOpts   string
 	MountDevice string
+	Size        uint64 `json:",omitempty"`
 }
 
 func (o *optsConfig) String() string {
@@ -65,9 +71,77 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+	if val, ok := opts["size"]; ok {
+		if v.needsMount() {
+			return validationError{fmt.Errorf("the size option cannot be combined with the type, o and device options")}
+		}
+		size, err := units.RAMInBytes(val)
+		if err != nil {
+			return validationError{errors.Wrapf(err, "invalid size: %q", val)}
+		}
+		if size <= 0 {
+			return validationError{fmt.Errorf("invalid size: %q, the size must be positive", val)}
+		}
+		v.opts.Size = uint64(size)
+	}
 	return nil
 }
 
+// cloneOpts returns the options of a copy of the volume: the size limit,
+// but not the options to mount a device.
+func (v *localVolume) cloneOpts() map[string]string {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	return map[string]string{"size": strconv.FormatUint(v.opts.Size, 10)}
+}
+
+// needsMount returns whether the volume is mounted from a device, as opposed
+// to a directory under the volumes directory, possibly with a size quota.
+func (v *localVolume) needsMount() bool {
+	return v.opts != nil && (v.opts.MountType != "" || v.opts.MountOpts != "" || v.opts.MountDevice != "")
+}
+
+// setQuota limits the size of the volume directory, if a size is set in the
+// options of the volume.
+func (v *localVolume) setQuota() error {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	return v.quota.setQuota(filepath.Dir(v.path), v.opts.Size)
+}
+
+// Status returns the size limit and the usage of volumes with a size option.
+func (v *localVolume) Status() map[string]interface{} {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	size, usage, _, err := v.quota.getQuota(filepath.Dir(v.path))
+	if err != nil {
+		logrus.Warnf("error getting the quota of volume %s: %v", v.name, err)
+		return map[string]interface{}{"Size": v.opts.Size}
+	}
+	return map[string]interface{}{
+		"Size":  size,
+		"Usage": usage,
+	}
+}
+
+// quotaUsage returns the usage of volumes with a size option, as accounted by
+// their quota. It returns false for other volumes, or if the quota cannot be
+// read.
+func (v *localVolume) quotaUsage() (volume.Usage, bool) {
+	if v.opts == nil || v.opts.Size == 0 {
+		return volume.Usage{}, false
+	}
+	_, size, inodes, err := v.quota.getQuota(filepath.Dir(v.path))
+	if err != nil {
+		logrus.Warnf("error getting the quota of volume %s: %v", v.name, err)
+		return volume.Usage{}, false
+	}
+	return volume.Usage{Size: int64(size), Inodes: int64(inodes)}, true
+}
+
 func (v *localVolume) mount() error {
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+
+	"github.com/docker/docker/volume"
 )
 
 type optsConfig struct{}
@@ -29,6 +31,26 @@ func setOpts(v *localVolume, opts map[string]string) error {
 	return nil
 }
 
+func (v *localVolume) cloneOpts() map[string]string {
+	return nil
+}
+
 func (v *localVolume) mount() error {
 	return nil
 }
+
+func (v *localVolume) needsMount() bool {
+	return v.opts != nil
+}
+
+func (v *localVolume) setQuota() error {
+	return nil
+}
+
+func (v *localVolume) quotaUsage() (volume.Usage, bool) {
+	return volume.Usage{}, false
+}
+
+func (v *localVolume) Status() map[string]interface{} {
+	return nil
+}
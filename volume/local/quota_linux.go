@@ -0,0 +1,76 @@
+// +build linux
+
+package local
+
+import (
+	"sync"
+
+	"github.com/docker/docker/daemon/graphdriver/quota"
+	"github.com/pkg/errors"
+)
+
+// quotaProjectIDOffset separates the project ids of the volumes from those
+// of the storage driver, which starts right after the project id of its home
+// directory, when both use quotas on the same filesystem.
+const quotaProjectIDOffset = 1 << 24
+
+// quotaCtl limits the size of the volumes created with a size option with
+// XFS project quotas. The project quota control of the volumes directory is
+// only initialized once such a volume is created or inspected, so that
+// nothing is set up on filesystems which are not used with quotas.
+type quotaCtl struct {
+	sync.Mutex
+	home string
+	ctl  *quota.Control
+	err  error
+}
+
+func newQuotaCtl(home string) *quotaCtl {
+	return &quotaCtl{home: home}
+}
+
+// control returns the project quota control of the volumes directory.
+// Callers of this function are expected to hold the lock.
+func (q *quotaCtl) control() (*quota.Control, error) {
+	if q.ctl == nil && q.err == nil {
+		q.ctl, q.err = quota.NewControlWithOffset(q.home, quotaProjectIDOffset)
+		if q.err != nil {
+			q.err = errors.Wrapf(q.err, "volume size quotas are not supported on the filesystem of %s, which must be xfs mounted with the pquota option", q.home)
+		}
+	}
+	return q.ctl, q.err
+}
+
+// setQuota limits the size of the data stored under path. Only the files
+// created under path after the call count towards the limit.
+func (q *quotaCtl) setQuota(path string, size uint64) error {
+	q.Lock()
+	defer q.Unlock()
+
+	ctl, err := q.control()
+	if err != nil {
+		return err
+	}
+	return ctl.SetQuota(path, quota.Quota{Size: size})
+}
+
+// getQuota returns the size limit, and the space and the number of inodes
+// used by a path configured with setQuota.
+func (q *quotaCtl) getQuota(path string) (size, usage, inodes uint64, err error) {
+	q.Lock()
+	defer q.Unlock()
+
+	ctl, err := q.control()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	var limit quota.Quota
+	if err := ctl.GetQuota(path, &limit); err != nil {
+		return 0, 0, 0, err
+	}
+	var u quota.Usage
+	if err := ctl.GetUsage(path, &u); err != nil {
+		return 0, 0, 0, err
+	}
+	return limit.Size, u.Size, u.Inodes, nil
+}
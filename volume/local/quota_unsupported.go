@@ -0,0 +1,21 @@
+// +build !linux
+
+package local
+
+import "errors"
+
+var errQuotaNotSupported = errors.New("volume size quotas are not supported on this platform")
+
+type quotaCtl struct{}
+
+func newQuotaCtl(home string) *quotaCtl {
+	return &quotaCtl{}
+}
+
+func (q *quotaCtl) setQuota(path string, size uint64) error {
+	return errQuotaNotSupported
+}
+
+func (q *quotaCtl) getQuota(path string) (size, usage, inodes uint64, err error) {
+	return 0, 0, 0, errQuotaNotSupported
+}
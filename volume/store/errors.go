@@ -3,6 +3,7 @@ package store
 import (
 	"strings"
 
+	"github.com/docker/docker/volume"
 	"github.com/pkg/errors"
 )
 
@@ -64,6 +65,12 @@ func IsNameConflict(err error) bool {
 	return isErr(err, errNameConflict)
 }
 
+// IsNotSupported returns a boolean indicating whether the error indicates that
+// the volume driver does not support the operation
+func IsNotSupported(err error) bool {
+	return isErr(err, volume.ErrNotSupported)
+}
+
 func isErr(err error, expected error) bool {
 	err = errors.Cause(err)
 	switch pe := err.(type) {
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -11,7 +12,9 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/boltdb/bolt"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/locker"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
 )
@@ -61,6 +64,8 @@ func New(rootPath string) (*VolumeStore, error) {
 		refs:    make(map[string]map[string]struct{}),
 		labels:  make(map[string]map[string]string),
 		options: make(map[string]map[string]string),
+		usage:   newUsageCache(),
+		done:    make(chan struct{}),
 	}
 
 	if rootPath != "" {
@@ -90,6 +95,7 @@ func New(rootPath string) (*VolumeStore, error) {
 	}
 
 	vs.restore()
+	go vs.refreshUsageLoop()
 
 	return vs, nil
 }
@@ -156,6 +162,7 @@ func (s *VolumeStore) Purge(name string) {
 	delete(s.labels, name)
 	delete(s.options, name)
 	s.globalLock.Unlock()
+	s.usage.remove(name)
 }
 
 // VolumeStore is a struct that stores the list of volumes available and keeps track of their usage counts
@@ -174,7 +181,12 @@ type VolumeStore struct {
 	labels map[string]map[string]string
 	// options stores volume options for each volume
 	options map[string]map[string]string
-	db      *bolt.DB
+	// usage caches the disk usage of volumes
+	usage *usageCache
+	// done is closed when the store is shut down
+	done         chan struct{}
+	shutdownOnce sync.Once
+	db           *bolt.DB
 }
 
 // List proxies to all registered volume drivers to get the full list of volumes
@@ -606,6 +618,159 @@ func (s *VolumeStore) Refs(v volume.Volume) []string {
 	return s.getRefs(name)
 }
 
+// acquire gets the volume with the given name and adds a reference to it
+// for the given operation, so that the volume is not removed while the
+// operation uses its data. The returned function releases the reference.
+func (s *VolumeStore) acquire(name, op string) (volume.Volume, func(), error) {
+	name = normaliseVolumeName(name)
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	v, err := s.getVolume(name)
+	if err != nil {
+		return nil, nil, &OpErr{Err: err, Name: name, Op: op}
+	}
+
+	ref := op + "-" + stringid.GenerateNonCryptoID()
+	s.setNamed(v, ref)
+	return v, func() { s.Dereference(v, ref) }, nil
+}
+
+// Clone creates a volume with the given name and labels, holding a copy of
+// the data of the source volume. The volume is created by the driver of the
+// source volume, which must implement volume.Cloner.
+func (s *VolumeStore) Clone(source, name string, labels map[string]string) (_ volume.Volume, err error) {
+	src, release, err := s.acquire(source, "clone")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	name = normaliseVolumeName(name)
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	valid, err := volume.IsVolumeNameValid(name)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, &OpErr{Err: errInvalidName, Name: name, Op: "clone"}
+	}
+
+	vd, err := volumedrivers.CreateDriver(src.DriverName())
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: "clone"}
+	}
+	defer func() {
+		if err != nil {
+			volumedrivers.RemoveDriver(vd.Name())
+		}
+	}()
+
+	cloner, ok := vd.(volume.Cloner)
+	if !ok {
+		return nil, &OpErr{Err: volume.ErrNotSupported, Name: src.Name(), Op: "clone"}
+	}
+
+	if v, err := s.checkConflict(name, vd.Name()); err != nil {
+		return nil, err
+	} else if v != nil {
+		return nil, &OpErr{Err: errNameConflict, Name: name, Op: "clone"}
+	}
+	if v, _ := vd.Get(name); v != nil {
+		return nil, &OpErr{Err: errNameConflict, Name: name, Op: "clone"}
+	}
+
+	logrus.Debugf("Cloning volume: driver %q, source %q, name %q", vd.Name(), src.Name(), name)
+	v, opts, err := cloner.Clone(unwrapVolume(src), name)
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: "clone"}
+	}
+	defer func() {
+		if err != nil {
+			if rmErr := vd.Remove(v); rmErr != nil {
+				logrus.Errorf("Error removing volume %s after failing to clone it: %v", name, rmErr)
+			}
+			s.Purge(name)
+		}
+	}()
+
+	s.globalLock.Lock()
+	s.labels[name] = labels
+	s.options[name] = opts
+	s.refs[name] = make(map[string]struct{})
+	s.globalLock.Unlock()
+
+	metadata := volumeMetadata{
+		Name:    name,
+		Driver:  vd.Name(),
+		Labels:  labels,
+		Options: opts,
+	}
+	if err := s.setMeta(name, metadata); err != nil {
+		return nil, err
+	}
+	return volumeWrapper{v, labels, vd.Scope(), opts}, nil
+}
+
+// Export returns a tar archive of the data of the volume with the given
+// name. The volume is not removed until the archive is closed.
+func (s *VolumeStore) Export(name string) (io.ReadCloser, error) {
+	v, release, err := s.acquire(name, "export")
+	if err != nil {
+		return nil, err
+	}
+
+	archiver, err := getArchiver(v)
+	if err != nil {
+		release()
+		return nil, &OpErr{Err: err, Name: v.Name(), Op: "export"}
+	}
+
+	rc, err := archiver.Export(unwrapVolume(v))
+	if err != nil {
+		release()
+		return nil, &OpErr{Err: err, Name: v.Name(), Op: "export"}
+	}
+	return ioutils.NewReadCloserWrapper(rc, func() error {
+		defer release()
+		return rc.Close()
+	}), nil
+}
+
+// Import extracts the given tar archive into the volume with the given name.
+func (s *VolumeStore) Import(name string, data io.Reader) error {
+	v, release, err := s.acquire(name, "import")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	archiver, err := getArchiver(v)
+	if err != nil {
+		return &OpErr{Err: err, Name: v.Name(), Op: "import"}
+	}
+	if err := archiver.Import(unwrapVolume(v), data); err != nil {
+		return &OpErr{Err: err, Name: v.Name(), Op: "import"}
+	}
+	return nil
+}
+
+// getArchiver returns the driver of the given volume if it implements
+// volume.Archiver.
+func getArchiver(v volume.Volume) (volume.Archiver, error) {
+	vd, err := volumedrivers.GetDriver(v.DriverName())
+	if err != nil {
+		return nil, err
+	}
+	archiver, ok := vd.(volume.Archiver)
+	if !ok {
+		return nil, volume.ErrNotSupported
+	}
+	return archiver, nil
+}
+
 // FilterByDriver returns the available volumes filtered by driver name
 func (s *VolumeStore) FilterByDriver(name string) ([]volume.Volume, error) {
 	vd, err := volumedrivers.GetDriver(name)
@@ -665,5 +830,8 @@ func unwrapVolume(v volume.Volume) volume.Volume {
 // Shutdown releases all resources used by the volume store
 // It does not make any changes to volumes, drivers, etc.
 func (s *VolumeStore) Shutdown() error {
+	s.shutdownOnce.Do(func() {
+		close(s.done)
+	})
 	return s.db.Close()
 }
@@ -6,7 +6,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/volume"
 	"github.com/docker/docker/volume/drivers"
 	volumetestutils "github.com/docker/docker/volume/testutils"
 )
@@ -232,3 +234,204 @@ func TestDerefMultipleOfSameRef(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestClone(t *testing.T) {
+	volumedrivers.Register(volumetestutils.NewFakeDriver("fakeclone"), "fakeclone")
+	defer volumedrivers.Unregister("fakeclone")
+	dir, err := ioutil.TempDir("", "test-clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create("clonesrc", "fakeclone", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := s.Clone("clonesrc", "clonedst", map[string]string{"backup": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Name() != "clonedst" || v.DriverName() != "fakeclone" {
+		t.Fatalf("Expected clonedst volume of the fakeclone driver, got %v", v)
+	}
+	if labels := v.(volume.DetailedVolume).Labels(); labels["backup"] != "true" {
+		t.Fatalf("Expected the labels of the clone to be set, got %v", labels)
+	}
+	if opts := v.(volume.DetailedVolume).Options(); opts["source"] != "clonesrc" {
+		t.Fatalf("Expected the options the clone was created with, got %v", opts)
+	}
+	if meta, err := s.getMeta("clonedst"); err != nil || meta.Options["source"] != "clonesrc" {
+		t.Fatalf("Expected the options of the clone to be stored, got %v: %v", meta.Options, err)
+	}
+	if l, _ := s.FilterByDriver("fakeclone"); len(l) != 2 {
+		t.Fatalf("Expected 2 volumes in the store, got %v: %v", len(l), l)
+	}
+
+	if _, err := s.Clone("clonesrc", "clonedst", nil); !IsNameConflict(err) {
+		t.Fatalf("Expected name conflict error, got %v", err)
+	}
+	if _, err := s.Clone("missing", "clonedst2", nil); err == nil {
+		t.Fatal("Expected error cloning a missing volume, got nil")
+	}
+
+	// the source volume can be removed once the clone is done
+	src, err := s.Get("clonesrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(src); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportNotSupported(t *testing.T) {
+	volumedrivers.Register(volumetestutils.NewFakeDriver("fakearchive"), "fakearchive")
+	defer volumedrivers.Unregister("fakearchive")
+	dir, err := ioutil.TempDir("", "test-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := s.Create("archived", "fakearchive", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Export("archived"); !IsNotSupported(err) {
+		t.Fatalf("Expected not supported error, got %v", err)
+	}
+	if err := s.Import("archived", strings.NewReader("")); !IsNotSupported(err) {
+		t.Fatalf("Expected not supported error, got %v", err)
+	}
+	if err := s.Remove(v); err != nil {
+		t.Fatalf("Expected the volume to be released after a failed export, got %v", err)
+	}
+}
+
+type usageVolume struct {
+	volume.Volume
+	usage volume.Usage
+	err   error
+}
+
+func (v *usageVolume) Usage() (volume.Usage, error) {
+	return v.usage, v.err
+}
+
+func TestUsage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-usage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Shutdown()
+
+	if _, err := s.Usage(volumetestutils.NewFakeVolume("fake1", "fake"), false); !IsNotSupported(err) {
+		t.Fatalf("Expected not supported error, got %v", err)
+	}
+
+	v := &usageVolume{Volume: volumetestutils.NewFakeVolume("used", "fake"), usage: volume.Usage{Size: 10, Inodes: 1}}
+	if _, cached := s.CachedUsage(v); cached {
+		t.Fatal("Expected no cached usage before the usage is determined")
+	}
+	usage, err := s.Usage(v, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Size != 10 || usage.Inodes != 1 {
+		t.Fatalf("Expected usage {10 1}, got %v", usage)
+	}
+
+	v.usage = volume.Usage{Size: 20, Inodes: 2}
+	if usage, _ := s.Usage(v, false); usage.Size != 10 {
+		t.Fatalf("Expected the cached usage, got %v", usage)
+	}
+	if usage, cached := s.CachedUsage(v); !cached || usage.Size != 10 {
+		t.Fatalf("Expected the cached usage, got %v", usage)
+	}
+	if usage, _ := s.Usage(v, true); usage.Size != 20 || usage.Inodes != 2 {
+		t.Fatalf("Expected a refreshed usage, got %v", usage)
+	}
+
+	v.usage = volume.Usage{Size: 30, Inodes: 3}
+	s.refreshUsage()
+	if usage, _ := s.Usage(v, false); usage.Size != 30 {
+		t.Fatalf("Expected the usage refreshed in the background, got %v", usage)
+	}
+
+	v.err = errors.New("usage error")
+	s.refreshUsage()
+	if _, exists := s.usage.get("used"); exists {
+		t.Fatal("Expected the volume to be dropped from the cache after an error")
+	}
+	if _, err := s.Usage(v, false); err == nil {
+		t.Fatal("Expected usage error, got nil")
+	}
+
+	v.err = nil
+	if _, err := s.Usage(v, false); err != nil {
+		t.Fatal(err)
+	}
+	s.Purge("used")
+	if _, exists := s.usage.get("used"); exists {
+		t.Fatal("Expected the volume to be dropped from the cache when purged")
+	}
+}
+
+func TestUsageWarmedOnStart(t *testing.T) {
+	v := &usageVolume{Volume: volumetestutils.NewFakeVolume("warm", "fake"), usage: volume.Usage{Size: 10, Inodes: 1}}
+	s := &VolumeStore{
+		names: map[string]volume.Volume{"warm": v},
+		usage: newUsageCache(),
+		done:  make(chan struct{}),
+	}
+	defer close(s.done)
+	go s.refreshUsageLoop()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		if usage, cached := s.CachedUsage(v); cached {
+			if usage.Size != 10 || usage.Inodes != 1 {
+				t.Fatalf("Expected usage {10 1}, got %v", usage)
+			}
+			return
+		}
+		select {
+		case <-timeout:
+			t.Fatal("Timeout waiting for the usage to be cached")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestShutdownTwice(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-shutdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	s.Shutdown()
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/volume"
+)
+
+// usageRefreshInterval is the interval at which the cached disk usage of the
+// volumes is refreshed in the background.
+var usageRefreshInterval = 5 * time.Minute
+
+// usageCache caches the disk usage of volumes, since determining it may
+// require walking all the files of a volume.
+type usageCache struct {
+	sync.Mutex
+	entries map[string]usageEntry
+}
+
+type usageEntry struct {
+	vol   volume.UsageVolume
+	usage volume.Usage
+}
+
+func newUsageCache() *usageCache {
+	return &usageCache{entries: make(map[string]usageEntry)}
+}
+
+func (c *usageCache) get(name string) (volume.Usage, bool) {
+	c.Lock()
+	e, exists := c.entries[name]
+	c.Unlock()
+	return e.usage, exists
+}
+
+func (c *usageCache) remove(name string) {
+	c.Lock()
+	delete(c.entries, name)
+	c.Unlock()
+}
+
+// update determines the usage of the given volume and caches it. The volume
+// is dropped from the cache if its usage cannot be determined, for example
+// because it was removed.
+func (c *usageCache) update(name string, v volume.UsageVolume) (volume.Usage, error) {
+	usage, err := v.Usage()
+
+	c.Lock()
+	defer c.Unlock()
+	if err != nil {
+		delete(c.entries, name)
+		return volume.Usage{}, err
+	}
+	c.entries[name] = usageEntry{vol: v, usage: usage}
+	return usage, nil
+}
+
+// Usage returns the disk usage of the given volume. The usage is cached and
+// refreshed periodically. It is determined again before returning if refresh
+// is true, or if it is not cached yet.
+// Volumes which cannot determine their usage return volume.ErrNotSupported.
+func (s *VolumeStore) Usage(v volume.Volume, refresh bool) (volume.Usage, error) {
+	name := normaliseVolumeName(v.Name())
+	uv, ok := unwrapVolume(v).(volume.UsageVolume)
+	if !ok {
+		return volume.Usage{}, &OpErr{Err: volume.ErrNotSupported, Name: name, Op: "usage"}
+	}
+
+	if !refresh {
+		if usage, exists := s.usage.get(name); exists {
+			return usage, nil
+		}
+	}
+	usage, err := s.usage.update(name, uv)
+	if err != nil {
+		return volume.Usage{}, &OpErr{Err: err, Name: name, Op: "usage"}
+	}
+	return usage, nil
+}
+
+// CachedUsage returns the cached disk usage of the given volume, without
+// determining it. It returns false if the usage of the volume is not cached
+// yet, or if the volume cannot determine its usage.
+func (s *VolumeStore) CachedUsage(v volume.Volume) (volume.Usage, bool) {
+	return s.usage.get(normaliseVolumeName(v.Name()))
+}
+
+// refreshUsage determines again the usage of the volumes in the cache, and
+// of the volumes known to the store.
+func (s *VolumeStore) refreshUsage() {
+	vols := make(map[string]volume.UsageVolume)
+	s.usage.Lock()
+	for name, e := range s.usage.entries {
+		vols[name] = e.vol
+	}
+	s.usage.Unlock()
+
+	s.globalLock.RLock()
+	for name, v := range s.names {
+		if uv, ok := unwrapVolume(v).(volume.UsageVolume); ok {
+			vols[name] = uv
+		}
+	}
+	s.globalLock.RUnlock()
+
+	for name, v := range vols {
+		if _, err := s.usage.update(name, v); err != nil {
+			logrus.WithError(err).WithField("volume", name).Debug("Error while refreshing volume usage")
+		}
+	}
+}
+
+// refreshUsageLoop fills the cache with the usage of the volumes when the
+// store is created, then refreshes it every usageRefreshInterval, until the
+// store is shut down.
+func (s *VolumeStore) refreshUsageLoop() {
+	s.refreshUsage()
+
+	ticker := time.NewTicker(usageRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.refreshUsage()
+		case <-s.done:
+			return
+		}
+	}
+}
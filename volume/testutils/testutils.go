@@ -114,3 +114,16 @@ func (d *FakeDriver) Get(name string) (volume.Volume, error) {
 func (*FakeDriver) Scope() string {
 	return "local"
 }
+
+// Clone creates a fake volume with the given name, as a copy of src. The
+// options of the new volume name src.
+func (d *FakeDriver) Clone(src volume.Volume, name string) (volume.Volume, map[string]string, error) {
+	if _, exists := d.vols[src.Name()]; !exists {
+		return nil, nil, fmt.Errorf("no such volume")
+	}
+	v, err := d.Create(name, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, map[string]string{"source": src.Name()}, nil
+}
@@ -2,6 +2,7 @@ package volume
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,6 +26,10 @@ const (
 	GlobalScope = "global"
 )
 
+// ErrNotSupported is returned when a driver does not support an optional
+// operation, such as cloning or archiving a volume.
+var ErrNotSupported = errors.New("operation not supported by the volume driver")
+
 // Driver is for creating and removing volumes.
 type Driver interface {
 	// Name returns the name of the volume driver.
@@ -49,6 +54,31 @@ type Capability struct {
 	// A `local` scope indicates that the driver only manages volumes resources local to the host
 	// Scope is declared by the driver
 	Scope string
+	// Clone indicates that the driver can create a volume with a copy of
+	// the data of an existing volume
+	Clone bool
+	// Archive indicates that the driver can export and import the data of
+	// its volumes as tar archives
+	Archive bool
+}
+
+// Cloner is implemented by drivers which can create a volume with a copy
+// of the data of an existing volume.
+type Cloner interface {
+	// Clone creates a new volume with the given name, holding a copy of
+	// the data of the src volume at the time of the call. It returns the
+	// options the new volume was created with.
+	Clone(src Volume, name string) (Volume, map[string]string, error)
+}
+
+// Archiver is implemented by drivers which can export and import the data
+// of their volumes as tar archives.
+type Archiver interface {
+	// Export returns a tar archive of the data of the volume.
+	Export(vol Volume) (io.ReadCloser, error)
+	// Import extracts the given tar archive into the volume, replacing
+	// the existing files with the same path.
+	Import(vol Volume, data io.Reader) error
 }
 
 // Volume is a place to store data. It is backed by a specific driver, and can be mounted.
@@ -76,6 +106,23 @@ type DetailedVolume interface {
 	Volume
 }
 
+// Usage is the disk usage of a volume.
+type Usage struct {
+	// Size is the disk space used by the volume, in bytes.
+	Size int64
+	// Inodes is the number of inodes used by the volume.
+	Inodes int64
+}
+
+// UsageVolume is implemented by volumes which can determine their disk
+// usage.
+type UsageVolume interface {
+	// Usage returns the disk usage of the volume. It may be slow, as it
+	// can walk all the files of the volume.
+	Usage() (Usage, error)
+	Volume
+}
+
 // MountPoint is the intersection point between a volume and a container. It
 // specifies which volume is to be used and where inside a container it should
 // be mounted.